@@ -0,0 +1,142 @@
+// activesum.go: Live SHA-256 sidecar for the active file (ChecksumInterval)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// activeChecksumSuffix is appended to Filename for the live checksum.
+const activeChecksumSuffix = ".sha256"
+
+// activeChecksum carries a running SHA-256 over the active file.
+//
+// WHY incremental: the file only grows between rotations, so each update
+// hashes just the bytes appended since the last one instead of re-reading
+// the whole file every interval.
+type activeChecksum struct {
+	mu     sync.Mutex
+	file   *os.File  // Active file the running hash covers
+	offset int64     // Bytes hashed so far
+	hash   hash.Hash // nil until the first update
+	saved  bool      // Sidecar written for the current offset
+
+	// Set by Truncate: the same file now holds different bytes
+	restart atomic.Bool
+}
+
+// activeChecksumPath returns the path of the live checksum sidecar.
+func (l *Logger) activeChecksumPath() string {
+	return l.Filename + activeChecksumSuffix
+}
+
+// updateActiveChecksum hashes what was appended to the active file since
+// the last call and atomically rewrites the sidecar. It does nothing while
+// failed over or when the active file is being swapped.
+func (l *Logger) updateActiveChecksum() {
+	s := &l.activeSum
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := l.currentFile.Load()
+	if active == nil || l.failedOver.Load() {
+		return
+	}
+	// Checksum what callers were told was written
+	if err := l.flushSyncBuffer(); err != nil && !isFileAlreadyClosedError(err) {
+		l.reportError("active_checksum", err)
+	}
+
+	// The active handle is write-only, so read through a second one
+	f, err := os.Open(l.Filename) // #nosec G304 -- Filename is the configured log path
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.reportError("active_checksum", err)
+		}
+		return
+	}
+	defer func() { _ = f.Close() }() // Read-only; close error is not actionable
+
+	info, err := f.Stat()
+	if err != nil {
+		l.reportError("active_checksum", err)
+		return
+	}
+	if activeInfo, err := active.Stat(); err != nil || !os.SameFile(info, activeInfo) {
+		return // Rotated or recreated since the load above; the next tick catches up
+	}
+
+	if s.restart.Swap(false) || s.hash == nil || s.file != active || info.Size() < s.offset {
+		s.file, s.offset, s.saved = active, 0, false
+		s.hash = sha256.New()
+	}
+	if info.Size() == s.offset && s.saved {
+		return // Nothing new since the last sidecar
+	}
+
+	n, err := io.Copy(s.hash, io.NewSectionReader(f, s.offset, info.Size()-s.offset))
+	s.offset += n
+	if err != nil {
+		s.hash = nil // Hashed a partial range; start over next time
+		l.reportError("active_checksum", err)
+		return
+	}
+
+	content := fmt.Sprintf("%x  %s\n", s.hash.Sum(nil), filepath.Base(l.Filename))
+	path := l.activeChecksumPath()
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(content), l.backupFileMode()); err != nil {
+		l.reportError("active_checksum", fmt.Errorf("failed to write checksum file %s: %v", tmpPath, err))
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath) // Ignore remove error during cleanup
+		l.reportError("active_checksum", fmt.Errorf("failed to rename checksum file %s: %v", path, err))
+		return
+	}
+	s.saved = true
+}
+
+// startActiveChecksum launches the periodic checksum goroutine once per Logger.
+func (l *Logger) startActiveChecksum() {
+	if l.ChecksumInterval <= 0 || l.activeSumLoop.Load() != nil {
+		return
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	if !l.activeSumLoop.CompareAndSwap(nil, s) {
+		return // Someone else started it
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(l.ChecksumInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if l.enterFS() { // Skipped while paused
+					l.updateActiveChecksum()
+					l.exitFS()
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,116 @@
+// activesum_test.go: Tests for the live checksum of the active file
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// wantSidecar returns the expected sidecar content for logFile's bytes.
+func wantSidecar(t *testing.T, logFile string) string {
+	t.Helper()
+	return fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte(readLog(t, logFile))), filepath.Base(logFile))
+}
+
+// waitSidecar polls until the sidecar matches logFile's current content.
+func waitSidecar(t *testing.T, logFile string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got, _ := os.ReadFile(logFile + activeChecksumSuffix)
+		want := wantSidecar(t, logFile)
+		if string(got) == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sidecar = %q, want %q", got, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestChecksumInterval_TracksActiveFile(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		ChecksumInterval: 10 * time.Millisecond,
+	})
+
+	if _, err := logger.Write([]byte("first entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitSidecar(t, logFile)
+
+	// Appended bytes extend the running hash
+	if _, err := logger.Write([]byte("second entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitSidecar(t, logFile)
+
+	// A new active file starts a new hash
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	if _, err := logger.Write([]byte("after rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitSidecar(t, logFile)
+
+	if err := logger.Truncate(); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	if _, err := logger.Write([]byte("after truncate, longer than before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitSidecar(t, logFile)
+}
+
+// TestChecksumInterval_FinalOnClose verifies Close leaves a sidecar that
+// matches the final file, even when no interval elapsed.
+func TestChecksumInterval_FinalOnClose(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "audit.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		Async:            true,
+		ChecksumInterval: time.Hour,
+	})
+	for i := 0; i < 100; i++ {
+		if _, err := fmt.Fprintf(logger, "entry %d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got, err := os.ReadFile(logFile + activeChecksumSuffix)
+	if err != nil {
+		t.Fatalf("sidecar missing after Close: %v", err)
+	}
+	if want := wantSidecar(t, logFile); string(got) != want {
+		t.Errorf("sidecar = %q, want %q", got, want)
+	}
+}
+
+func TestChecksumInterval_OffByDefault(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "plain.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Checksum: true})
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(logFile + activeChecksumSuffix); !os.IsNotExist(err) {
+		t.Errorf("live sidecar written without ChecksumInterval (err=%v)", err)
+	}
+}
@@ -0,0 +1,91 @@
+// appendatomic_test.go: Tests that concurrent large writes are never torn
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// tornLineSize is well above PIPE_BUF (4096 on Linux), the largest write
+// POSIX promises to keep atomic on pipes.
+const tornLineSize = 64 << 10
+
+// writeLargeLines has each writer write lines made of its own letter.
+func writeLargeLines(t *testing.T, logger *Logger, writers, perWriter int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		line := append(bytes.Repeat([]byte{byte('A' + w)}, tornLineSize-1), '\n')
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := logger.Write(line); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// checkWholeLines verifies every line in the log file and its backups is
+// one writer's line, complete, and that all of them arrived.
+func checkWholeLines(t *testing.T, logFile string, want int) {
+	t.Helper()
+	paths, _ := filepath.Glob(logFile + "*")
+	got := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		for _, line := range strings.SplitAfter(string(data), "\n") {
+			if line == "" {
+				continue
+			}
+			if len(line) != tornLineSize || strings.Trim(line[:len(line)-1], line[:1]) != "" {
+				t.Fatalf("torn line in %s: %d bytes starting %q", filepath.Base(path), len(line), line[:min(len(line), 16)])
+			}
+			got++
+		}
+	}
+	if got != want {
+		t.Errorf("found %d whole lines, want %d", got, want)
+	}
+}
+
+func TestAppendAtomicity_ConcurrentLargeWrites(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  LoggerConfig
+	}{
+		{"sync", LoggerConfig{DisableAutoScale: true}},
+		{"sync_rotating", LoggerConfig{DisableAutoScale: true, MaxSizeStr: "1MB"}},
+		{"async", LoggerConfig{Async: true}},
+		{"buffered_sync", LoggerConfig{BufferedSync: true, SyncBufferSize: 8 << 10}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Filename = filepath.Join(t.TempDir(), "app.log")
+			logger := newTestLogger(t, &cfg)
+
+			const writers, perWriter = 8, 16
+			writeLargeLines(t, logger, writers, perWriter)
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			checkWholeLines(t, cfg.Filename, writers*perWriter)
+		})
+	}
+}
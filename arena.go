@@ -0,0 +1,158 @@
+// arena.go: Inline byte-arena storage for the MPSC ring (ArenaSlotSize)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// maxArenaSlotSize bounds ArenaSlotSize: the arena is allocated up front,
+// so large messages belong in the pointer ring.
+const maxArenaSlotSize = 1 << 20
+
+// newRing creates the MPSC ring configured for l: an arena ring when
+// ArenaSlotSize is set, else the pointer ring.
+func (l *Logger) newRing(slots uint64) *ringBuffer {
+	if l.ArenaSlotSize > 0 {
+		return newArenaRing(slots, l.ArenaSlotSize)
+	}
+	return newRingBuffer(slots)
+}
+
+// newArenaRing creates a ring of size slots (rounded up to a power of 2)
+// that copies each message inline into its own cellSize-byte cell of one
+// contiguous arena.
+//
+// WHY: the pointer ring stores a *[]byte per message, so every push heap
+// allocates a slice header (plus the copy when the pool runs dry) and the
+// GC scans every slot. Arena cells hold no pointers and are reused in
+// place, so steady-state pushes and pops allocate nothing.
+func newArenaRing(size uint64, cellSize int) *ringBuffer {
+	rb := newRingBuffer(size)
+	slots := rb.slots()
+	rb.buffer = nil                                 // Cells replace the pointer slots
+	rb.arena = make([]byte, slots*uint64(cellSize)) // #nosec G115 -- validated positive and bounded
+	rb.cellSize = cellSize
+	rb.cellLen = make([]atomic.Uint32, slots)
+	return rb
+}
+
+// fits reports whether a message of n bytes can be stored in the ring.
+// Pointer rings take any size; arena rings only messages up to a cell.
+func (rb *ringBuffer) fits(n int) bool {
+	return rb.arena == nil || n <= rb.cellSize
+}
+
+// pushArena copies data into the next cell. Returns false when the ring
+// is full or data does not fit in a cell. Thread-safe for producers.
+func (rb *ringBuffer) pushArena(data []byte) bool {
+	if len(data) > rb.cellSize {
+		return false
+	}
+	size := rb.slots()
+	for {
+		tail := rb.tail.Load()
+		head := rb.head.Load()
+		if tail-head >= size {
+			return false // Buffer full
+		}
+		// Reserve the cell first, as in push, then fill it
+		if rb.tail.CompareAndSwap(tail, tail+1) {
+			idx := tail & rb.mask
+			offset := idx * uint64(rb.cellSize) // #nosec G115 -- cellSize is positive
+			copy(rb.arena[offset:], data)
+			// Length + 1 marks the cell written (0 = empty), so an empty
+			// message is still distinguishable from an unwritten cell
+			rb.cellLen[idx].Store(uint32(len(data)) + 1) // #nosec G115 -- bounded by maxArenaSlotSize
+			rb.signalDataAvailable()
+			return true
+		}
+	}
+}
+
+// popArena appends the oldest message to dst and frees its cell. The
+// caller holds popMu.
+//
+// WHY popMu: the cell is read in place, so it must be cleared before head
+// moves past it and a producer may refill it. With Sync flushing
+// alongside the consumer there can be two poppers, and a head CAS alone
+// (as in pop) would let the loser clear a cell that was already refilled.
+func (rb *ringBuffer) popArena(dst []byte) ([]byte, bool) {
+	head := rb.head.Load()
+	if head >= rb.end() {
+		return dst, false
+	}
+
+	idx := head & rb.mask
+	// A producer may have reserved the cell but not filled it yet: spin
+	// briefly, as pop does
+	var marker uint32
+	for spin := 0; spin < 1000; spin++ {
+		if marker = rb.cellLen[idx].Load(); marker != 0 {
+			break
+		}
+	}
+	if marker == 0 {
+		return dst, false
+	}
+
+	offset := idx * uint64(rb.cellSize) // #nosec G115 -- cellSize is positive
+	dst = append(dst, rb.arena[offset:offset+uint64(marker-1)]...)
+	rb.cellLen[idx].Store(0)
+	rb.head.Store(head + 1)
+	return dst, true
+}
+
+// flushArena is flushRing for an arena ring: messages are copied from their
+// cells straight into one coalescing buffer, so draining allocates nothing
+// per message.
+func (c *MPSCConsumer) flushArena(buffer *ringBuffer, batchSize int) int {
+	scratch := batchScratchPool.Get().(*[]byte)
+	defer batchScratchPool.Put(scratch)
+
+	ends := make([]int, 0, batchSize)
+	batch := make([][]byte, 0, batchSize)
+	itemsProcessed := 0
+	for {
+		buf := (*scratch)[:0]
+		ends = ends[:0]
+		buffer.popMu.Lock()
+		for len(ends) < batchSize {
+			var ok bool
+			if buf, ok = buffer.popArena(buf); !ok {
+				break
+			}
+			ends = append(ends, len(buf))
+		}
+		buffer.popMu.Unlock()
+		*scratch = buf // Keep any growth for the next batch
+		if len(ends) == 0 {
+			break
+		}
+
+		// Views are cut only now: appends above may have moved buf
+		batch = batch[:0]
+		start := 0
+		for _, end := range ends {
+			batch = append(batch, buf[start:end])
+			start = end
+		}
+		c.writeMessages(batch)
+		c.logger.releaseBufferBytes(len(buf))
+		itemsProcessed += len(ends)
+	}
+	return itemsProcessed
+}
+
+// validateArenaSlotSize checks ArenaSlotSize for ValidateConfig.
+func validateArenaSlotSize(size int) error {
+	if size < 0 || size > maxArenaSlotSize {
+		return fmt.Errorf("invalid ArenaSlotSize %d: must be between 0 and %d", size, maxArenaSlotSize)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// arena_test.go: Tests and benchmarks for the inline byte-arena ring
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestArena_PreservesOrderAndContent verifies messages written through
+// the arena reach the file intact and in order.
+func TestArena_PreservesOrderAndContent(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "arena.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		Async:         true,
+		BufferSize:    1024, // Room for every entry: no fallback to reorder
+		ArenaSlotSize: 64,
+	})
+
+	var want strings.Builder
+	for i := 0; i < 500; i++ {
+		line := fmt.Sprintf("arena entry %03d\n", i)
+		want.WriteString(line)
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != want.String() {
+		t.Errorf("log content out of order or incomplete:\n%s", got)
+	}
+}
+
+// TestArena_OversizedMessageFallsBack verifies a message larger than a
+// cell is written through the backpressure path rather than truncated.
+func TestArena_OversizedMessageFallsBack(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "arena.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		Async:         true,
+		ArenaSlotSize: 16,
+	})
+
+	large := strings.Repeat("x", 100) + "\n"
+	for _, line := range []string{"small\n", large, "small again\n"} {
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Sync(); err != nil { // Keep the sync fallback in order
+			t.Fatalf("Sync: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got, want := readLog(t, logFile), "small\n"+large+"small again\n"; got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+// TestArena_ConcurrentWriters runs producers against a small arena so
+// cells are reused while the consumer and Sync drain; run with -race.
+func TestArena_ConcurrentWriters(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "arena.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		Async:         true,
+		BufferSize:    8,
+		MinBufferSize: 8,
+		ArenaSlotSize: 32,
+	})
+
+	const writers, perWriter = 8, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := logger.Write([]byte(fmt.Sprintf("w%d-%03d\n", w, i))); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+				if i%50 == 0 {
+					_ = logger.Sync()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(readLog(t, logFile), "\n"), "\n")
+	if len(lines) != writers*perWriter {
+		t.Fatalf("got %d lines, want %d", len(lines), writers*perWriter)
+	}
+	// The sync fallback on a full ring may overtake queued entries, so
+	// check that every entry arrived exactly once and uncorrupted
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		var w, i int
+		if _, err := fmt.Sscanf(line, "w%d-%d", &w, &i); err != nil || len(line) != len("w0-000") {
+			t.Fatalf("corrupted line %q", line)
+		}
+		if seen[line] {
+			t.Fatalf("duplicated line %q", line)
+		}
+		seen[line] = true
+	}
+}
+
+func TestArena_ValidateConfig(t *testing.T) {
+	for _, size := range []int{-1, maxArenaSlotSize + 1} {
+		cfg := &LoggerConfig{Filename: "app.log", ArenaSlotSize: size}
+		if err := ValidateConfig(cfg); err == nil {
+			t.Errorf("ArenaSlotSize %d accepted", size)
+		}
+	}
+}
+
+// BenchmarkRing_PushPop compares per-message allocations of the pointer
+// ring and the arena ring.
+func BenchmarkRing_PushPop(b *testing.B) {
+	data := []byte("benchmark entry with a typical length for a log record\n")
+
+	b.Run("Pointer", func(b *testing.B) {
+		rb := newRingBuffer(1024)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rb.push(data)
+			msg, _ := rb.pop()
+			safeBufferPool.Put(msg)
+		}
+	})
+
+	b.Run("Arena", func(b *testing.B) {
+		rb := newArenaRing(1024, 128)
+		buf := make([]byte, 0, 128)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rb.push(data)
+			rb.popMu.Lock()
+			buf, _ = rb.popArena(buf[:0])
+			rb.popMu.Unlock()
+		}
+	})
+}
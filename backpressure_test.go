@@ -0,0 +1,58 @@
+// backpressure_test.go: Tests for BackpressurePolicy validation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBackpressurePolicy_UnknownRejectedAtConstruction(t *testing.T) {
+	for _, policy := range []string{"droop", "Drop", "sync"} {
+		_, err := NewWithConfig(&LoggerConfig{
+			Filename:           filepath.Join(t.TempDir(), "bp.log"),
+			Async:              true,
+			BackpressurePolicy: policy,
+		})
+		if err == nil {
+			t.Errorf("policy %q accepted, want construction error", policy)
+		}
+	}
+}
+
+func TestBackpressurePolicy_EmptyMeansFallback(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "bp.log")})
+
+	if logger.BackpressurePolicy != "fallback" {
+		t.Errorf("BackpressurePolicy = %q, want fallback", logger.BackpressurePolicy)
+	}
+}
+
+// TestBackpressurePolicy_StructLiteralUnknownReported verifies a typo in a
+// struct-literal Logger is reported once instead of silently ignored.
+func TestBackpressurePolicy_StructLiteralUnknownReported(t *testing.T) {
+	reports := 0
+	logger := &Logger{
+		Filename:           filepath.Join(t.TempDir(), "bp.log"),
+		BackpressurePolicy: "droop",
+		ErrorCallback: func(op string, err error) {
+			if op == "backpressure_policy" {
+				reports++
+			}
+		},
+	}
+	defer func() { _ = logger.Close() }()
+
+	for i := 0; i < 3; i++ {
+		if got := logger.backpressurePolicy(); got != "fallback" {
+			t.Fatalf("backpressurePolicy() = %q, want fallback", got)
+		}
+	}
+	if reports != 1 {
+		t.Errorf("unknown policy reported %d times, want 1", reports)
+	}
+}
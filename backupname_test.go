@@ -0,0 +1,73 @@
+// backupname_test.go: Tests for backup name collision handling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestRotate_SameSecondBackupsDoNotCollide(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "collide.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	// Three rotations well within one second share a timestamp
+	for i := 0; i < 3; i++ {
+		if _, err := fmt.Fprintf(logger, "segment %d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate %d: %v", i, err)
+		}
+	}
+
+	backups, _ := filepath.Glob(logFile + ".*")
+	sort.Strings(backups)
+	if len(backups) != 3 {
+		t.Fatalf("backups = %v, want 3 distinct files", backups)
+	}
+
+	contents := make(map[string]bool)
+	for _, backup := range backups {
+		data, err := os.ReadFile(backup)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		contents[string(data)] = true
+	}
+	for i := 0; i < 3; i++ {
+		if want := fmt.Sprintf("segment %d\n", i); !contents[want] {
+			t.Errorf("no backup holds %q; backups = %v", want, backups)
+		}
+	}
+
+	if got := logger.countExistingBackups(); got != 3 {
+		t.Errorf("countExistingBackups = %d, want 3", got)
+	}
+}
+
+func TestIsBackupSuffix(t *testing.T) {
+	cases := map[string]bool{
+		"2025-01-02-15-04-05":        true,
+		"2025-01-02-15-04-05.gz":     true,
+		"2025-01-02-15-04-05.gz.enc": true,
+		"2025-01-02-15-04-05.1":      true,
+		"2025-01-02-15-04-05.12.gz":  true,
+		"2025-01-02-15-04-05.x":      false,
+		"2025-01-02-15-04-05.":       false,
+		"state":                      false,
+		"2025-01-02-15-04-05.sha256": false,
+	}
+	for suffix, want := range cases {
+		if got := isBackupSuffix(suffix); got != want {
+			t.Errorf("isBackupSuffix(%q) = %v, want %v", suffix, got, want)
+		}
+	}
+}
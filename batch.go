@@ -0,0 +1,99 @@
+// batch.go: WriteBatch for submitting several messages as one unit
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agilira/go-timecache"
+)
+
+// WriteBatch writes several messages so that they land adjacent in the
+// file, with no writes from other goroutines interleaved between them.
+// Frameworks that accumulate records can hand them over in one call
+// instead of paying the per-message overhead of Write.
+//
+// The chunks are coalesced into a single buffer (after Transform and
+// PreWriteHook, which run once per chunk) and submitted as one message: a single ring slot in
+// async mode, a single file.Write in sync mode. Backpressure and rotation
+// treat the batch as one write, so a batch is never split across files.
+// The chunks are copied; the caller may reuse them after WriteBatch returns.
+//
+// Returns the total number of bytes written.
+//
+// Example:
+//
+//	records := [][]byte{
+//		[]byte("request started\n"),
+//		[]byte("request finished\n"),
+//	}
+//	n, err := logger.WriteBatch(records)
+func (l *Logger) WriteBatch(chunks [][]byte) (int, error) {
+	if l.closed.Load() {
+		return 0, ErrClosed
+	}
+
+	// Same initialization contract as Write (see there)
+	l.timeCacheOnce.Do(func() {
+		l.timeCache = timecache.NewWithResolution(time.Millisecond)
+	})
+
+	total := 0
+	for _, chunk := range chunks {
+		total += len(chunk)
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	// The batch is sampled and rate-limited as one write
+	if !l.admitWrite(total) {
+		return total, nil
+	}
+
+	// Increment write counter for auto-scaling metrics (one submission)
+	l.writeCount.Add(1)
+
+	batch := make([]byte, 0, total)
+	added := 0
+	for _, chunk := range chunks {
+		if l.Transform != nil {
+			var err error
+			if chunk, err = l.applyTransform(chunk); err != nil {
+				return 0, err
+			}
+			if chunk == nil {
+				l.transformDropped.Add(1)
+				continue
+			}
+		}
+		if l.preWriteHook != nil {
+			var err error
+			chunk, err = l.preWriteHook(chunk)
+			if err != nil {
+				return 0, fmt.Errorf("pre-write hook failed: %w", err)
+			}
+		}
+		batch = append(batch, chunk...)
+		if l.unterminated(chunk) {
+			batch = append(batch, '\n')
+			added++
+		}
+	}
+
+	// batch is ours, so the zero-copy path applies. As in Write, n does
+	// not count newlines added by EnsureNewline
+	if len(batch) == 0 {
+		return total, nil // Every chunk dropped by Transform
+	}
+	n, err := l.deliver(batch, l.dispatchOwned)
+	if err == nil && l.Transform != nil {
+		return total, nil // Transform may change lengths; see writeTransformed
+	}
+	return min(n, len(batch)-added), err
+}
@@ -0,0 +1,99 @@
+// batch_test.go: Tests for WriteBatch
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteBatch_ChunksStayAdjacent(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "batch.log")
+			logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Async: async})
+
+			const batches, perBatch = 20, 5
+			var wg sync.WaitGroup
+			for g := 0; g < batches; g++ {
+				wg.Add(2)
+				go func(g int) {
+					defer wg.Done()
+					chunks := make([][]byte, perBatch)
+					for i := range chunks {
+						chunks[i] = []byte(fmt.Sprintf("batch-%d-%d\n", g, i))
+					}
+					n, err := logger.WriteBatch(chunks)
+					if err != nil {
+						t.Errorf("WriteBatch: %v", err)
+					}
+					if want := len(bytes.Join(chunks, nil)); n != want {
+						t.Errorf("WriteBatch n = %d, want %d", n, want)
+					}
+				}(g)
+				// Concurrent single writes that must not interleave with batches
+				go func(g int) {
+					defer wg.Done()
+					_, _ = logger.Write([]byte(fmt.Sprintf("single-%d\n", g)))
+				}(g)
+			}
+			wg.Wait()
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			data, err := os.ReadFile(logFile)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(lines) != batches*(perBatch+1) {
+				t.Fatalf("got %d lines, want %d", len(lines), batches*(perBatch+1))
+			}
+			for i, line := range lines {
+				var g, idx int
+				if _, err := fmt.Sscanf(line, "batch-%d-%d", &g, &idx); err != nil || idx != 0 {
+					continue
+				}
+				for j := 1; j < perBatch; j++ {
+					if want := fmt.Sprintf("batch-%d-%d", g, j); i+j >= len(lines) || lines[i+j] != want {
+						t.Fatalf("line %d: batch %d interrupted, want %q", i+j, g, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriteBatch_AppliesHookPerChunk(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "batch-hook.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		PreWriteHook: func(data []byte) ([]byte, error) {
+			return append([]byte("> "), data...), nil
+		},
+	})
+
+	n, err := logger.WriteBatch([][]byte{[]byte("one\n"), []byte("two\n")})
+	if err != nil {
+		t.Fatalf("WriteBatch: %v", err)
+	}
+	want := "> one\n> two\n"
+	data, _ := os.ReadFile(logFile)
+	if string(data) != want || n != len(want) {
+		t.Errorf("file = %q (n=%d), want %q", data, n, want)
+	}
+
+	if n, err := logger.WriteBatch(nil); n != 0 || err != nil {
+		t.Errorf("WriteBatch(nil) = %d, %v; want 0, nil", n, err)
+	}
+}
@@ -0,0 +1,71 @@
+// boundary.go: Deferring threshold rotation to a record boundary
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "fmt"
+
+// checkRotation rotates when a limit is reached after a write. With a
+// RecordBoundary the rotation is only recorded, and performed by
+// rotateAtBoundary before the next write that starts a record.
+func (l *Logger) checkRotation(currentSize uint64) {
+	if l.RecordBoundary != nil && l.deferredRotation.Load() != uint32(rotateNone) {
+		return // Already waiting for a boundary
+	}
+	reason := l.shouldRotate(currentSize)
+	if reason == rotateNone {
+		return
+	}
+	if l.RecordBoundary != nil {
+		l.deferredRotation.CompareAndSwap(uint32(rotateNone), uint32(reason))
+		return
+	}
+	l.triggerRotation(reason)
+}
+
+// rotateAtBoundary performs a deferred rotation if data starts a record.
+// Called before data is written.
+func (l *Logger) rotateAtBoundary(data []byte) {
+	if l.deferredRotation.Load() != uint32(rotateNone) && l.safeInvokeRecordBoundary(data) {
+		l.rotateDeferred()
+	}
+}
+
+// rotateDeferred performs the deferred rotation, if any. The reason is
+// cleared by updateRotationState, so a rotation skipped here (paused, or
+// already running) is retried at the next boundary.
+func (l *Logger) rotateDeferred() {
+	if reason := rotationReason(l.deferredRotation.Load()); reason != rotateNone { // #nosec G115 -- only rotationReason values are stored
+		l.triggerRotation(reason)
+	}
+}
+
+// boundaryIndex returns the index of the first message in batch that
+// starts a record while a rotation is deferred, or -1.
+func (l *Logger) boundaryIndex(batch [][]byte) int {
+	if l.deferredRotation.Load() == uint32(rotateNone) {
+		return -1
+	}
+	for i, data := range batch {
+		if l.safeInvokeRecordBoundary(data) {
+			return i
+		}
+	}
+	return -1
+}
+
+// safeInvokeRecordBoundary calls RecordBoundary with panic recovery. A
+// panic counts as a boundary: rotating mid-record beats a file that never
+// rotates again.
+func (l *Logger) safeInvokeRecordBoundary(data []byte) (boundary bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("record_boundary_panic", fmt.Errorf("RecordBoundary callback panicked: %v", r))
+			boundary = true
+		}
+	}()
+	return l.RecordBoundary(data)
+}
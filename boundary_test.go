@@ -0,0 +1,147 @@
+// boundary_test.go: Tests for RecordBoundary
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stackTraceBoundary treats indented lines as continuations.
+func stackTraceBoundary(data []byte) bool {
+	return len(data) > 0 && data[0] != '\t'
+}
+
+// writeStackTraces writes n records of one header line and five
+// indented frames, one line per write.
+func writeStackTraces(t *testing.T, logger *Logger, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := fmt.Fprintf(logger, "panic in request %03d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		for frame := 0; frame < 5; frame++ {
+			if _, err := fmt.Fprintf(logger, "\tat handler.go:%d\n", frame); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+		}
+	}
+}
+
+// assertRecordsWhole checks every file in dir starts with a record header
+// and every record has all its frames, and returns how many files there are.
+func assertRecordsWhole(t *testing.T, dir string, records int) int {
+	t.Helper()
+	seen := 0
+	files := listDir(t, dir)
+	for _, name := range files {
+		content := readLog(t, filepath.Join(dir, name))
+		if content == "" {
+			continue
+		}
+		if content[0] == '\t' {
+			t.Errorf("%s starts mid-record:\n%.60s", name, content)
+		}
+		headers := strings.Count(content, "panic")
+		if frames := strings.Count(content, "\tat "); frames != 5*headers {
+			t.Errorf("%s has %d frames for %d records", name, frames, headers)
+		}
+		seen += headers
+	}
+	if seen != records {
+		t.Errorf("found %d records, want %d", seen, records)
+	}
+	return len(files)
+}
+
+func TestRecordBoundary_SyncNeverSplitsRecords(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(dir, "app.log"),
+		MaxSizeStr:       "1KB",
+		DisableAutoScale: true,
+		RecordBoundary:   stackTraceBoundary,
+	})
+	writeStackTraces(t, logger, 60)
+	rotations := logger.Stats().SizeRotations
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if rotations < 3 {
+		t.Fatalf("SizeRotations = %d, want several past a 1KB limit", rotations)
+	}
+	if files := assertRecordsWhole(t, dir, 60); files != int(rotations)+1 {
+		t.Errorf("%d files for %d rotations", files, rotations)
+	}
+}
+
+func TestRecordBoundary_AsyncSplitsBatchAtBoundary(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:       filepath.Join(dir, "app.log"),
+		MaxSizeStr:     "1KB",
+		Async:          true,
+		RecordBoundary: stackTraceBoundary,
+	})
+	writeStackTraces(t, logger, 60)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if rotations := logger.Stats().SizeRotations; rotations == 0 {
+		t.Fatal("no size rotation past a 1KB limit")
+	}
+	assertRecordsWhole(t, dir, 60)
+}
+
+// TestRecordBoundary_WithoutItRotatesMidRecord shows the default keeps
+// rotating as soon as the limit is reached.
+func TestRecordBoundary_WithoutItRotatesMidRecord(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(dir, "app.log"),
+		MaxSizeStr:       "1KB",
+		DisableAutoScale: true,
+	})
+	writeStackTraces(t, logger, 60)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	split := false
+	for _, name := range listDir(t, dir) {
+		if content := readLog(t, filepath.Join(dir, name)); content != "" && content[0] == '\t' {
+			split = true
+		}
+	}
+	if !split {
+		t.Error("no file starts mid-record; the test no longer exercises RecordBoundary")
+	}
+}
+
+func TestRecordBoundary_PanicRotates(t *testing.T) {
+	dir := t.TempDir()
+	var reported []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(dir, "app.log"),
+		MaxSizeStr:       "1KB",
+		DisableAutoScale: true,
+		RecordBoundary:   func([]byte) bool { panic("bad detector") },
+		ErrorCallback:    func(op string, err error) { reported = append(reported, op) },
+	})
+
+	writeStackTraces(t, logger, 20)
+	if logger.Stats().SizeRotations == 0 {
+		t.Error("a panicking RecordBoundary blocked rotation")
+	}
+	if len(reported) == 0 || reported[0] != "record_boundary_panic" {
+		t.Errorf("reported operations = %v, want record_boundary_panic", reported)
+	}
+}
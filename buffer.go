@@ -8,6 +8,7 @@ package lethe
 
 import (
 	"context"
+	"fmt"
 	"math/bits"
 	"sync"
 	"sync/atomic"
@@ -80,10 +81,73 @@ type ringBuffer struct {
 	head   atomic.Uint64            // Consumer head pointer
 	tail   atomic.Uint64            // Producer tail pointer
 
+	// Arena storage (ArenaSlotSize), replacing buffer: messages are copied
+	// into fixed-size cells of one byte slice, see arena.go
+	arena    []byte
+	cellSize int
+	cellLen  []atomic.Uint32 // Message length + 1 once written; 0 = empty
+	popMu    sync.Mutex      // Serializes arena pops (see popArena)
+
 	// Event-driven notification for CPU-efficient idle waiting
 	cond    *sync.Cond  // Condition variable for consumer wakeup
 	condMu  sync.Mutex  // Mutex for condition variable
 	hasData atomic.Bool // Fast path check to avoid lock contention
+
+	// IdleTimeout: set while the consumer goroutine is parked; the writer
+	// that clears it calls unpark to start a new one
+	parked atomic.Bool
+	unpark func()
+
+	// Adaptive resize: the larger ring that replaced this one. Set before
+	// ringRetired, so a consumer that finds the ring retired and drained
+	// can move on to it (see tryAdaptiveResize).
+	next atomic.Pointer[ringBuffer]
+}
+
+// ringRetired is set in a ring's tail once adaptive resize has replaced
+// it. WHY in tail: producers reserve slots with a CAS on tail, so after
+// the bit is set no reservation can succeed, and tail-head exceeds any
+// capacity, so push reports the ring full without an extra check.
+const ringRetired = 1 << 63
+
+// end returns the tail without the ringRetired bit: one past the last
+// reserved slot.
+func (rb *ringBuffer) end() uint64 {
+	return rb.tail.Load() &^ ringRetired
+}
+
+// retired reports whether adaptive resize has replaced the ring.
+func (rb *ringBuffer) retired() bool {
+	return rb.tail.Load()&ringRetired != 0
+}
+
+// retire sets ringRetired, after which every push fails.
+func (rb *ringBuffer) retire() {
+	for {
+		tail := rb.tail.Load()
+		if tail&ringRetired != 0 || rb.tail.CompareAndSwap(tail, tail|ringRetired) {
+			return
+		}
+	}
+}
+
+// newest follows the adaptive resize links from rb to the ring that has
+// not been replaced (yet).
+func (rb *ringBuffer) newest() *ringBuffer {
+	for next := rb.next.Load(); next != nil; next = rb.next.Load() {
+		rb = next
+	}
+	return rb
+}
+
+// queued returns the number of messages reserved in the ring and not yet
+// popped.
+func (rb *ringBuffer) queued() uint64 {
+	head, end := rb.head.Load(), rb.end()
+	if end > head {
+		return end - head
+	}
+	return 0
 }
 
 // nextPow2 returns the next power of 2 greater than or equal to x
@@ -94,13 +158,28 @@ func nextPow2(x uint64) uint64 {
 	return 1 << (64 - bits.LeadingZeros64(x-1))
 }
 
-// newRingBuffer creates a new ring buffer with given size (must be power of 2)
-func newRingBuffer(size uint64) *ringBuffer {
-	// Ensure minimum size for performance
-	if size < 64 {
-		size = 64
+// defaultMinBufferSize is the smallest ring initMPSC creates unless
+// MinBufferSize lowers it.
+const defaultMinBufferSize = 64
+
+// ringSlots returns the ring capacity for a requested slot count: at least
+// MinBufferSize (default 64), rounded up to a power of 2.
+func (l *Logger) ringSlots(requested int) uint64 {
+	floor := defaultMinBufferSize
+	if l.MinBufferSize > 0 {
+		floor = l.MinBufferSize
 	}
+	return nextPow2(uint64(max(requested, floor))) // #nosec G115 -- floor is positive
+}
+
+// slots returns the ring capacity in messages.
+func (rb *ringBuffer) slots() uint64 {
+	return rb.mask + 1
+}
 
+// newRingBuffer creates a new ring buffer with given size (rounded up to a
+// power of 2). Minimum sizes are the caller's concern (see ringSlots).
+func newRingBuffer(size uint64) *ringBuffer {
 	// Ensure size is power of 2 for optimal performance
 	size = nextPow2(size)
 
@@ -127,11 +206,15 @@ func newRingBuffer(size uint64) *ringBuffer {
 // - Cache-friendly access patterns
 // - Power-of-2 sizing enables fast modulo via bitwise AND
 func (rb *ringBuffer) push(data []byte) bool {
+	if rb.arena != nil {
+		return rb.pushArena(data)
+	}
+
 	// Fast path with CAS loop + bounded check
 	for {
 		tail := rb.tail.Load()
 		head := rb.head.Load()
-		size := uint64(len(rb.buffer))
+		size := rb.slots()
 
 		// Check if buffer is full
 		if tail-head >= size {
@@ -165,6 +248,12 @@ func (rb *ringBuffer) signalDataAvailable() {
 	// Set flag first (atomic, no lock needed)
 	rb.hasData.Store(true)
 
+	// A parked consumer has no goroutine to signal
+	if rb.parked.Load() && rb.parked.CompareAndSwap(true, false) {
+		rb.unpark()
+		return
+	}
+
 	// Signal condition variable to wake up blocked consumer
 	// Use non-blocking signal - consumer will check hasData flag
 	rb.cond.Signal()
@@ -174,11 +263,15 @@ func (rb *ringBuffer) signalDataAvailable() {
 // The caller promises not to reuse the data slice after this call
 // Returns true if successful, false if buffer is full
 func (rb *ringBuffer) pushOwned(data []byte) bool {
+	if rb.arena != nil {
+		return rb.pushArena(data) // Copied inline; data is simply dropped
+	}
+
 	// Fast path with CAS loop + bounded check
 	for {
 		tail := rb.tail.Load()
 		head := rb.head.Load()
-		size := uint64(len(rb.buffer))
+		size := rb.slots()
 
 		// Check if buffer is full
 		if tail-head >= size {
@@ -206,8 +299,15 @@ func (rb *ringBuffer) pushOwned(data []byte) bool {
 // A producer may have reserved a slot (tail++) but not yet written the data.
 // We do a brief spin-wait (bounded) before returning false.
 func (rb *ringBuffer) pop() ([]byte, bool) {
+	if rb.arena != nil {
+		// Copies out of the arena; only adaptive resize pops one at a time
+		rb.popMu.Lock()
+		defer rb.popMu.Unlock()
+		return rb.popArena(safeBufferPool.Get(0))
+	}
+
 	head := rb.head.Load()
-	tail := rb.tail.Load()
+	tail := rb.end()
 
 	// Check if buffer is empty
 	if head >= tail {
@@ -259,12 +359,20 @@ func (rb *ringBuffer) pop() ([]byte, bool) {
 // Note: This type is exported for type safety but should not be used directly.
 // Instances are managed internally by the Logger.
 type MPSCConsumer struct {
-	buffer *ringBuffer
+	buffer atomic.Pointer[ringBuffer] // Ring being drained; moves on after adaptive resize (see ring)
 	logger *Logger
 	ctx    context.Context
 	cancel context.CancelFunc
 	ticker *time.Ticker
 	wg     sync.WaitGroup
+
+	running   atomic.Bool  // True until the run loop exits (see Logger.Health)
+	heartbeat atomic.Int64 // UnixNano of the run loop's last iteration
+
+	watchdog *backgroundLoop // StallTimeout checker, nil when disabled
+	stalled  atomic.Bool     // Set by the watchdog for the current stall
+
+	parkMu sync.Mutex // Orders unpark's wg.Add before stop's wg.Wait
 }
 
 // newMPSCConsumer creates a new MPSC consumer with configurable flush timing
@@ -272,17 +380,23 @@ func newMPSCConsumer(buffer *ringBuffer, logger *Logger) *MPSCConsumer {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	consumer := &MPSCConsumer{
-		buffer: buffer,
 		logger: logger,
 		ctx:    ctx,
 		cancel: cancel,
 		ticker: nil, // No longer needed - we use event-driven wakeup
 	}
+	consumer.buffer.Store(buffer)
 
 	// Start consumer goroutine
 	consumer.wg.Add(1)
+	consumer.running.Store(true)
+	consumer.heartbeat.Store(time.Now().UnixNano())
 	go consumer.run()
 
+	if logger.StallTimeout > 0 {
+		consumer.startStallWatchdog(logger.StallTimeout)
+	}
+
 	return consumer
 }
 
@@ -291,112 +405,319 @@ func newMPSCConsumer(buffer *ringBuffer, logger *Logger) *MPSCConsumer {
 // It wakes up only when:
 // 1. New data is pushed to the buffer (signalDataAvailable)
 // 2. Context is cancelled (shutdown)
+//
+// With IdleTimeout the loop instead returns once idle that long; the
+// next write starts it again (see park).
 func (c *MPSCConsumer) run() {
 	defer c.wg.Done()
+	parking := false
+	defer func() {
+		if !parking {
+			c.running.Store(false)
+		}
+	}()
 
 	for {
+		c.heartbeat.Store(time.Now().UnixNano())
+
 		// Check for shutdown first
 		select {
 		case <-c.ctx.Done():
 			// Final flush before shutdown
-			c.flushAll()
+			c.flushSafely()
 			return
 		default:
 		}
 
+		// Hold buffered messages while paused (see Logger.Pause)
+		c.logger.fsInFlight.Add(1)
+		if c.logger.paused.Load() {
+			c.logger.fsInFlight.Add(-1)
+			c.waitForResume()
+			continue
+		}
+
 		// Try to flush any available data
-		itemsProcessed := c.flushAll()
+		itemsProcessed := c.flushSafely()
+
+		if itemsProcessed > 0 {
+			c.syncBatch()
+		}
+		c.logger.fsInFlight.Add(-1)
 
 		if itemsProcessed == 0 {
 			// Buffer is empty - wait for signal instead of polling
-			c.waitForData()
+			if c.waitForData() && c.park() {
+				parking = true
+				return
+			}
 		}
 		// If we processed items, immediately loop back to check for more
 	}
 }
 
+// flushSafely runs flushAll, converting a panic into a "consumer_panic"
+// report. Without it a single bad batch would kill the consumer goroutine,
+// the ring would fill up, and every later write would hit backpressure.
+// Messages of the panicking batch are lost.
+func (c *MPSCConsumer) flushSafely() (items int) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.reportError("consumer_panic", fmt.Errorf("MPSC consumer panicked: %v", r))
+			items = 1 // Loop again instead of sleeping on a possibly non-empty buffer
+		}
+	}()
+	return c.flushAll()
+}
+
 // waitForData blocks until new data is available or context is cancelled.
-// This is the key to CPU-efficient idle waiting.
-func (c *MPSCConsumer) waitForData() {
-	c.buffer.condMu.Lock()
-	defer c.buffer.condMu.Unlock()
+// This is the key to CPU-efficient idle waiting. Returns true when
+// IdleTimeout elapsed with no data.
+func (c *MPSCConsumer) waitForData() bool {
+	buffer := c.ring()
+	buffer.condMu.Lock()
+	defer buffer.condMu.Unlock()
 
 	// Check if we should stop
 	select {
 	case <-c.ctx.Done():
-		return
+		return false
 	default:
 	}
 
 	// Clear the flag before waiting
-	c.buffer.hasData.Store(false)
+	buffer.hasData.Store(false)
 
-	// Double-check buffer is still empty (avoid race with push)
-	if c.buffer.tail.Load() > c.buffer.head.Load() {
+	// Double-check buffer is still empty (avoid race with push); a retired
+	// ring gets no more pushes, so wait on its successor instead
+	if buffer.queued() > 0 || buffer.retired() || c.logger.spilling() {
 		// Data arrived between flushAll and here - don't wait
-		return
+		return false
+	}
+
+	var idle <-chan time.Time
+	if c.logger.IdleTimeout > 0 {
+		timer := time.NewTimer(c.logger.IdleTimeout)
+		defer timer.Stop()
+		idle = timer.C
 	}
 
 	// Wait for signal with timeout to allow periodic shutdown checks
 	// Using a goroutine to implement timeout since sync.Cond doesn't have native timeout
 	done := make(chan struct{})
+	timedOut := false // Guarded by condMu
 	go func() {
 		select {
 		case <-c.ctx.Done():
 			// Wake up the waiting goroutine on shutdown
-			c.buffer.cond.Signal()
+			buffer.cond.Signal()
+		case <-idle:
+			// Under condMu, so the signal cannot land before Wait
+			buffer.condMu.Lock()
+			timedOut = true
+			buffer.cond.Signal()
+			buffer.condMu.Unlock()
 		case <-done:
 		}
 	}()
 
-	c.buffer.cond.Wait()
+	buffer.cond.Wait()
+	expired := timedOut && !buffer.hasData.Load()
 	close(done)
+	return expired
+}
+
+// defaultConsumerBatchSize is the number of popped messages coalesced into
+// a single file.Write when ConsumerBatchSize is unset.
+const defaultConsumerBatchSize = 64
+
+// batchScratchPool recycles the coalescing buffers used by writeBatch.
+// WHY a pool rather than a consumer field: Sync() may call flushAll from
+// another goroutine concurrently with the consumer loop.
+var batchScratchPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 64*1024)
+		return &buf
+	},
 }
 
 // flushAll drains available data from ring buffer to file
 // Returns the number of items processed
 func (c *MPSCConsumer) flushAll() int {
+	batchSize := c.logger.ConsumerBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultConsumerBatchSize
+	}
+
+	itemsProcessed := 0
+	for buffer := c.ring(); ; {
+		if buffer.arena != nil {
+			itemsProcessed += c.flushArena(buffer, batchSize)
+		} else {
+			itemsProcessed += c.flushRing(buffer, batchSize)
+		}
+		// A ring retired by adaptive resize is drained before its
+		// successor, which only holds newer messages
+		next := c.ring()
+		if next == buffer {
+			break
+		}
+		buffer = next
+	}
+	// Spilled messages are newer than anything that was in the ring
+	return itemsProcessed + c.drainSpill(batchSize)
+}
+
+// ring returns the ring to drain: the current one, or its successor once
+// adaptive resize retired it and every message it took has been popped.
+func (c *MPSCConsumer) ring() *ringBuffer {
+	for {
+		buffer := c.buffer.Load()
+		next := buffer.next.Load()
+		if next == nil || !buffer.retired() || buffer.queued() > 0 {
+			return buffer
+		}
+		c.buffer.CompareAndSwap(buffer, next) // Sync may race the consumer here
+	}
+}
+
+// flushRing drains a pointer ring, one coalesced write per batch.
+func (c *MPSCConsumer) flushRing(buffer *ringBuffer, batchSize int) int {
 	itemsProcessed := 0
-	// Process all available entries
+	batch := make([][]byte, 0, batchSize)
 	for {
-		data, ok := c.buffer.pop()
-		if !ok {
-			break // Buffer empty
+		batch = batch[:0]
+		for len(batch) < batchSize {
+			data, ok := buffer.pop()
+			if !ok {
+				break // Buffer empty
+			}
+			batch = append(batch, data)
+		}
+		if len(batch) == 0 {
+			break
 		}
 
-		c.writeToFile(data)
-		itemsProcessed++
+		c.writeBatch(batch)
+		itemsProcessed += len(batch)
 	}
 	return itemsProcessed
 }
 
-// writeToFile writes data directly to file (consumer is single-threaded)
-func (c *MPSCConsumer) writeToFile(data []byte) {
-	// Write to file FIRST - this must complete before returning buffer to pool
-	if c.logger.currentFile.Load() != nil {
-		file := c.logger.currentFile.Load()
-		n, err := file.Write(data)
-		if err == nil {
-			// Update size and check rotation (n from Write() is always >= 0, but be safe)
-			if n < 0 {
-				n = 0
+// writeBatch writes popped messages and returns their buffers to the pool.
+func (c *MPSCConsumer) writeBatch(batch [][]byte) {
+	c.writeMessages(batch)
+
+	// Return buffers to safe pool after file write completes
+	// This is safe because file.Write() has completed and data is no longer being accessed
+	for _, data := range batch {
+		c.logger.releaseBufferBytes(len(data))
+		safeBufferPool.Put(data)
+	}
+}
+
+// writeMessages writes messages with a single syscall (consumer is
+// single-threaded). Rotation thresholds are checked once per flushed batch,
+// so a batch is never split across two files, except where a deferred
+// rotation finds the RecordBoundary it was waiting for.
+func (c *MPSCConsumer) writeMessages(batch [][]byte) {
+	// CloseContext gave up on the drain; the file is closed once we stop
+	if c.logger.drainAbandoned.Load() {
+		return
+	}
+	if c.logger.RecordBoundary != nil {
+		if i := c.logger.boundaryIndex(batch); i >= 0 {
+			if i > 0 {
+				c.writeRun(batch[:i])
 			}
-			newSize := c.logger.bytesWritten.Add(uint64(n)) // #nosec G115 -- n checked for negative values above
-			if c.logger.shouldRotate(newSize) {
-				c.logger.triggerRotation()
+			c.logger.rotateDeferred()
+			batch = batch[i:]
+		}
+	}
+	c.writeRun(batch)
+}
+
+// writeRun writes consecutive messages to the current file.
+func (c *MPSCConsumer) writeRun(batch [][]byte) {
+	c.logger.ensureFilePresent()
+	c.logger.maybeFailBack()
+
+	// Write to file FIRST - this must complete before returning buffers to pool
+	if file := c.logger.currentFile.Load(); file != nil {
+		payload := batch[0]
+		var scratch *[]byte
+		if len(batch) > 1 {
+			scratch = batchScratchPool.Get().(*[]byte)
+			buf := (*scratch)[:0]
+			for _, data := range batch {
+				buf = append(buf, data...)
+			}
+			*scratch = buf
+			payload = buf
+		}
+
+		var n int
+		var err error
+		if c.logger.CompressActive {
+			n, err = c.logger.writeCompressed(payload)
+		} else {
+			n, err = writeFull(file, payload)
+			if err != nil {
+				var m int
+				m, err = c.logger.failover(file, payload[n:], err)
+				n += m
 			}
 		}
+		// Account for partially written data too: it is in the file either way
+		newSize := c.logger.bytesWritten.Add(uint64(n)) // #nosec G115 -- writeFull never returns a negative count
+		c.logger.totalWritten.Add(uint64(n))            // #nosec G115 -- writeFull never returns a negative count
+		c.logger.countLines(payload[:n])
+		c.logger.markDirty()
+		c.logger.tee(payload[:n])
+		if err != nil {
+			c.logger.reportWriteError("write", err)
+		} else {
+			c.logger.consumedCount.Add(uint64(len(batch)))
+			c.logger.checkRotation(newSize)
+		}
+
+		if scratch != nil {
+			batchScratchPool.Put(scratch)
+		}
 	}
+}
 
-	// Return buffer to safe pool after file write completes
-	// This is safe because file.Write() has completed and data is no longer being accessed
-	safeBufferPool.Put(data)
+// syncBatch applies the durability policy after a flushed batch:
+// fsync unconditionally for SyncOnWrite, or when SyncInterval is due.
+func (c *MPSCConsumer) syncBatch() {
+	if c.logger.SyncOnWrite {
+		if file := c.logger.currentFile.Load(); file != nil {
+			if err := c.logger.fsync(file); err != nil && !isFileAlreadyClosedError(err) {
+				c.logger.reportWriteError("fsync", err)
+			}
+		}
+		return
+	}
+	c.logger.syncIfDue()
 }
 
 // stop gracefully stops the consumer
 func (c *MPSCConsumer) stop() {
+	c.parkMu.Lock()
 	c.cancel()
+	c.parkMu.Unlock()
 	// Wake up consumer if it's waiting on the condition variable
-	c.buffer.cond.Broadcast()
+	c.ring().cond.Broadcast()
 	c.wg.Wait() // Wait for consumer to finish
+
+	// Parked: no run loop was left to do the final flush
+	if c.running.Load() {
+		c.flushSafely()
+		c.running.Store(false)
+	}
+
+	// Stopped last, so a final flush stuck on the disk is still reported
+	if c.watchdog != nil {
+		c.watchdog.stop()
+	}
 }
@@ -0,0 +1,112 @@
+// buffer_bytes_test.go: Tests for the MaxBufferBytes async memory cap
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMaxBufferBytes_DropPolicyAppliesOverBudget verifies an oversized
+// message triggers backpressure even though slots are free.
+func TestMaxBufferBytes_DropPolicyAppliesOverBudget(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           filepath.Join(t.TempDir(), "budget.log"),
+		Async:              true,
+		BufferSize:         1024,
+		MaxBufferBytes:     100,
+		BackpressurePolicy: "drop",
+	})
+
+	if _, err := logger.Write([]byte(strings.Repeat("x", 200))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().DroppedOnFull; got != 1 {
+		t.Errorf("DroppedOnFull = %d, want 1", got)
+	}
+}
+
+// TestMaxBufferBytes_FallbackWritesSynchronously verifies the default policy
+// still persists over-budget messages.
+func TestMaxBufferBytes_FallbackWritesSynchronously(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "budget.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:       logFile,
+		Async:          true,
+		MaxBufferBytes: 100,
+	})
+
+	big := strings.Repeat("y", 200) + "\n"
+	if _, err := logger.Write([]byte(big)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != big {
+		t.Errorf("file has %d bytes, want %d", len(content), len(big))
+	}
+}
+
+// TestMaxBufferBytes_StatsDrainToZero verifies BufferedBytes returns to zero
+// once the consumer has written everything.
+func TestMaxBufferBytes_StatsDrainToZero(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: filepath.Join(t.TempDir(), "budget.log"),
+		Async:    true,
+	})
+
+	for i := 0; i < 500; i++ {
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := logger.Stats().BufferedBytes; got != 0 {
+		t.Errorf("BufferedBytes after Close = %d, want 0", got)
+	}
+}
+
+// TestMaxBufferBytes_WriteOwnedAccounted verifies the ownership-transfer
+// path reserves and releases bytes like Write.
+func TestMaxBufferBytes_WriteOwnedAccounted(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           filepath.Join(t.TempDir(), "owned.log"),
+		Async:              true,
+		MaxBufferBytes:     100,
+		BackpressurePolicy: "drop",
+	})
+
+	if _, err := logger.WriteOwned([]byte(strings.Repeat("z", 200))); err != nil {
+		t.Fatalf("WriteOwned: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := logger.WriteOwned([]byte("owned\n")); err != nil {
+			t.Fatalf("WriteOwned: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	stats := logger.Stats()
+	if stats.DroppedOnFull == 0 {
+		t.Error("oversized owned write was not subject to MaxBufferBytes")
+	}
+	if stats.BufferedBytes != 0 {
+		t.Errorf("BufferedBytes after Close = %d, want 0", stats.BufferedBytes)
+	}
+}
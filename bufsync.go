@@ -0,0 +1,157 @@
+// bufsync.go: In-memory batching of sync-mode writes (BufferedSync)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bufio"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultSyncBufferSize is the BufferedSync buffer when SyncBufferSize is unset.
+const defaultSyncBufferSize = 64 * 1024
+
+// syncBuffer batches sync-mode writes for one Logger. Unlike the MPSC path
+// it is guarded by a mutex: BufferedSync targets single-goroutine loggers,
+// where the lock is uncontended and far cheaper than a syscall per write.
+type syncBuffer struct {
+	mu   sync.Mutex
+	w    *bufio.Writer
+	file *os.File // File w writes to; nil until the next write rebinds it
+}
+
+// write buffers data for the active file, rebinding the buffer when the
+// file changed since the last write.
+func (b *syncBuffer) write(l *Logger, data []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Rotation flushes and detaches the buffer while holding b.mu, so the
+	// active file loaded here is never one that is about to be closed
+	if file := l.currentFile.Load(); b.file != file {
+		if err := b.flushLocked(); err != nil {
+			l.reportWriteError("buffered_flush", err)
+		}
+		b.w.Reset(file)
+		b.file = file
+	}
+
+	n, err := b.w.Write(data)
+	if err != nil {
+		// bufio.Writer errors are sticky; start over so the next write
+		// succeeds once the disk recovers
+		b.w.Reset(b.file)
+	}
+	return n, err
+}
+
+// flushLocked writes out buffered data. On failure the unwritten rest is
+// dropped for the same reason as in write. The caller holds b.mu.
+func (b *syncBuffer) flushLocked() error {
+	if b.file == nil || b.w.Buffered() == 0 {
+		return nil
+	}
+	if err := b.w.Flush(); err != nil {
+		b.w.Reset(b.file)
+		return err
+	}
+	return nil
+}
+
+// startBufferedSync creates the sync buffer and its periodic flush
+// goroutine once per Logger.
+func (l *Logger) startBufferedSync() {
+	if !l.BufferedSync || l.syncBuf.Load() != nil {
+		return
+	}
+
+	size := l.SyncBufferSize
+	if size <= 0 {
+		size = defaultSyncBufferSize
+	}
+	if !l.syncBuf.CompareAndSwap(nil, &syncBuffer{w: bufio.NewWriterSize(nil, size)}) {
+		return // Someone else started it
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	l.syncBufLoop.Store(s)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(l.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if l.enterFS() { // Skipped while paused
+					if err := l.flushSyncBuffer(); err != nil && !isFileAlreadyClosedError(err) {
+						l.reportWriteError("buffered_flush", err)
+					}
+					l.exitFS()
+				}
+			}
+		}
+	}()
+}
+
+// writeBuffered is the BufferedSync counterpart of writeFull.
+func (l *Logger) writeBuffered(file *os.File, data []byte) (int, error) {
+	b := l.syncBuf.Load()
+	if b == nil {
+		return writeFull(file, data)
+	}
+	return b.write(l, data)
+}
+
+// flushSyncBuffer writes out data held by BufferedSync, or by the
+// CompressActive gzip stream. No-op otherwise.
+func (l *Logger) flushSyncBuffer() error {
+	if g := l.activeGz.Load(); g != nil {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.flushLocked()
+	}
+	b := l.syncBuf.Load()
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.flushLocked()
+}
+
+// holdSyncBuffer flushes BufferedSync data into the active file and blocks
+// buffered writers until the returned release is called, so the file can
+// be closed and swapped without losing or misplacing a buffered write.
+// The next write after release rebinds the buffer to the new file.
+// With CompressActive it finishes the file's gzip member instead.
+func (l *Logger) holdSyncBuffer() (release func()) {
+	if g := l.activeGz.Load(); g != nil {
+		g.mu.Lock()
+		if err := g.finishLocked(); err != nil {
+			l.reportWriteError("compress_active", err)
+		}
+		g.file = nil
+		return g.mu.Unlock
+	}
+	b := l.syncBuf.Load()
+	if b == nil {
+		return func() {}
+	}
+	b.mu.Lock()
+	if err := b.flushLocked(); err != nil {
+		l.reportWriteError("buffered_flush", err)
+	}
+	b.file = nil
+	return b.mu.Unlock
+}
@@ -0,0 +1,131 @@
+// bufsync_test.go: Tests for BufferedSync
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBufferedLogger returns a BufferedSync Logger whose timer never fires
+// during a test unless flushInterval says otherwise.
+func newBufferedLogger(t *testing.T, flushInterval time.Duration) (*Logger, string) {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "buffered.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		BufferedSync:  true,
+		FlushInterval: flushInterval,
+	})
+	return logger, logFile
+}
+
+// TestBufferedSync_HeldUntilSync verifies writes stay in memory until Sync.
+func TestBufferedSync_HeldUntilSync(t *testing.T) {
+	logger, logFile := newBufferedLogger(t, time.Hour)
+	defer func() { _ = logger.Close() }()
+
+	for i := 0; i < 10; i++ {
+		if _, err := logger.Write([]byte("buffered entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if got := readLog(t, logFile); got != "" {
+		t.Fatalf("file before Sync = %q, want empty", got)
+	}
+	if got := logger.Stats().TotalBytes; got != 150 {
+		t.Errorf("TotalBytes = %d, want 150 (buffered bytes count as written)", got)
+	}
+
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := readLog(t, logFile); got != strings.Repeat("buffered entry\n", 10) {
+		t.Errorf("file after Sync = %q", got)
+	}
+}
+
+// TestBufferedSync_TimerFlush verifies FlushInterval writes out idle data.
+func TestBufferedSync_TimerFlush(t *testing.T) {
+	logger, logFile := newBufferedLogger(t, 10*time.Millisecond)
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte("eventually\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for readLog(t, logFile) == "" && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := readLog(t, logFile); got != "eventually\n" {
+		t.Errorf("file = %q, want the timer to flush it", got)
+	}
+}
+
+// TestBufferedSync_RotationFlushesThenSwaps verifies buffered data lands in
+// the sealed backup and later writes in the new file.
+func TestBufferedSync_RotationFlushesThenSwaps(t *testing.T) {
+	logger, logFile := newBufferedLogger(t, time.Hour)
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	backup, err := logger.RotateNamed()
+	if err != nil {
+		t.Fatalf("RotateNamed: %v", err)
+	}
+	if _, err := logger.Write([]byte("after rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if got := readLog(t, backup); got != "before rotation\n" {
+		t.Errorf("backup = %q, want the write buffered before rotation", got)
+	}
+	if got := readLog(t, logFile); got != "after rotation\n" {
+		t.Errorf("active file = %q, want only the write after rotation", got)
+	}
+}
+
+// TestBufferedSync_CloseFlushes verifies Close writes out buffered data.
+func TestBufferedSync_CloseFlushes(t *testing.T) {
+	logger, logFile := newBufferedLogger(t, time.Hour)
+
+	if _, err := logger.Write([]byte("last words\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := readLog(t, logFile); got != "last words\n" {
+		t.Errorf("file after Close = %q", got)
+	}
+}
+
+// TestBufferedSync_Validation verifies the incompatible combinations are rejected.
+func TestBufferedSync_Validation(t *testing.T) {
+	for name, c := range map[string]LoggerConfig{
+		"async":         {Async: true},
+		"sync_on_write": {SyncOnWrite: true},
+		"multi_process": {MultiProcess: true},
+		"negative_size": {SyncBufferSize: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c.Filename = "app.log"
+			c.BufferedSync = true
+			if err := ValidateConfig(&c); err == nil {
+				t.Error("ValidateConfig accepted an invalid BufferedSync configuration")
+			}
+		})
+	}
+}
@@ -0,0 +1,531 @@
+// builder.go: Fluent configuration builder for Logger
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Builder assembles a LoggerConfig through method chaining.
+// Optional fields become discoverable via code completion, and validation
+// is centralized in Build so conflicting settings are rejected up front.
+//
+// The first error encountered by a setter is kept and returned by Build;
+// later setters still run but cannot clear it.
+//
+// Example:
+//
+//	logger, err := lethe.NewBuilder("app.log").
+//		MaxSize("100MB").
+//		MaxAge("7d").
+//		MaxBackups(10).
+//		Compress(true).
+//		Async(true).
+//		Build()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer logger.Close()
+//
+// NewWithConfig remains available for struct-based configuration.
+type Builder struct {
+	config LoggerConfig
+	err    error
+}
+
+// NewBuilder starts a Builder for the given log file.
+func NewBuilder(filename string) *Builder {
+	return &Builder{config: LoggerConfig{Filename: filename}}
+}
+
+// setErr records the first configuration error.
+func (b *Builder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Name sets the Logger's logical name (see Logger.Name).
+func (b *Builder) Name(name string) *Builder {
+	b.config.Name = name
+	return b
+}
+
+// MaxSize sets the rotation size threshold as a string ("100MB", "1GB").
+func (b *Builder) MaxSize(size string) *Builder {
+	if _, err := ParseSize(size); err != nil {
+		b.setErr(fmt.Errorf("invalid MaxSize %q: %w", size, err))
+	}
+	b.config.MaxSizeStr = size
+	return b
+}
+
+// MaxAge sets the rotation age threshold as a string ("7d", "24h").
+// Mutually exclusive with MaxAgeDuration.
+func (b *Builder) MaxAge(age string) *Builder {
+	if _, err := ParseDuration(age); err != nil {
+		b.setErr(fmt.Errorf("invalid MaxAge %q: %w", age, err))
+	}
+	b.config.MaxAgeStr = age
+	return b
+}
+
+// MaxAgeDuration sets the rotation age threshold as a time.Duration.
+// Mutually exclusive with MaxAge.
+func (b *Builder) MaxAgeDuration(age time.Duration) *Builder {
+	b.config.MaxAge = age
+	return b
+}
+
+// RotationJitter delays age-based rotation by a random per-instance
+// amount up to d.
+func (b *Builder) RotationJitter(d time.Duration) *Builder {
+	b.config.RotationJitter = d
+	return b
+}
+
+// RotationMode sets how rotation limits combine ("any" or "all").
+func (b *Builder) RotationMode(mode string) *Builder {
+	b.config.RotationMode = mode
+	return b
+}
+
+// MaxLines sets the line-count rotation threshold.
+func (b *Builder) MaxLines(lines int64) *Builder {
+	b.config.MaxLines = lines
+	return b
+}
+
+// RotateAt sets the wall-clock rotation time ("HH:MM" or "HH:MM:SS").
+func (b *Builder) RotateAt(at string) *Builder {
+	b.config.RotateAt = at
+	return b
+}
+
+// MaxBackups sets how many rotated files to keep (0 = keep all).
+func (b *Builder) MaxBackups(n int) *Builder {
+	b.config.MaxBackups = n
+	return b
+}
+
+// MaxFileAge sets the maximum age of backups before cleanup.
+func (b *Builder) MaxFileAge(age time.Duration) *Builder {
+	b.config.MaxFileAge = age
+	return b
+}
+
+// LocalTime uses local time instead of UTC for backup names.
+func (b *Builder) LocalTime(enabled bool) *Builder {
+	b.config.LocalTime = enabled
+	return b
+}
+
+// TimeZone sets an IANA zone for backup names and RotateAt.
+func (b *Builder) TimeZone(zone string) *Builder {
+	b.config.TimeZone = zone
+	return b
+}
+
+// Compress enables compression of rotated files (gzip unless Compression
+// selects another codec).
+func (b *Builder) Compress(enabled bool) *Builder {
+	b.config.Compress = enabled
+	return b
+}
+
+// CompressMinSize leaves backups below n bytes uncompressed (<0 = none).
+func (b *Builder) CompressMinSize(n int64) *Builder {
+	b.config.CompressMinSize = n
+	return b
+}
+
+// TempDir sets the scratch directory for in-progress compression output.
+func (b *Builder) TempDir(dir string) *Builder {
+	b.config.TempDir = dir
+	return b
+}
+
+// CompressOnClose rotates and compresses the final active file on Close.
+func (b *Builder) CompressOnClose(enabled bool) *Builder {
+	b.config.CompressOnClose = enabled
+	return b
+}
+
+// CompressActive writes the active file as a gzip stream.
+func (b *Builder) CompressActive(enabled bool) *Builder {
+	b.config.CompressActive = enabled
+	return b
+}
+
+// Compression selects the codec used by Compress by name (e.g., "gzip").
+func (b *Builder) Compression(name string) *Builder {
+	b.config.Compression = name
+	return b
+}
+
+// CompressionTiers re-compresses backups with denser codecs as they age.
+func (b *Builder) CompressionTiers(tiers ...CompressionTier) *Builder {
+	b.config.CompressionTiers = tiers
+	return b
+}
+
+// Encryptor encrypts rotated backups at rest; read them with OpenBackup.
+func (b *Builder) Encryptor(enc Encryptor) *Builder {
+	b.config.Encryptor = enc
+	return b
+}
+
+// Checksum enables SHA-256 sidecars for rotated files.
+func (b *Builder) Checksum(enabled bool) *Builder {
+	b.config.Checksum = enabled
+	return b
+}
+
+// ChecksumInterval keeps a live SHA-256 sidecar of the active file,
+// refreshed every d.
+func (b *Builder) ChecksumInterval(d time.Duration) *Builder {
+	b.config.ChecksumInterval = d
+	return b
+}
+
+// Async enables the MPSC buffered write path.
+func (b *Builder) Async(enabled bool) *Builder {
+	b.config.Async = enabled
+	return b
+}
+
+// DisableAutoScale keeps a non-Async logger strictly synchronous.
+func (b *Builder) DisableAutoScale(disabled bool) *Builder {
+	b.config.DisableAutoScale = disabled
+	return b
+}
+
+// AutoScale sets the sync -> MPSC switching thresholds.
+func (b *Builder) AutoScale(thresholds AutoScaleConfig) *Builder {
+	b.config.AutoScale = &thresholds
+	return b
+}
+
+// SampleRate keeps only this fraction of writes (0.1 = 10%).
+func (b *Builder) SampleRate(rate float64) *Builder {
+	b.config.SampleRate = rate
+	return b
+}
+
+// MaxWritesPerSecond caps accepted writes per second.
+func (b *Builder) MaxWritesPerSecond(n int) *Builder {
+	b.config.MaxWritesPerSecond = n
+	return b
+}
+
+// MaxMessageSize caps the size of a single write in bytes (0 = unlimited).
+func (b *Builder) MaxMessageSize(n int64) *Builder {
+	b.config.MaxMessageSize = n
+	return b
+}
+
+// OversizePolicy sets what happens to writes over MaxMessageSize
+// ("reject" or "truncate").
+func (b *Builder) OversizePolicy(policy string) *Builder {
+	b.config.OversizePolicy = policy
+	return b
+}
+
+// PausePolicy sets how writes behave during Pause ("buffer" or "error").
+func (b *Builder) PausePolicy(policy string) *Builder {
+	b.config.PausePolicy = policy
+	return b
+}
+
+// Dedup enables suppression of consecutive identical writes.
+func (b *Builder) Dedup(enabled bool) *Builder {
+	b.config.Dedup = enabled
+	return b
+}
+
+// DedupWindow sets the repeat window used by Dedup.
+func (b *Builder) DedupWindow(d time.Duration) *Builder {
+	b.config.DedupWindow = d
+	return b
+}
+
+// EnsureNewline appends '\n' to writes that do not end in one.
+func (b *Builder) EnsureNewline(enabled bool) *Builder {
+	b.config.EnsureNewline = enabled
+	return b
+}
+
+// BufferSize sets the MPSC ring size in slots (rounded up to a power of 2
+// and to at least MinBufferSize).
+func (b *Builder) BufferSize(slots int) *Builder {
+	b.config.BufferSize = slots
+	return b
+}
+
+// MinBufferSize sets the smallest ring BufferSize is rounded up to.
+func (b *Builder) MinBufferSize(slots int) *Builder {
+	b.config.MinBufferSize = slots
+	return b
+}
+
+// ArenaSlotSize stores async messages inline in fixed cells of size bytes
+// instead of the pointer ring. Larger messages take the backpressure path.
+func (b *Builder) ArenaSlotSize(size int) *Builder {
+	b.config.ArenaSlotSize = size
+	return b
+}
+
+// MaxBufferBytes caps the bytes enqueued in the MPSC buffer.
+func (b *Builder) MaxBufferBytes(n int64) *Builder {
+	b.config.MaxBufferBytes = n
+	return b
+}
+
+// BackpressurePolicy sets the full-buffer behavior ("fallback", "drop", "adaptive", "overflow").
+func (b *Builder) BackpressurePolicy(policy string) *Builder {
+	b.config.BackpressurePolicy = policy
+	return b
+}
+
+// MaxSpillBytes caps the spill file of the "overflow" policy.
+func (b *Builder) MaxSpillBytes(n int64) *Builder {
+	b.config.MaxSpillBytes = n
+	return b
+}
+
+// FlushInterval sets the MPSC consumer flush interval.
+func (b *Builder) FlushInterval(d time.Duration) *Builder {
+	b.config.FlushInterval = d
+	return b
+}
+
+// ConsumerBatchSize sets how many messages the consumer coalesces per write.
+func (b *Builder) ConsumerBatchSize(n int) *Builder {
+	b.config.ConsumerBatchSize = n
+	return b
+}
+
+// StallTimeout reports a consumer that makes no progress for d.
+func (b *Builder) StallTimeout(d time.Duration) *Builder {
+	b.config.StallTimeout = d
+	return b
+}
+
+// IdleTimeout parks the consumer goroutine after d without writes.
+func (b *Builder) IdleTimeout(d time.Duration) *Builder {
+	b.config.IdleTimeout = d
+	return b
+}
+
+// BackgroundWorkers sets the number of post-rotation task workers.
+func (b *Builder) BackgroundWorkers(n int) *Builder {
+	b.config.BackgroundWorkers = n
+	return b
+}
+
+// MaxCompressions caps how many backups are compressed at once.
+func (b *Builder) MaxCompressions(n int) *Builder {
+	b.config.MaxCompressions = n
+	return b
+}
+
+// SyncOnWrite fsyncs after every write (every batch in async mode).
+func (b *Builder) SyncOnWrite(enabled bool) *Builder {
+	b.config.SyncOnWrite = enabled
+	return b
+}
+
+// SyncInterval fsyncs periodically when data was written.
+func (b *Builder) SyncInterval(d time.Duration) *Builder {
+	b.config.SyncInterval = d
+	return b
+}
+
+// BufferedSync batches sync-mode writes in memory, flushed every FlushInterval.
+func (b *Builder) BufferedSync(enabled bool) *Builder {
+	b.config.BufferedSync = enabled
+	return b
+}
+
+// SyncBufferSize sets the BufferedSync buffer size in bytes.
+func (b *Builder) SyncBufferSize(n int) *Builder {
+	b.config.SyncBufferSize = n
+	return b
+}
+
+// BackupFileMode sets the permissions of rotated, compressed and checksum
+// files (default: FileMode).
+func (b *Builder) BackupFileMode(mode os.FileMode) *Builder {
+	b.config.BackupFileMode = mode
+	return b
+}
+
+// DirMode sets the permissions of log directories created for Filename
+// (default: 0750).
+func (b *Builder) DirMode(mode os.FileMode) *Builder {
+	b.config.DirMode = mode
+	return b
+}
+
+// RetryMaxDelay enables exponential backoff of file-operation retries:
+// the delay doubles from RetryDelay up to d (0 = fixed delay).
+func (b *Builder) RetryMaxDelay(d time.Duration) *Builder {
+	b.config.RetryMaxDelay = d
+	return b
+}
+
+// RetryJitter randomizes each retry delay between half and all of it.
+func (b *Builder) RetryJitter(enabled bool) *Builder {
+	b.config.RetryJitter = enabled
+	return b
+}
+
+// Symlink maintains a stable link pointing at the active file.
+func (b *Builder) Symlink(path string) *Builder {
+	b.config.Symlink = path
+	return b
+}
+
+// FallbackFilename sets where writes go while the log file is unwritable.
+func (b *Builder) FallbackFilename(path string) *Builder {
+	b.config.FallbackFilename = path
+	return b
+}
+
+// OnFailover sets the callback for switches to and from FallbackFilename.
+func (b *Builder) OnFailover(fn func(event FailoverEvent)) *Builder {
+	b.config.OnFailover = fn
+	return b
+}
+
+// RecreateIfMissing reopens the active file after external deletion.
+func (b *Builder) RecreateIfMissing(enabled bool) *Builder {
+	b.config.RecreateIfMissing = enabled
+	return b
+}
+
+// InodeCheckInterval reopens the active file when its path is replaced
+// externally, checking at most once per d.
+func (b *Builder) InodeCheckInterval(d time.Duration) *Builder {
+	b.config.InodeCheckInterval = d
+	return b
+}
+
+// PersistState keeps rotation sequence numbers across restarts.
+func (b *Builder) PersistState(enabled bool) *Builder {
+	b.config.PersistState = enabled
+	return b
+}
+
+// Manifest enables the Filename + ".manifest" backup inventory.
+func (b *Builder) Manifest(enabled bool) *Builder {
+	b.config.Manifest = enabled
+	return b
+}
+
+// MultiProcess serializes rotation with other processes sharing the file.
+func (b *Builder) MultiProcess(enabled bool) *Builder {
+	b.config.MultiProcess = enabled
+	return b
+}
+
+// Preallocate reserves MaxSize bytes of disk space for each active file.
+func (b *Builder) Preallocate(enabled bool) *Builder {
+	b.config.Preallocate = enabled
+	return b
+}
+
+// Tee sets a secondary writer that mirrors every write.
+func (b *Builder) Tee(w io.Writer) *Builder {
+	b.config.Tee = w
+	return b
+}
+
+// ErrorCallback sets the handler for internal errors.
+func (b *Builder) ErrorCallback(fn func(operation string, err error)) *Builder {
+	b.config.ErrorCallback = fn
+	return b
+}
+
+// OnError sets the handler for internal errors tagged with the Logger name.
+func (b *Builder) OnError(fn func(event ErrorEvent)) *Builder {
+	b.config.OnError = fn
+	return b
+}
+
+// RotateWhen sets a custom rotation predicate, consulted on every write.
+func (b *Builder) RotateWhen(fn func(currentSize uint64, fileAge time.Duration) bool) *Builder {
+	b.config.RotateWhen = fn
+	return b
+}
+
+// RecordBoundary defers threshold rotation until a write starts a record.
+func (b *Builder) RecordBoundary(fn func(data []byte) bool) *Builder {
+	b.config.RecordBoundary = fn
+	return b
+}
+
+// Transform sets a hook that rewrites (e.g. redacts) each write; nil drops it.
+func (b *Builder) Transform(fn func(data []byte) []byte) *Builder {
+	b.config.Transform = fn
+	return b
+}
+
+// RecentBufferSize keeps the last n writes in memory for RecentLogs.
+func (b *Builder) RecentBufferSize(n int) *Builder {
+	b.config.RecentBufferSize = n
+	return b
+}
+
+// OnRotate sets the rotation callback.
+func (b *Builder) OnRotate(fn func(event RotationEvent)) *Builder {
+	b.config.OnRotate = fn
+	return b
+}
+
+// OnCompress sets the compression callback.
+func (b *Builder) OnCompress(fn func(srcPath, gzPath string, ratio float64)) *Builder {
+	b.config.OnCompress = fn
+	return b
+}
+
+// OnCleanup sets the cleanup callback.
+func (b *Builder) OnCleanup(fn func(removedPaths []string)) *Builder {
+	b.config.OnCleanup = fn
+	return b
+}
+
+// Config returns a copy of the accumulated configuration and the first
+// recorded error, for callers that want to inspect or tweak it before
+// passing it to NewWithConfig.
+func (b *Builder) Config() (LoggerConfig, error) {
+	return b.config, b.validate()
+}
+
+// Build validates the configuration and creates the Logger.
+func (b *Builder) Build() (*Logger, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	config := b.config
+	return NewWithConfig(&config)
+}
+
+// validate returns the first setter error or a cross-field conflict.
+func (b *Builder) validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.config.MaxAge > 0 && b.config.MaxAgeStr != "" {
+		return errors.New("cannot set both MaxAge and MaxAgeDuration; choose one")
+	}
+	return ValidateConfig(&b.config)
+}
@@ -0,0 +1,122 @@
+// builder_test.go: Tests for the fluent configuration builder
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuilder_BuildsConfiguredLogger(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "built.log")
+	logger, err := NewBuilder(logFile).
+		MaxSize("10MB").
+		MaxAge("7d").
+		MaxBackups(3).
+		Compress(true).
+		Async(true).
+		BackpressurePolicy("drop").
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	if logger.MaxSizeStr != "10MB" || logger.MaxBackups != 3 {
+		t.Errorf("MaxSizeStr=%q MaxBackups=%d", logger.MaxSizeStr, logger.MaxBackups)
+	}
+	if logger.MaxAge != 7*24*time.Hour {
+		t.Errorf("MaxAge = %v, want 7d", logger.MaxAge)
+	}
+	if !logger.Compress || !logger.Async || logger.BackpressurePolicy != "drop" {
+		t.Errorf("Compress=%v Async=%v Policy=%q", logger.Compress, logger.Async, logger.BackpressurePolicy)
+	}
+	if _, err := logger.Write([]byte("built\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+}
+
+func TestBuilder_RejectsInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		b    *Builder
+	}{
+		{"empty filename", NewBuilder("")},
+		{"bad size", NewBuilder(filepath.Join(dir, "a.log")).MaxSize("huge")},
+		{"bad age", NewBuilder(filepath.Join(dir, "b.log")).MaxAge("forever")},
+		{"age set twice", NewBuilder(filepath.Join(dir, "c.log")).MaxAge("1h").MaxAgeDuration(time.Hour)},
+		{"bad time zone", NewBuilder(filepath.Join(dir, "d.log")).TimeZone("Nowhere/Land")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := tt.b.Build()
+			if err == nil {
+				_ = logger.Close()
+				t.Fatal("expected Build error")
+			}
+		})
+	}
+}
+
+func TestBuilder_FirstErrorWins(t *testing.T) {
+	_, err := NewBuilder(filepath.Join(t.TempDir(), "e.log")).
+		MaxSize("bogus").
+		MaxSize("10MB").
+		Config()
+	if err == nil {
+		t.Fatal("later valid setter must not clear the earlier error")
+	}
+}
+
+func TestBuilder_Encryptor(t *testing.T) {
+	enc, err := NewAESGCMEncryptor(make([]byte, 32))
+	if err != nil {
+		t.Fatalf("NewAESGCMEncryptor: %v", err)
+	}
+	config, err := NewBuilder(filepath.Join(t.TempDir(), "app.log")).Encryptor(enc).Config()
+	if err != nil {
+		t.Fatalf("Config: %v", err)
+	}
+	if config.Encryptor != enc {
+		t.Error("Encryptor not set on the config")
+	}
+}
+
+func TestBuilder_BackupFileMode(t *testing.T) {
+	config, err := NewBuilder(filepath.Join(t.TempDir(), "app.log")).BackupFileMode(0o600).Config()
+	if err != nil {
+		t.Fatalf("Config: %v", err)
+	}
+	if config.BackupFileMode != 0o600 {
+		t.Errorf("BackupFileMode = %o, want 600", config.BackupFileMode)
+	}
+}
+
+func TestBuilder_DirMode(t *testing.T) {
+	config, err := NewBuilder(filepath.Join(t.TempDir(), "app.log")).DirMode(0o755).Config()
+	if err != nil {
+		t.Fatalf("Config: %v", err)
+	}
+	if config.DirMode != 0o755 {
+		t.Errorf("DirMode = %o, want 755", config.DirMode)
+	}
+}
+
+func TestBuilder_RetryBackoff(t *testing.T) {
+	config, err := NewBuilder(filepath.Join(t.TempDir(), "app.log")).
+		RetryMaxDelay(time.Second).
+		RetryJitter(true).
+		Config()
+	if err != nil {
+		t.Fatalf("Config: %v", err)
+	}
+	if config.RetryMaxDelay != time.Second || !config.RetryJitter {
+		t.Errorf("RetryMaxDelay = %v, RetryJitter = %v; want 1s, true", config.RetryMaxDelay, config.RetryJitter)
+	}
+}
@@ -0,0 +1,72 @@
+// cleanup_test.go: Tests for retention cleanup matching
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCleanup_IgnoresFilesSharingThePrefix(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	old := time.Now().Add(-48 * time.Hour)
+
+	// Files Lethe did not create, all older than MaxFileAge
+	unrelated := []string{"app.log.notes", "app.log.backup-of-something-else", "app.log.2025-01-02"}
+	for _, name := range unrelated {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("keep me"), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	// An expired Lethe backup with its checksum sidecar
+	expired := filepath.Join(dir, "app.log.2025-01-02-15-04-05.gz")
+	for _, path := range []string{expired, expired + ".sha256"} {
+		if err := os.WriteFile(path, []byte("old backup"), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if err := os.Chtimes(path, old, old); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		MaxBackups: 1,
+		MaxFileAge: 24 * time.Hour,
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("data\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	}
+
+	for _, name := range unrelated {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("unrelated file %s was removed: %v", name, err)
+		}
+	}
+	for _, path := range []string{expired, expired + ".sha256"} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expired %s not removed (err=%v)", filepath.Base(path), err)
+		}
+	}
+	if backups, _ := filepath.Glob(logFile + ".*-*-*-*-*-*"); len(backups) != 1 {
+		t.Errorf("backups = %v, want MaxBackups=1", backups)
+	}
+}
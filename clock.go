@@ -0,0 +1,33 @@
+// clock.go: Time source for rotation and retention decisions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "time"
+
+// clock supplies the current time to age checks, backup names and
+// retention. Tests install a fake one to move time without sleeping.
+type clock interface {
+	Now() time.Time
+}
+
+// setClock replaces the time source. Call it before the first write: the
+// field is read without synchronization on the write path.
+func (l *Logger) setClock(c clock) {
+	l.clock = c
+}
+
+// now returns the time from the installed clock, else the time cache
+// (millisecond resolution, no syscall), else time.Now.
+func (l *Logger) now() time.Time {
+	if l.clock != nil {
+		return l.clock.Now()
+	}
+	if l.timeCache != nil {
+		return l.timeCache.CachedTime()
+	}
+	return time.Now()
+}
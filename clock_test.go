@@ -0,0 +1,104 @@
+// clock_test.go: Fake clock for deterministic time-based tests
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestClock_AgeRotationWithoutSleeping(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "aged.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		MaxAgeStr:        "1h",
+		DisableAutoScale: true,
+	})
+	clk := newFakeClock()
+	logger.setClock(clk)
+
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	clk.Advance(59 * time.Minute)
+	if _, err := logger.Write([]byte("still young\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().AgeRotations; got != 0 {
+		t.Fatalf("AgeRotations = %d before MaxAge, want 0", got)
+	}
+
+	clk.Advance(2 * time.Minute)
+	if _, err := logger.Write([]byte("aged out\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().AgeRotations; got != 1 {
+		t.Fatalf("AgeRotations = %d after MaxAge, want 1", got)
+	}
+
+	// The backup is named after the fake time, not the wall clock
+	backup := logFile + "." + clk.Now().In(logger.location()).Format(backupTimeFormat)
+	if _, err := os.Stat(backup); err != nil {
+		t.Errorf("backup %s missing; directory holds %v", backup, listDir(t, filepath.Dir(logFile)))
+	}
+}
+
+func TestClock_CleanupAgesBackupsByClock(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "retained.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		MaxFileAge: 24 * time.Hour,
+	})
+	clk := &fakeClock{now: time.Now()}
+	logger.setClock(clk)
+
+	backup := logFile + ".2025-01-01-00-00-00"
+	if err := os.WriteFile(backup, []byte("old\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := logger.cleanupOldFiles(); err != nil {
+		t.Fatalf("cleanupOldFiles: %v", err)
+	}
+	if _, err := os.Stat(backup); err != nil {
+		t.Fatalf("fresh backup removed before MaxFileAge: %v", err)
+	}
+
+	clk.Advance(25 * time.Hour)
+	if err := logger.cleanupOldFiles(); err != nil {
+		t.Fatalf("cleanupOldFiles: %v", err)
+	}
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Error("backup kept past MaxFileAge on the injected clock")
+	}
+}
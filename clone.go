@@ -0,0 +1,136 @@
+// clone.go: Derive a Logger for another file from an existing one
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Clone returns a new Logger writing to filename with the same
+// configuration as l, e.g. for per-tenant logs that share one policy.
+// Limits, retention and the error callback changed at runtime
+// (SetMaxSize, SetMaxAge, ReconfigureRetention, SetErrorCallback) carry
+// over as they are at the time of the call.
+//
+// Only configuration is copied: the clone has its own file, buffers,
+// counters, time cache and background workers, and must be closed on
+// its own. Callbacks, Encryptor and Tee are shared by reference, so they
+// must be safe for concurrent use by both Loggers; writers added with
+// AddTee are not copied. A relative Symlink resolves next to filename, so
+// clear or change it on the clone (before its first write) when both
+// files live in the same directory. FallbackFilename is not copied: two
+// Loggers must not fail over into the same file. Neither is Name, so the
+// clone is named after filename rather than sharing l's identity.
+//
+// Returns an error if filename is empty or the configuration fails
+// ValidateConfig (possible when l was built as a struct literal).
+func (l *Logger) Clone(filename string) (*Logger, error) {
+	if filename == "" {
+		return nil, errors.New("lethe: Clone: filename cannot be empty")
+	}
+
+	clone, err := NewWithConfig(l.config(filename))
+	if err != nil {
+		return nil, fmt.Errorf("lethe: Clone: %w", err)
+	}
+
+	if size := l.maxSizeBytes.Load(); size != 0 {
+		clone.maxSizeBytes.Store(size)
+	}
+	clone.maxAgeOverride.Store(l.maxAgeOverride.Load())
+	if p := l.retention.Load(); p != nil {
+		policy := *p
+		clone.retention.Store(&policy)
+	}
+	return clone, nil
+}
+
+// config rebuilds the LoggerConfig l was created from, for filename.
+func (l *Logger) config(filename string) *LoggerConfig {
+	return &LoggerConfig{
+		Filename:           filename,
+		MaxSize:            l.MaxSize,
+		MaxBackups:         l.MaxBackups,
+		MaxSizeStr:         l.MaxSizeStr,
+		MaxAgeStr:          l.MaxAgeStr,
+		MaxLines:           l.MaxLines,
+		RotateAt:           l.RotateAt,
+		RotateWhen:         l.RotateWhen,
+		RecordBoundary:     l.RecordBoundary,
+		MaxAge:             l.MaxAge,
+		MaxFileAge:         l.MaxFileAge,
+		RotationJitter:     l.RotationJitter,
+		RotationMode:       l.RotationMode,
+		LocalTime:          l.LocalTime,
+		TimeZone:           l.TimeZone,
+		Compress:           l.Compress,
+		Compression:        l.Compression,
+		CompressOnClose:    l.CompressOnClose,
+		CompressActive:     l.CompressActive,
+		CompressionTiers:   l.CompressionTiers, // Copied by NewWithConfig
+		CompressMinSize:    l.CompressMinSize,
+		TempDir:            l.TempDir,
+		Checksum:           l.Checksum,
+		ChecksumInterval:   l.ChecksumInterval,
+		Async:              l.Async,
+		SampleRate:         l.SampleRate,
+		MaxWritesPerSecond: l.MaxWritesPerSecond,
+		PausePolicy:        l.PausePolicy,
+		MaxMessageSize:     l.MaxMessageSize,
+		OversizePolicy:     l.OversizePolicy,
+		Dedup:              l.Dedup,
+		DedupWindow:        l.DedupWindow,
+		EnsureNewline:      l.EnsureNewline,
+		DisableAutoScale:   l.DisableAutoScale,
+		AutoScale:          l.AutoScale, // Copied by NewWithConfig
+		ErrorCallback:      l.errorCallbackFunc(),
+		PreWriteHook:       l.preWriteHook,
+		Transform:          l.Transform,
+		RecentBufferSize:   l.RecentBufferSize,
+		FileMode:           l.FileMode,
+		BackupFileMode:     l.BackupFileMode,
+		DirMode:            l.DirMode,
+		RetryCount:         l.RetryCount,
+		RetryDelay:         l.RetryDelay,
+		RetryMaxDelay:      l.RetryMaxDelay,
+		RetryJitter:        l.RetryJitter,
+		BackgroundWorkers:  l.BackgroundWorkers,
+		MaxCompressions:    l.MaxCompressions,
+		BufferSize:         l.BufferSize,
+		MinBufferSize:      l.MinBufferSize,
+		ArenaSlotSize:      l.ArenaSlotSize,
+		MaxBufferBytes:     l.MaxBufferBytes,
+		BackpressurePolicy: l.BackpressurePolicy,
+		MaxSpillBytes:      l.MaxSpillBytes,
+		FlushInterval:      l.FlushInterval,
+		AdaptiveFlush:      l.AdaptiveFlush,
+		ConsumerBatchSize:  l.ConsumerBatchSize,
+		StallTimeout:       l.StallTimeout,
+		IdleTimeout:        l.IdleTimeout,
+		SyncOnWrite:        l.SyncOnWrite,
+		SyncInterval:       l.SyncInterval,
+		BufferedSync:       l.BufferedSync,
+		SyncBufferSize:     l.SyncBufferSize,
+		MetricsCallback:    l.metricsCallback,
+		MetricsInterval:    l.metricsInterval,
+		OnRotate:           l.OnRotate,
+		OnError:            l.OnError,
+		OnCompress:         l.OnCompress,
+		OnCleanup:          l.OnCleanup,
+		Symlink:            l.Symlink,
+		RecreateIfMissing:  l.RecreateIfMissing,
+		InodeCheckInterval: l.InodeCheckInterval,
+		OnFailover:         l.OnFailover,
+		Encryptor:          l.Encryptor,
+		Tee:                l.Tee,
+		Manifest:           l.Manifest,
+		PersistState:       l.PersistState,
+		MultiProcess:       l.MultiProcess,
+		Preallocate:        l.Preallocate,
+	}
+}
@@ -0,0 +1,180 @@
+// clone_test.go: Tests for Logger.Clone
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClone_CopiesConfiguration(t *testing.T) {
+	dir := t.TempDir()
+	var rotations int
+	parent, err := NewWithConfig(&LoggerConfig{
+		Filename:        filepath.Join(dir, "tenant-a.log"),
+		MaxSizeStr:      "1KB",
+		MaxBackups:      3,
+		Compress:        true,
+		CompressMinSize: -1,
+		FileMode:        0600,
+		RetryCount:      5,
+		AutoScale:       &AutoScaleConfig{},
+		OnRotate:        func(RotationEvent) { rotations++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = parent.Close() }()
+
+	cloneName := filepath.Join(dir, "tenant-b.log")
+	clone, err := parent.Clone(cloneName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = clone.Close() }()
+
+	if clone.Filename != cloneName {
+		t.Errorf("Filename: got %q, want %q", clone.Filename, cloneName)
+	}
+	if clone.MaxSizeStr != "1KB" || clone.MaxBackups != 3 || !clone.Compress ||
+		clone.CompressMinSize != -1 || clone.FileMode != 0600 || clone.RetryCount != 5 {
+		t.Errorf("configuration not copied: %+v", clone)
+	}
+	if clone.AutoScale == parent.AutoScale {
+		t.Error("AutoScale thresholds shared with the parent instead of copied")
+	}
+
+	// Exceed the limit on the clone only: it rotates, the parent does not
+	for i := 0; i < 3; i++ {
+		if _, err := clone.Write(make([]byte, 700)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := parent.Write([]byte("parent\n")); err != nil {
+		t.Fatal(err)
+	}
+	if clone.Stats().RotationCount == 0 {
+		t.Error("clone did not rotate past MaxSizeStr")
+	}
+	if parent.Stats().RotationCount != 0 {
+		t.Errorf("parent RotationCount: got %d, want 0", parent.Stats().RotationCount)
+	}
+	if rotations == 0 {
+		t.Error("OnRotate callback not carried over to the clone")
+	}
+}
+
+func TestClone_IndependentRuntimeState(t *testing.T) {
+	dir := t.TempDir()
+	parent, err := NewWithConfig(&LoggerConfig{
+		Filename: filepath.Join(dir, "a.log"),
+		Async:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = parent.Close() }()
+	if _, err := parent.Write([]byte("parent line\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := parent.Clone(filepath.Join(dir, "b.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.timeCache == parent.timeCache {
+		t.Error("clone shares the parent's time cache")
+	}
+	if clone.Stats().WriteCount != 0 || clone.buffer.Load() != nil || clone.currentFile.Load() != nil {
+		t.Error("clone inherited runtime state from the parent")
+	}
+
+	if _, err := clone.Write([]byte("clone line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the clone must leave the parent (and its workers) running
+	if _, err := parent.Write([]byte("after clone close\n")); err != nil {
+		t.Fatalf("parent write after clone Close: %v", err)
+	}
+	if err := parent.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readLog(t, filepath.Join(dir, "b.log")); got != "clone line\n" {
+		t.Errorf("clone file: got %q", got)
+	}
+	if got := readLog(t, filepath.Join(dir, "a.log")); got != "parent line\nafter clone close\n" {
+		t.Errorf("parent file: got %q", got)
+	}
+}
+
+func TestClone_CarriesRuntimeLimits(t *testing.T) {
+	dir := t.TempDir()
+	parent, err := NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(dir, "a.log"),
+		MaxSizeStr: "10MB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = parent.Close() }()
+
+	if err := parent.SetMaxSize("2KB"); err != nil {
+		t.Fatal(err)
+	}
+	if err := parent.SetMaxAge("1h"); err != nil {
+		t.Fatal(err)
+	}
+	if err := parent.ReconfigureRetention(RetentionPolicy{MaxBackups: 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := parent.Clone(filepath.Join(dir, "b.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = clone.Close() }()
+
+	if got := clone.Stats().MaxSizeBytes; got != 2048 {
+		t.Errorf("MaxSizeBytes: got %d, want 2048", got)
+	}
+	if got := time.Duration(clone.maxAgeOverride.Load()); got != time.Hour {
+		t.Errorf("MaxAge override: got %v, want 1h", got)
+	}
+	if got := clone.effectiveRetention().MaxBackups; got != 7 {
+		t.Errorf("retention MaxBackups: got %d, want 7", got)
+	}
+
+	// The clone's policy is a copy: changing it leaves the parent alone
+	if err := clone.SetMaxSize("4KB"); err != nil {
+		t.Fatal(err)
+	}
+	if got := parent.Stats().MaxSizeBytes; got != 2048 {
+		t.Errorf("parent MaxSizeBytes changed to %d", got)
+	}
+}
+
+func TestClone_Errors(t *testing.T) {
+	parent := &Logger{Filename: filepath.Join(t.TempDir(), "a.log")}
+	if _, err := parent.Clone(""); err == nil {
+		t.Error("Clone(\"\") succeeded, want error")
+	}
+
+	invalid := &Logger{Filename: "a.log", PausePolicy: "later"}
+	if _, err := invalid.Clone(filepath.Join(t.TempDir(), "b.log")); err == nil {
+		t.Error("Clone of an invalid configuration succeeded, want error")
+	}
+
+	if _, err := os.Stat(parent.Filename); !os.IsNotExist(err) {
+		t.Error("Clone touched the parent's file")
+	}
+}
@@ -0,0 +1,171 @@
+// close_test.go: Tests for CloseContext
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// blockingEncryptor blocks Encrypt until release is closed, simulating a
+// background task stuck on a slow disk.
+type blockingEncryptor struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e blockingEncryptor) Encrypt(dst io.Writer, src io.Reader) error {
+	close(e.started)
+	<-e.release
+	return nil
+}
+
+func (e blockingEncryptor) Decrypt(src io.Reader) (io.Reader, error) { return src, nil }
+
+func TestCloseContext_TimesOutButClosesFile(t *testing.T) {
+	enc := blockingEncryptor{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(enc.release)
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "stuck.log")})
+	logger.Encryptor = enc
+
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	<-enc.started // A worker is now stuck
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := logger.CloseContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CloseContext error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("CloseContext took %v, want about the 50ms deadline", elapsed)
+	}
+
+	// The descriptor was closed despite the timeout
+	if _, err := logger.currentFile.Load().Write([]byte("x")); err == nil {
+		t.Error("active file still writable after CloseContext timeout")
+	}
+
+	// Later calls are no-ops, like Close
+	if err := logger.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
+
+// TestCloseContext_TimeoutWaitsForWriteInProgress verifies a timed-out
+// CloseContext leaves the file open under a consumer stuck mid-write, stops
+// the drain there, and closes the file once that write returns.
+func TestCloseContext_TimeoutWaitsForWriteInProgress(t *testing.T) {
+	logger, logFile, tee := newStuckLogger(t, 5)
+	file := logger.currentFile.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CloseContext = %v, want DeadlineExceeded", err)
+	}
+	if _, err := file.Write(nil); err != nil {
+		t.Fatalf("file closed under the stuck consumer: %v", err)
+	}
+
+	close(tee.release)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := file.Write(nil); errors.Is(err, os.ErrClosed) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("file never closed after the stuck write returned")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The queued messages were abandoned, not written after the timeout
+	if got := readLog(t, logFile); got != "entry 00\n" {
+		t.Errorf("log = %q, want only the message being written at the deadline", got)
+	}
+}
+
+func TestCloseContext_CompletesWithinDeadline(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: filepath.Join(t.TempDir(), "clean.log"),
+		Async:    true,
+	})
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := logger.CloseContext(ctx); err != nil {
+		t.Fatalf("CloseContext = %v, want nil", err)
+	}
+}
+
+func TestClose_IsIdempotent(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "twice.log")})
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var closer io.Closer = logger
+	if err := closer.Close(); err != nil {
+		t.Fatalf("first Close: %v", err)
+	}
+	if err := closer.Close(); err != nil {
+		t.Errorf("second Close: %v, want nil", err)
+	}
+}
+
+func TestClose_WriteAfterCloseReturnsErrClosed(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		// CompressOnClose leaves no active file behind, so a write that
+		// reopened it would be visible as a new file
+		logFile := filepath.Join(t.TempDir(), "closed.log")
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:        logFile,
+			Async:           async,
+			Compress:        true,
+			CompressOnClose: true,
+		})
+		if _, err := logger.Write([]byte("data\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		writes := map[string]func() (int, error){
+			"Write":        func() (int, error) { return logger.Write([]byte("late\n")) },
+			"WriteString":  func() (int, error) { return logger.WriteString("late\n") },
+			"WriteOwned":   func() (int, error) { return logger.WriteOwned([]byte("late\n")) },
+			"WriteBatch":   func() (int, error) { return logger.WriteBatch([][]byte{[]byte("late\n")}) },
+			"WriteContext": func() (int, error) { return logger.WriteContext(context.Background(), []byte("late\n")) },
+		}
+		for name, write := range writes {
+			if n, err := write(); !errors.Is(err, ErrClosed) || n != 0 {
+				t.Errorf("async=%v %s after Close = (%d, %v), want (0, ErrClosed)", async, name, n, err)
+			}
+		}
+
+		if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+			t.Errorf("async=%v: active file exists after Close (err=%v), want it not reopened", async, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// closestats_test.go: Tests for the Close drain report
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStuckLogger returns an async Logger whose consumer is stuck on tee
+// with n messages queued behind the one it is writing.
+func newStuckLogger(t *testing.T, n int) (*Logger, string, *blockingWriter) {
+	t.Helper()
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logFile := filepath.Join(t.TempDir(), "drain.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Async: true, Tee: tee})
+
+	if _, err := logger.Write([]byte("entry 00\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-tee.entered // The consumer is now stuck
+	for i := 1; i <= n; i++ {
+		if _, err := logger.Write([]byte(fmt.Sprintf("entry %02d\n", i))); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	return logger, logFile, tee
+}
+
+func TestCloseStats_ReportsDrainedMessages(t *testing.T) {
+	logger, logFile, tee := newStuckLogger(t, 20)
+	time.AfterFunc(20*time.Millisecond, func() { close(tee.release) })
+
+	stats, err := logger.CloseStats()
+	if err != nil {
+		t.Fatalf("CloseStats: %v", err)
+	}
+	if stats.ShutdownPending != 20 {
+		t.Errorf("ShutdownPending = %d, want 20 queued messages", stats.ShutdownPending)
+	}
+	if stats.ShutdownFlushed != stats.ShutdownPending || stats.ShutdownLost != 0 {
+		t.Errorf("ShutdownFlushed = %d, ShutdownLost = %d; want every pending message flushed",
+			stats.ShutdownFlushed, stats.ShutdownLost)
+	}
+	if stats.ShutdownDrainNs < uint64(20*time.Millisecond) {
+		t.Errorf("ShutdownDrainNs = %v, want at least the 20ms the consumer was stuck",
+			time.Duration(stats.ShutdownDrainNs))
+	}
+	if got := strings.Count(readLog(t, logFile), "\n"); got != 21 {
+		t.Errorf("log has %d lines, want 21", got)
+	}
+
+	// Later calls return the same report
+	again, err := logger.CloseStats()
+	if err != nil || again.ShutdownFlushed != stats.ShutdownFlushed {
+		t.Errorf("second CloseStats = (%d, %v), want (%d, nil)", again.ShutdownFlushed, err, stats.ShutdownFlushed)
+	}
+}
+
+// TestCloseStats_CountsLostOnTimeout verifies messages a timed-out
+// CloseContext gives up on are reported as lost.
+func TestCloseStats_CountsLostOnTimeout(t *testing.T) {
+	logger, _, tee := newStuckLogger(t, 5)
+	defer close(tee.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CloseContext = %v, want DeadlineExceeded", err)
+	}
+
+	stats := logger.Stats()
+	if stats.ShutdownPending != 5 || stats.ShutdownLost != 5 || stats.ShutdownFlushed != 0 {
+		t.Errorf("ShutdownPending/Flushed/Lost = %d/%d/%d, want 5/0/5",
+			stats.ShutdownPending, stats.ShutdownFlushed, stats.ShutdownLost)
+	}
+}
+
+func TestCloseStats_SyncModeReportsNothingPending(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "sync.log")})
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	stats, err := logger.CloseStats()
+	if err != nil {
+		t.Fatalf("CloseStats: %v", err)
+	}
+	if stats.ShutdownPending != 0 || stats.ShutdownLost != 0 {
+		t.Errorf("ShutdownPending = %d, ShutdownLost = %d; want 0 in sync mode", stats.ShutdownPending, stats.ShutdownLost)
+	}
+}
@@ -0,0 +1,231 @@
+// compress.go: Pluggable compression codecs for rotated backups
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// defaultCompression is the codec used when Compression is empty.
+const defaultCompression = "gzip"
+
+// Compressor is a compression codec for rotated backups. Lethe ships
+// "gzip"; other codecs (e.g., zstd or snappy from a third-party package)
+// are added with RegisterCompressor, which keeps Lethe itself free of
+// external dependencies.
+//
+// Backups carry the codec's Extension, and OpenBackup recognizes both the
+// extension and the Magic prefix, so readers need no configuration.
+type Compressor interface {
+	// Name is the value selected by Compression (e.g., "zstd").
+	Name() string
+
+	// Extension is the suffix appended to compressed backups, including
+	// the leading dot (e.g., ".zst").
+	Extension() string
+
+	// Magic is the fixed prefix of every compressed stream, used to detect
+	// the codec when the file name does not reveal it. May be empty.
+	Magic() []byte
+
+	// NewWriter returns a writer that compresses into w. Close must flush
+	// the stream but not close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+
+	// NewReader returns a reader that decompresses r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// gzipCompressor is the built-in "gzip" codec.
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string      { return "gzip" }
+func (gzipCompressor) Extension() string { return ".gz" }
+func (gzipCompressor) Magic() []byte     { return []byte{0x1f, 0x8b} }
+
+func (gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCompressor) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// WHY RWMutex and a slice: codecs are registered once at startup and read
+// only on rotation and OpenBackup, never on the write path, and a handful
+// of entries is faster to scan than to hash.
+var (
+	compressorsMu sync.RWMutex
+	compressors   = []Compressor{gzipCompressor{}}
+)
+
+// reservedExtensions are suffixes Lethe already gives another meaning.
+var reservedExtensions = []string{encryptedSuffix, ".tmp", ".sha256", stateSuffix, lockSuffix, manifestSuffix, spillSuffix}
+
+// RegisterCompressor makes a codec available to Compression and OpenBackup.
+// Call it from an init function or before constructing Loggers.
+//
+// Returns an error if the name or extension is empty or already
+// registered, if the extension lacks a leading dot, contains another dot
+// or a path separator, is purely numeric (it would look like a backup
+// collision counter), or is one of Lethe's own suffixes.
+//
+// Example (with github.com/klauspost/compress/zstd):
+//
+//	type zstdCodec struct{}
+//
+//	func (zstdCodec) Name() string      { return "zstd" }
+//	func (zstdCodec) Extension() string { return ".zst" }
+//	func (zstdCodec) Magic() []byte     { return []byte{0x28, 0xb5, 0x2f, 0xfd} }
+//	func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) }
+//	func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return d.IOReadCloser(), nil
+//	}
+//
+//	_ = lethe.RegisterCompressor(zstdCodec{})
+func RegisterCompressor(c Compressor) error {
+	if c == nil {
+		return errors.New("compressor cannot be nil")
+	}
+	name, ext := c.Name(), c.Extension()
+	if name == "" {
+		return errors.New("compressor name cannot be empty")
+	}
+	if err := validateCompressorExtension(ext); err != nil {
+		return fmt.Errorf("compressor %q: %w", name, err)
+	}
+
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	for _, existing := range compressors {
+		if existing.Name() == name {
+			return fmt.Errorf("compressor %q is already registered", name)
+		}
+		if existing.Extension() == ext {
+			return fmt.Errorf("compressor %q: extension %q is already used by %q", name, ext, existing.Name())
+		}
+	}
+	compressors = append(compressors, c)
+	return nil
+}
+
+// validateCompressorExtension rejects extensions that would make backup
+// names ambiguous.
+func validateCompressorExtension(ext string) error {
+	rest, ok := strings.CutPrefix(ext, ".")
+	if !ok || rest == "" {
+		return fmt.Errorf("extension %q must start with a dot", ext)
+	}
+	if strings.ContainsAny(rest, `./\`) {
+		return fmt.Errorf("extension %q must be a single suffix", ext)
+	}
+	if strings.Trim(rest, "0123456789") == "" {
+		return fmt.Errorf("extension %q cannot be numeric", ext)
+	}
+	for _, reserved := range reservedExtensions {
+		if ext == reserved {
+			return fmt.Errorf("extension %q is reserved", ext)
+		}
+	}
+	return nil
+}
+
+// lookupCompressor returns the codec registered under name ("" = gzip).
+func lookupCompressor(name string) (Compressor, bool) {
+	if name == "" {
+		name = defaultCompression
+	}
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	for _, c := range compressors {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// compressorForPath returns the codec whose extension ends path, if any.
+func compressorForPath(path string) (Compressor, bool) {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	for _, c := range compressors {
+		if strings.HasSuffix(path, c.Extension()) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// compressedExtensions returns the extensions of all registered codecs.
+func compressedExtensions() []string {
+	compressorsMu.RLock()
+	defer compressorsMu.RUnlock()
+	exts := make([]string, len(compressors))
+	for i, c := range compressors {
+		exts[i] = c.Extension()
+	}
+	return exts
+}
+
+// trimCompressedSuffix strips a registered codec extension from name.
+func trimCompressedSuffix(name string) string {
+	if c, ok := compressorForPath(name); ok {
+		return strings.TrimSuffix(name, c.Extension())
+	}
+	return name
+}
+
+// backupForms lists every name a background worker may turn base into:
+// base itself, each compressed form, and the encrypted variants.
+func backupForms(base string) []string {
+	exts := compressedExtensions()
+	forms := make([]string, 0, 2+2*len(exts))
+	forms = append(forms, base, base+encryptedSuffix)
+	for _, ext := range exts {
+		forms = append(forms, base+ext, base+ext+encryptedSuffix)
+	}
+	return forms
+}
+
+// detectCompressor wraps r in a buffered reader and reports the codec
+// whose Magic prefixes the stream, if any. The returned reader must be
+// used in place of r, since peeked bytes are not consumed.
+func detectCompressor(r io.Reader) (io.Reader, Compressor, bool) {
+	compressorsMu.RLock()
+	candidates := make([]Compressor, 0, len(compressors))
+	longest := 0
+	for _, c := range compressors {
+		if magic := c.Magic(); len(magic) > 0 {
+			candidates = append(candidates, c)
+			longest = max(longest, len(magic))
+		}
+	}
+	compressorsMu.RUnlock()
+
+	br := bufio.NewReader(r)
+	// A short or failed peek just means fewer bytes to match against;
+	// the real error, if any, surfaces on the caller's first Read.
+	head, _ := br.Peek(longest)
+	for _, c := range candidates {
+		if bytes.HasPrefix(head, c.Magic()) {
+			return br, c, true
+		}
+	}
+	return br, nil, false
+}
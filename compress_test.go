@@ -0,0 +1,162 @@
+// compress_test.go: Tests for the pluggable compression registry
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// zlibCodec stands in for a third-party codec such as zstd or snappy.
+type zlibCodec struct{}
+
+func (zlibCodec) Name() string      { return "zlib" }
+func (zlibCodec) Extension() string { return ".zz" }
+func (zlibCodec) Magic() []byte     { return []byte{0x78, 0x9c} }
+
+func (zlibCodec) NewWriter(w io.Writer) (io.WriteCloser, error) { return zlib.NewWriter(w), nil }
+func (zlibCodec) NewReader(r io.Reader) (io.ReadCloser, error)  { return zlib.NewReader(r) }
+
+var registerZlibOnce sync.Once
+
+func registerZlib(t *testing.T) {
+	t.Helper()
+	registerZlibOnce.Do(func() {
+		if err := RegisterCompressor(zlibCodec{}); err != nil {
+			t.Fatalf("RegisterCompressor: %v", err)
+		}
+	})
+}
+
+// rotateCompressed writes content, rotates with the named codec and returns
+// the path of the single compressed backup.
+func rotateCompressed(t *testing.T, codec string, content []byte) string {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressMinSize: -1,
+		Compression:     codec,
+	})
+
+	if _, err := logger.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+
+	c, _ := lookupCompressor(codec)
+	backups, _ := filepath.Glob(logFile + ".*" + c.Extension())
+	if len(backups) != 1 {
+		t.Fatalf("compressed backups = %v, want exactly one", backups)
+	}
+	return backups[0]
+}
+
+func readBackup(t *testing.T, path string) []byte {
+	t.Helper()
+	r, err := OpenBackup(path, nil)
+	if err != nil {
+		t.Fatalf("OpenBackup(%s): %v", filepath.Base(path), err)
+	}
+	defer func() { _ = r.Close() }()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	return data
+}
+
+func TestCompressor_RoundTripPerCodec(t *testing.T) {
+	registerZlib(t)
+	content := bytes.Repeat([]byte("round trip through the codec registry\n"), 200)
+
+	for _, codec := range []string{"gzip", "zlib"} {
+		t.Run(codec, func(t *testing.T) {
+			backup := rotateCompressed(t, codec, content)
+			if got := readBackup(t, backup); !bytes.Equal(got, content) {
+				t.Errorf("OpenBackup returned %d bytes, want the original %d", len(got), len(content))
+			}
+		})
+	}
+}
+
+func TestCompressor_DetectsCodecByMagic(t *testing.T) {
+	registerZlib(t)
+	content := []byte("no extension to go by\n")
+
+	for _, codec := range []string{"gzip", "zlib"} {
+		t.Run(codec, func(t *testing.T) {
+			backup := rotateCompressed(t, codec, content)
+			c, _ := lookupCompressor(codec)
+			renamed := strings.TrimSuffix(backup, c.Extension()) + ".archived"
+			if err := os.Rename(backup, renamed); err != nil {
+				t.Fatalf("Rename: %v", err)
+			}
+			if got := readBackup(t, renamed); !bytes.Equal(got, content) {
+				t.Errorf("OpenBackup = %q, want %q", got, content)
+			}
+		})
+	}
+}
+
+func TestCompressor_DefaultIsGzip(t *testing.T) {
+	backup := rotateCompressed(t, "", []byte("default codec\n"))
+	if !strings.HasSuffix(backup, ".gz") {
+		t.Errorf("backup %s, want .gz", filepath.Base(backup))
+	}
+}
+
+func TestRegisterCompressor_Rejects(t *testing.T) {
+	registerZlib(t)
+	tests := []struct {
+		name  string
+		codec Compressor
+	}{
+		{"nil", nil},
+		{"duplicate name", namedCodec{name: "gzip", ext: ".gzip2"}},
+		{"duplicate extension", namedCodec{name: "other", ext: ".gz"}},
+		{"empty name", namedCodec{name: "", ext: ".x"}},
+		{"no dot", namedCodec{name: "x", ext: "x"}},
+		{"numeric", namedCodec{name: "x", ext: ".1"}},
+		{"multi-part", namedCodec{name: "x", ext: ".tar.x"}},
+		{"reserved", namedCodec{name: "x", ext: encryptedSuffix}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterCompressor(tt.codec); err == nil {
+				t.Error("RegisterCompressor succeeded, want error")
+			}
+		})
+	}
+}
+
+// namedCodec is a Compressor with configurable identity for validation tests.
+type namedCodec struct {
+	zlibCodec
+	name, ext string
+}
+
+func (c namedCodec) Name() string      { return c.name }
+func (c namedCodec) Extension() string { return c.ext }
+
+func TestIsBackupSuffix_RegisteredExtension(t *testing.T) {
+	registerZlib(t)
+	for _, suffix := range []string{"2025-01-02-15-04-05.zz", "2025-01-02-15-04-05.3.zz.enc"} {
+		if !isBackupSuffix(suffix) {
+			t.Errorf("isBackupSuffix(%q) = false, want true", suffix)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// compressactive.go: Gzip-compressed active log file (CompressActive)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"compress/gzip"
+	"os"
+	"sync"
+	"time"
+)
+
+// compressActiveFlushInterval is how often CompressActive makes buffered
+// output readable. WHY not FlushInterval: each flush ends a deflate block,
+// and at the 1ms default the blocks get too small to compress well.
+const compressActiveFlushInterval = time.Second
+
+// activeGzip is the gzip stream in front of the active file. Each file
+// gets its own gzip member, and every open (including after a restart)
+// starts a new one; concatenated members decode as one stream, so the
+// file stays valid gzip however often the process restarts.
+//
+// WHY a mutex: gzip.Writer is not safe for concurrent use, and in sync
+// mode writers arrive from any goroutine. Compression costs far more
+// than the uncontended lock.
+type activeGzip struct {
+	mu      sync.Mutex
+	zw      *gzip.Writer
+	file    *os.File // File the open member belongs to; nil until the next write
+	member  bool     // A member has been started on file
+	pending bool     // Data written since the last flush
+}
+
+// write compresses data into the active file's member, finishing the
+// member of the previous file when rotation swapped it.
+func (g *activeGzip) write(l *Logger, data []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if file := l.currentFile.Load(); g.file != file {
+		if err := g.finishLocked(); err != nil {
+			l.reportWriteError("compress_active", err)
+		}
+		g.file = file
+	}
+	if !g.member {
+		g.zw.Reset(g.file)
+		g.member = true
+	}
+
+	n, err := g.zw.Write(data)
+	g.pending = true
+	if err != nil {
+		// gzip.Writer errors are sticky; the next write starts a new member
+		g.member = false
+	}
+	return n, err
+}
+
+// flushLocked makes everything written so far decodable from the file.
+// The caller holds g.mu.
+func (g *activeGzip) flushLocked() error {
+	if !g.member || !g.pending {
+		return nil
+	}
+	g.pending = false
+	return g.zw.Flush()
+}
+
+// finishLocked writes the member trailer. The caller holds g.mu.
+func (g *activeGzip) finishLocked() error {
+	if !g.member {
+		return nil
+	}
+	g.member, g.pending = false, false
+	return g.zw.Close() // Closes the member, not the file
+}
+
+// startCompressActive creates the gzip stream and its periodic flush
+// goroutine once per Logger.
+func (l *Logger) startCompressActive() {
+	if !l.CompressActive || l.activeGz.Load() != nil {
+		return
+	}
+	if !l.activeGz.CompareAndSwap(nil, &activeGzip{zw: gzip.NewWriter(nil)}) {
+		return // Someone else started it
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	l.activeGzLoop.Store(s)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(compressActiveFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if l.enterFS() { // Skipped while paused
+					if err := l.flushSyncBuffer(); err != nil && !isFileAlreadyClosedError(err) {
+						l.reportWriteError("compress_active", err)
+					}
+					l.exitFS()
+				}
+			}
+		}
+	}()
+}
+
+// writeCompressed is the CompressActive counterpart of writeFull. The
+// returned count is of uncompressed bytes, so MaxSize and the byte
+// counters measure log data, not its size on disk.
+func (l *Logger) writeCompressed(data []byte) (int, error) {
+	g := l.activeGz.Load()
+	if g == nil {
+		l.startCompressActive()
+		g = l.activeGz.Load()
+	}
+	return g.write(l, data)
+}
+
+// closeActiveGzip finishes the open member at Close, so the file ends
+// with a complete gzip trailer.
+func (l *Logger) closeActiveGzip() error {
+	g := l.activeGz.Load()
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	err := g.finishLocked()
+	g.file = nil
+	return err
+}
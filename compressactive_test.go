@@ -0,0 +1,128 @@
+// compressactive_test.go: Tests for CompressActive
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// readGzip decodes path. A missing trailer (the member is still open) is
+// tolerated when allowOpen is set.
+func readGzip(t *testing.T, path string, allowOpen bool) string {
+	t.Helper()
+	f, err := os.Open(path) // #nosec G304 -- test path
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("%s is not gzip: %v", path, err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil && !(allowOpen && errors.Is(err, io.ErrUnexpectedEOF)) {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestCompressActive_LiveFileIsGzip(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "active.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, CompressActive: true})
+
+	want := strings.Repeat("a highly repetitive log line\n", 200)
+	if _, err := logger.Write([]byte(want)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	// Readable while the member is still open
+	if got := readGzip(t, logFile, true); got != want {
+		t.Errorf("live decode returned %d bytes, want %d", len(got), len(want))
+	}
+	if info, err := os.Stat(logFile); err != nil || info.Size() >= int64(len(want)) {
+		t.Errorf("active file not compressed on disk: %v, %v", info.Size(), err)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := readGzip(t, logFile, false); got != want {
+		t.Errorf("decode after Close returned %d bytes, want %d", len(got), len(want))
+	}
+}
+
+func TestCompressActive_RotationRenamesToGz(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "rotated.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:       logFile,
+		CompressActive: true,
+		Compress:       true, // Must not compress the backup a second time
+	})
+
+	if _, err := logger.Write([]byte("first file\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	logger.WaitForBackgroundTasks()
+
+	backups, _ := filepath.Glob(logFile + ".*")
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".gz") || strings.HasSuffix(backups[0], ".gz.gz") {
+		t.Fatalf("backups = %v, want one .gz", backups)
+	}
+	if got := readGzip(t, backups[0], false); got != "first file\n" {
+		t.Errorf("backup content = %q", got)
+	}
+
+	if _, err := logger.Write([]byte("second file\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := readGzip(t, logFile, true); got != "second file\n" {
+		t.Errorf("new active file content = %q", got)
+	}
+}
+
+// TestCompressActive_ReopenAppendsMember verifies a restart appends a new
+// member that decodes together with the old one.
+func TestCompressActive_ReopenAppendsMember(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "restarted.log")
+	for _, line := range []string{"first run\n", "second run\n"} {
+		logger := newTestLogger(t, &LoggerConfig{Filename: logFile, CompressActive: true, Async: true})
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	if got := readGzip(t, logFile, false); got != "first run\nsecond run\n" {
+		t.Errorf("decoded = %q", got)
+	}
+}
+
+func TestCompressActive_ValidateConfig(t *testing.T) {
+	if err := ValidateConfig(&LoggerConfig{Filename: "app.log", CompressActive: true, Compression: "zstd"}); err == nil {
+		t.Error("CompressActive accepted a non-gzip Compression")
+	}
+	if err := ValidateConfig(&LoggerConfig{Filename: "app.log", CompressActive: true, BufferedSync: true}); err == nil {
+		t.Error("CompressActive accepted with BufferedSync")
+	}
+}
@@ -0,0 +1,40 @@
+// compresslimit.go: Bound on concurrent compressions (MaxCompressions)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+// maxCompressions returns MaxCompressions with the default
+// (BackgroundWorkers, i.e. no extra limit) applied.
+func (l *Logger) maxCompressions() int {
+	if l.MaxCompressions > 0 {
+		return l.MaxCompressions
+	}
+	return l.backgroundWorkerCount()
+}
+
+// acquireCompressSlot blocks until fewer than MaxCompressions
+// compressions run, and returns the function releasing the slot.
+//
+// WHY a channel semaphore on the worker itself: compression is the only
+// CPU-heavy task; cleanup and checksum tasks must keep running on the
+// other workers during a rotation storm, so the limit cannot simply be a
+// smaller pool.
+func (l *Logger) acquireCompressSlot() (release func()) {
+	sem := l.compressSem.Load()
+	if sem == nil {
+		// Lazily created so Loggers built as struct literals work too
+		ch := make(chan struct{}, l.maxCompressions())
+		l.compressSem.CompareAndSwap(nil, &ch)
+		sem = l.compressSem.Load()
+	}
+
+	*sem <- struct{}{}
+	l.activeCompressions.Add(1)
+	return func() {
+		l.activeCompressions.Add(-1)
+		<-*sem
+	}
+}
@@ -0,0 +1,90 @@
+// compresslimit_test.go: Tests for MaxCompressions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowCodec is gzip that holds each stream open for a while and records
+// how many streams were open at once.
+type slowCodec struct{}
+
+var slowCodecOpen, slowCodecPeak atomic.Int64
+
+func (slowCodec) Name() string      { return "slow" }
+func (slowCodec) Extension() string { return ".slow" }
+func (slowCodec) Magic() []byte     { return nil }
+
+func (slowCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	open := slowCodecOpen.Add(1)
+	for peak := slowCodecPeak.Load(); open > peak && !slowCodecPeak.CompareAndSwap(peak, open); peak = slowCodecPeak.Load() {
+	}
+	return &slowWriter{gzip.NewWriter(w)}, nil
+}
+
+func (slowCodec) NewReader(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
+
+type slowWriter struct{ *gzip.Writer }
+
+func (w *slowWriter) Close() error {
+	time.Sleep(20 * time.Millisecond)
+	slowCodecOpen.Add(-1)
+	return w.Writer.Close()
+}
+
+var registerSlowOnce sync.Once
+
+func TestMaxCompressions_LimitsConcurrentCompressions(t *testing.T) {
+	registerSlowOnce.Do(func() {
+		if err := RegisterCompressor(slowCodec{}); err != nil {
+			t.Fatalf("RegisterCompressor: %v", err)
+		}
+	})
+	slowCodecPeak.Store(0)
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:          filepath.Join(t.TempDir(), "app.log"),
+		Compress:          true,
+		Compression:       "slow",
+		CompressMinSize:   -1,
+		BackgroundWorkers: 4,
+		MaxCompressions:   1,
+	})
+
+	for i := 0; i < 4; i++ {
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond) // Distinct backup names
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for logger.Stats().ActiveCompressions == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := logger.Stats().ActiveCompressions; got != 1 {
+		t.Errorf("ActiveCompressions during a burst = %d, want 1", got)
+	}
+
+	logger.WaitForBackgroundTasks()
+	if got := slowCodecPeak.Load(); got != 1 {
+		t.Errorf("peak concurrent compressions = %d, want 1", got)
+	}
+	if got := logger.Stats().ActiveCompressions; got != 0 {
+		t.Errorf("ActiveCompressions after the burst = %d, want 0", got)
+	}
+}
@@ -0,0 +1,96 @@
+// compressminsize_test.go: Tests for skipping compression of tiny backups
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func rotateOnce(t *testing.T, config *LoggerConfig, content []byte) {
+	t.Helper()
+	logger := newTestLogger(t, config)
+
+	if _, err := logger.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+}
+
+func TestCompressMinSize_TinyBackupStaysPlain(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	rotateOnce(t, &LoggerConfig{Filename: logFile, Compress: true, Checksum: true}, []byte("10 bytes!\n"))
+
+	if gz, _ := filepath.Glob(logFile + ".*.gz"); len(gz) != 0 {
+		t.Errorf("compressed a 10-byte backup: %v", gz)
+	}
+	backups, _ := filepath.Glob(logFile + ".*-*-*-*-*-[0-9][0-9]")
+	if len(backups) != 1 {
+		t.Fatalf("plain backups = %v, want one", backups)
+	}
+	if !fileExists(backups[0] + ".sha256") {
+		t.Error("checksum sidecar missing for the uncompressed backup")
+	}
+}
+
+func TestCompressMinSize_LargeBackupCompressed(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	rotateOnce(t, &LoggerConfig{Filename: logFile, Compress: true}, bytes.Repeat([]byte("a"), 2048))
+
+	if gz, _ := filepath.Glob(logFile + ".*.gz"); len(gz) != 1 {
+		t.Errorf("compressed backups = %v, want one above the default threshold", gz)
+	}
+}
+
+func TestCompressMinSize_NegativeCompressesEverything(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	rotateOnce(t, &LoggerConfig{Filename: logFile, Compress: true, CompressMinSize: -1}, []byte("tiny\n"))
+
+	if gz, _ := filepath.Glob(logFile + ".*.gz"); len(gz) != 1 {
+		t.Errorf("compressed backups = %v, want one with the threshold disabled", gz)
+	}
+}
+
+// TestStats_CompressionTotalsSkipPlainBackups verifies the compression
+// totals cover compressed backups only.
+func TestStats_CompressionTotalsSkipPlainBackups(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Compress: true})
+
+	for _, content := range [][]byte{bytes.Repeat([]byte("a"), 4096), []byte("tiny\n")} {
+		if _, err := logger.Write(content); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	}
+
+	gz, _ := filepath.Glob(logFile + ".*.gz")
+	if len(gz) != 1 {
+		t.Fatalf("compressed backups = %v, want one", gz)
+	}
+	info, err := os.Stat(gz[0])
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+
+	stats := logger.Stats()
+	if stats.UncompressedBytes != 4096 {
+		t.Errorf("UncompressedBytes = %d, want 4096 (the tiny backup stays plain)", stats.UncompressedBytes)
+	}
+	if stats.CompressedBytes != uint64(info.Size()) {
+		t.Errorf("CompressedBytes = %d, want %d", stats.CompressedBytes, info.Size())
+	}
+	if want := float64(info.Size()) / 4096; stats.CompressionRatio != want {
+		t.Errorf("CompressionRatio = %v, want %v", stats.CompressionRatio, want)
+	}
+}
@@ -0,0 +1,118 @@
+// compressonclose_test.go: Tests for archiving the final file on Close
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompressOnClose_ArchivesFinalFile(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "job.log")
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:        logFile,
+				Compress:        true,
+				CompressMinSize: -1,
+				CompressOnClose: true,
+				Async:           async,
+			})
+
+			var want strings.Builder
+			for i := 0; i < 100; i++ {
+				line := fmt.Sprintf("step %d\n", i)
+				want.WriteString(line)
+				if _, err := logger.Write([]byte(line)); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			if fileExists(logFile) {
+				t.Error("plain active file left behind after Close")
+			}
+			backups, _ := filepath.Glob(logFile + ".*.gz")
+			if len(backups) != 1 {
+				t.Fatalf("compressed backups = %v, want exactly one", backups)
+			}
+			if got := string(readBackup(t, backups[0])); got != want.String() {
+				t.Errorf("archive holds %d bytes, want %d", len(got), want.Len())
+			}
+		})
+	}
+}
+
+func TestCompressOnClose_EmptyFileLeavesNoBackup(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "job.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressOnClose: true,
+	})
+	if _, err := logger.Write(nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if backups, _ := filepath.Glob(logFile + ".*"); len(backups) != 0 {
+		t.Errorf("backups = %v, want none for an empty file", backups)
+	}
+}
+
+func TestCompressOnClose_HonorsCloseContextDeadline(t *testing.T) {
+	enc := blockingEncryptor{started: make(chan struct{}), release: make(chan struct{})}
+	logFile := filepath.Join(t.TempDir(), "job.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressMinSize: -1,
+		CompressOnClose: true,
+		Encryptor:       enc,
+	})
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := logger.CloseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CloseContext = %v, want DeadlineExceeded", err)
+	}
+	<-enc.started // Archiving reached encryption and is still running
+	close(enc.release)
+
+	// Archiving completes in the background after the deadline
+	deadline := time.Now().Add(2 * time.Second)
+	for len(globEnc(logFile)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("archive never completed after the deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func globEnc(logFile string) []string {
+	matches, _ := filepath.Glob(logFile + ".*.gz" + encryptedSuffix)
+	return matches
+}
+
+func TestCompressOnClose_RequiresCompress(t *testing.T) {
+	err := ValidateConfig(&LoggerConfig{Filename: "app.log", CompressOnClose: true})
+	if err == nil {
+		t.Error("ValidateConfig accepted CompressOnClose without Compress")
+	}
+}
@@ -8,7 +8,9 @@ package lethe
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -172,6 +174,217 @@ func ValidatePathLength(path string) error {
 	return nil
 }
 
+// defaultDirMode is used for created log directories when DirMode is unset.
+const defaultDirMode os.FileMode = 0750
+
+// validateDirMode rejects DirMode values that cannot work for a log
+// directory: non-permission bits, or an owner lacking write and search
+// (execute) permission, which would make the log file impossible to create.
+func validateDirMode(mode os.FileMode) error {
+	if mode == 0 {
+		return nil // Default
+	}
+	if mode&^os.ModePerm != 0 {
+		return fmt.Errorf("invalid DirMode %v: only permission bits are allowed", mode)
+	}
+	if mode&0300 != 0300 {
+		return fmt.Errorf("invalid DirMode %#o: owner needs write and execute permission", uint32(mode))
+	}
+	return nil
+}
+
+// maxConfigBufferSize bounds BufferSize (ring slots) accepted by ValidateConfig.
+// Each slot holds a pointer, so 1M slots is already several MB before any data.
+const maxConfigBufferSize = 1 << 20
+
+// validBackpressurePolicy reports whether policy is a known BackpressurePolicy.
+// The empty string selects the documented default ("fallback").
+func validBackpressurePolicy(policy string) bool {
+	switch policy {
+	case "", "fallback", "drop", "adaptive", "overflow":
+		return true
+	}
+	return false
+}
+
+// ValidateConfig performs pre-flight checks on a LoggerConfig without
+// creating any files, so CI pipelines can fail fast on bad configuration.
+// NewWithConfig calls it before constructing the Logger.
+//
+// Checks performed:
+//   - Filename is set and, with {host} and {pid} expanded, within OS path limits
+//   - MaxSizeStr and MaxAgeStr parse (ParseSize / ParseDuration)
+//   - MaxAge and MaxAgeStr are not both set
+//   - BackpressurePolicy, PausePolicy, OversizePolicy and RotationMode are known values
+//   - Compression, if set, names a registered Compressor
+//   - CompressionTiers have distinct positive AfterAge values and registered codecs
+//   - CompressOnClose is only set together with Compress
+//   - CompressActive only uses gzip and is not combined with BufferedSync or MultiProcess
+//   - Preallocate is not combined with MultiProcess
+//   - BufferedSync is not combined with Async, SyncOnWrite or MultiProcess
+//   - FallbackFilename differs from Filename and is not combined with MultiProcess, BufferedSync or CompressActive
+//   - BufferSize is not negative, and neither it nor MinBufferSize exceeds
+//     the supported maximum (0 selects the default)
+//   - ArenaSlotSize is between 0 and 1MB
+//   - SampleRate is within [0, 1]; MaxWritesPerSecond, MaxMessageSize and RecentBufferSize are not negative
+//   - BackgroundWorkers, MaxCompressions, DedupWindow, RetryMaxDelay, StallTimeout, IdleTimeout, RotationJitter, ChecksumInterval, InodeCheckInterval, SyncBufferSize and MaxSpillBytes are not negative
+//   - DirMode, if set, lets the owner create files in the directory
+//   - RotateAt and TimeZone are valid
+//
+// Returns the first problem found, or nil if the configuration is usable.
+func ValidateConfig(c *LoggerConfig) error {
+	if c == nil {
+		return errors.New("config cannot be nil")
+	}
+	if c.Filename == "" {
+		return errors.New("filename cannot be empty")
+	}
+	if err := ValidatePathLength(ExpandFilename(c.Filename)); err != nil {
+		return fmt.Errorf("invalid log file path: %w", err)
+	}
+
+	if c.MaxSizeStr != "" {
+		if _, err := ParseSize(c.MaxSizeStr); err != nil {
+			return fmt.Errorf("invalid MaxSizeStr: %w", err)
+		}
+	}
+	if c.MaxAge > 0 && c.MaxAgeStr != "" {
+		return fmt.Errorf("cannot specify both MaxAge and MaxAgeStr; use MaxAgeStr for string-based configuration")
+	}
+	if c.MaxAgeStr != "" {
+		if _, err := ParseDuration(c.MaxAgeStr); err != nil {
+			return fmt.Errorf("invalid MaxAgeStr: %w", err)
+		}
+	}
+
+	if !validBackpressurePolicy(c.BackpressurePolicy) {
+		return fmt.Errorf("invalid BackpressurePolicy %q: must be \"fallback\", \"drop\", \"adaptive\" or \"overflow\"", c.BackpressurePolicy)
+	}
+	if c.PausePolicy != "" && c.PausePolicy != PausePolicyBuffer && c.PausePolicy != PausePolicyError {
+		return fmt.Errorf("invalid PausePolicy %q: must be \"buffer\" or \"error\"", c.PausePolicy)
+	}
+	if c.OversizePolicy != "" && c.OversizePolicy != OversizePolicyReject && c.OversizePolicy != OversizePolicyTruncate {
+		return fmt.Errorf("invalid OversizePolicy %q: must be \"reject\" or \"truncate\"", c.OversizePolicy)
+	}
+	if c.RotationMode != "" && c.RotationMode != RotationModeAny && c.RotationMode != RotationModeAll {
+		return fmt.Errorf("invalid RotationMode %q: must be \"any\" or \"all\"", c.RotationMode)
+	}
+	if c.Compression != "" {
+		if _, ok := lookupCompressor(c.Compression); !ok {
+			return fmt.Errorf("invalid Compression %q: no such compressor registered", c.Compression)
+		}
+	}
+	if err := validateCompressionTiers(c.CompressionTiers); err != nil {
+		return err
+	}
+	if c.CompressOnClose && !c.Compress {
+		return errors.New("invalid CompressOnClose: requires Compress")
+	}
+	if c.CompressActive {
+		if c.Compression != "" && c.Compression != defaultCompression {
+			return fmt.Errorf("invalid CompressActive: the active file is always gzip, Compression %q does not apply", c.Compression)
+		}
+		if c.BufferedSync || c.MultiProcess {
+			return errors.New("invalid CompressActive: cannot be combined with BufferedSync or MultiProcess")
+		}
+	}
+	if c.Preallocate && c.MultiProcess {
+		return errors.New("invalid Preallocate: cannot be combined with MultiProcess")
+	}
+	if c.BufferedSync && (c.Async || c.SyncOnWrite || c.MultiProcess) {
+		return errors.New("invalid BufferedSync: cannot be combined with Async, SyncOnWrite or MultiProcess")
+	}
+	if c.FallbackFilename != "" {
+		if filepath.Clean(c.FallbackFilename) == filepath.Clean(c.Filename) {
+			return errors.New("invalid FallbackFilename: must differ from Filename")
+		}
+		if c.MultiProcess || c.BufferedSync || c.CompressActive {
+			return errors.New("invalid FallbackFilename: cannot be combined with MultiProcess, BufferedSync or CompressActive")
+		}
+		if err := ValidatePathLength(c.FallbackFilename); err != nil {
+			return fmt.Errorf("invalid FallbackFilename: %w", err)
+		}
+	}
+	if c.BufferSize < 0 {
+		return fmt.Errorf("invalid BufferSize %d: must not be negative (0 selects the default)", c.BufferSize)
+	}
+	if c.BufferSize > maxConfigBufferSize {
+		return fmt.Errorf("invalid BufferSize %d: exceeds maximum of %d slots", c.BufferSize, maxConfigBufferSize)
+	}
+	if c.MinBufferSize > maxConfigBufferSize {
+		return fmt.Errorf("invalid MinBufferSize %d: exceeds maximum of %d slots", c.MinBufferSize, maxConfigBufferSize)
+	}
+	if err := validateArenaSlotSize(c.ArenaSlotSize); err != nil {
+		return err
+	}
+	if err := validateDirMode(c.DirMode); err != nil {
+		return err
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("invalid SampleRate %v: must be between 0 and 1", c.SampleRate)
+	}
+	if c.MaxWritesPerSecond < 0 {
+		return fmt.Errorf("invalid MaxWritesPerSecond %d: must not be negative", c.MaxWritesPerSecond)
+	}
+	if c.MaxMessageSize < 0 {
+		return fmt.Errorf("invalid MaxMessageSize %d: must not be negative", c.MaxMessageSize)
+	}
+	if c.RecentBufferSize < 0 {
+		return fmt.Errorf("invalid RecentBufferSize %d: must not be negative", c.RecentBufferSize)
+	}
+	if c.BackgroundWorkers < 0 {
+		return fmt.Errorf("invalid BackgroundWorkers %d: must not be negative", c.BackgroundWorkers)
+	}
+	if c.MaxCompressions < 0 {
+		return fmt.Errorf("invalid MaxCompressions %d: must not be negative", c.MaxCompressions)
+	}
+	if c.DedupWindow < 0 {
+		return fmt.Errorf("invalid DedupWindow %v: must not be negative", c.DedupWindow)
+	}
+	if c.RetryMaxDelay < 0 {
+		return fmt.Errorf("invalid RetryMaxDelay %v: must not be negative", c.RetryMaxDelay)
+	}
+	if c.MaxSpillBytes < 0 {
+		return fmt.Errorf("invalid MaxSpillBytes %d: must not be negative", c.MaxSpillBytes)
+	}
+	if c.SyncBufferSize < 0 {
+		return fmt.Errorf("invalid SyncBufferSize %d: must not be negative", c.SyncBufferSize)
+	}
+	if c.StallTimeout < 0 {
+		return fmt.Errorf("invalid StallTimeout %v: must not be negative", c.StallTimeout)
+	}
+	if c.IdleTimeout < 0 {
+		return fmt.Errorf("invalid IdleTimeout %v: must not be negative", c.IdleTimeout)
+	}
+	if c.RotationJitter < 0 {
+		return fmt.Errorf("invalid RotationJitter %v: must not be negative", c.RotationJitter)
+	}
+	if c.ChecksumInterval < 0 {
+		return fmt.Errorf("invalid ChecksumInterval %v: must not be negative", c.ChecksumInterval)
+	}
+	if c.InodeCheckInterval < 0 {
+		return fmt.Errorf("invalid InodeCheckInterval %v: must not be negative", c.InodeCheckInterval)
+	}
+	if c.AutoScale != nil {
+		if err := c.AutoScale.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.RotateAt != "" {
+		if _, err := parseRotateAt(c.RotateAt); err != nil {
+			return err
+		}
+	}
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			return fmt.Errorf("invalid TimeZone %q: %w", c.TimeZone, err)
+		}
+	}
+
+	return nil
+}
+
 // GetDefaultFileMode returns the appropriate default file mode for the OS
 func GetDefaultFileMode() os.FileMode {
 	if runtime.GOOS == "windows" {
@@ -195,13 +408,46 @@ func GetDefaultFileMode() os.FileMode {
 // - High load: Temporary resource exhaustion
 //
 // Conservative approach: Short delays, limited retries to avoid hanging
+//
+// The delay between attempts is fixed; use RetryWithPolicy for exponential
+// backoff with jitter.
 func RetryFileOperation(operation func() error, retryCount int, retryDelay time.Duration) error {
+	return RetryWithPolicy(operation, RetryPolicy{Count: retryCount, Delay: retryDelay})
+}
+
+// RetryPolicy controls how RetryWithPolicy spaces out attempts.
+type RetryPolicy struct {
+	// Count is the number of attempts (default: 3).
+	Count int
+
+	// Delay is the wait after the first failed attempt (default: 10ms).
+	Delay time.Duration
+
+	// MaxDelay caps the delay, which doubles after each failed attempt.
+	// Zero, or a value not above Delay, keeps the delay fixed.
+	MaxDelay time.Duration
+
+	// Jitter randomizes each delay between half and all of its value.
+	Jitter bool
+}
+
+// RetryWithPolicy executes a file operation like RetryFileOperation, with
+// exponential backoff: the delay starts at Delay and doubles after each
+// failure, up to MaxDelay.
+//
+// WHY backoff and jitter: a fixed delay keeps many goroutines or processes
+// retrying in lockstep against a briefly unavailable NFS or cloud mount;
+// doubling gives the mount time to recover, and jitter spreads the retries.
+func RetryWithPolicy(operation func() error, policy RetryPolicy) error {
+	retryCount := policy.Count
 	if retryCount <= 0 {
 		retryCount = 3 // Default retry count - balances reliability vs latency
 	}
+	retryDelay := policy.Delay
 	if retryDelay <= 0 {
 		retryDelay = 10 * time.Millisecond // Default delay - short enough to be unnoticeable
 	}
+	maxDelay := max(policy.MaxDelay, retryDelay)
 
 	var lastErr error
 	for i := 0; i < retryCount; i++ {
@@ -214,11 +460,21 @@ func RetryFileOperation(operation func() error, retryCount int, retryDelay time.
 
 		// On the last attempt, don't wait - fail fast
 		if i < retryCount-1 {
-			time.Sleep(retryDelay)
+			time.Sleep(retryBackoff(retryDelay, policy.Jitter))
+			retryDelay = min(retryDelay*2, maxDelay)
 		}
 	}
 
-	return fmt.Errorf("operation failed after %d retries: %v", retryCount, lastErr)
+	return fmt.Errorf("operation failed after %d retries: %w", retryCount, lastErr)
+}
+
+// retryBackoff returns delay, or with jitter a random value in [delay/2, delay].
+func retryBackoff(delay time.Duration, jitter bool) time.Duration {
+	if !jitter || delay < 2 {
+		return delay
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1) // #nosec G404 -- retry jitter needs no cryptographic randomness
 }
 
 // ConfigSource defines how to load LoggerConfig from multiple sources
@@ -293,13 +549,15 @@ func LoadFromJSONFile(filepath string) (*LoggerConfig, error) {
 //   - {PREFIX}_CHECKSUM -> Checksum
 //   - {PREFIX}_ASYNC -> Async
 //   - {PREFIX}_LOCAL_TIME -> LocalTime
-//   - {PREFIX}_BACKPRESSURE_POLICY -> BackpressurePolicy
+//   - {PREFIX}_BACKPRESSURE_POLICY -> BackpressurePolicy (alias: {PREFIX}_BACKPRESSURE)
 //   - {PREFIX}_BUFFER_SIZE -> BufferSize
 //   - {PREFIX}_FLUSH_INTERVAL -> FlushInterval
 //   - {PREFIX}_ADAPTIVE_FLUSH -> AdaptiveFlush
 //   - {PREFIX}_FILE_MODE -> FileMode
 //   - {PREFIX}_RETRY_COUNT -> RetryCount
 //   - {PREFIX}_RETRY_DELAY -> RetryDelay
+//   - {PREFIX}_RETRY_MAX_DELAY -> RetryMaxDelay
+//   - {PREFIX}_RETRY_JITTER -> RetryJitter
 //
 // Parameters:
 //   - prefix: Environment variable prefix (e.g., "LETHE", "LOG")
@@ -311,7 +569,16 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 	}
 
 	config := &LoggerConfig{}
+	if err := applyEnv(prefix, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
 
+// applyEnv overlays the {PREFIX}_* variables that are set onto config.
+// Unset variables leave the corresponding field untouched, so callers can
+// start from non-zero defaults (see NewFromEnv).
+func applyEnv(prefix string, config *LoggerConfig) error {
 	// Helper function to get env value with prefix
 	getEnv := func(key string) string {
 		return os.Getenv(prefix + "_" + key)
@@ -329,6 +596,8 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 	}
 	if val := getEnv("BACKPRESSURE_POLICY"); val != "" {
 		config.BackpressurePolicy = val
+	} else if val := getEnv("BACKPRESSURE"); val != "" {
+		config.BackpressurePolicy = val
 	}
 
 	// Parse boolean values
@@ -336,35 +605,42 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 		if b, err := strconv.ParseBool(val); err == nil {
 			config.Compress = b
 		} else {
-			return nil, fmt.Errorf("invalid boolean value for %s_COMPRESS: %q", prefix, val)
+			return fmt.Errorf("invalid boolean value for %s_COMPRESS: %q", prefix, val)
 		}
 	}
 	if val := getEnv("CHECKSUM"); val != "" {
 		if b, err := strconv.ParseBool(val); err == nil {
 			config.Checksum = b
 		} else {
-			return nil, fmt.Errorf("invalid boolean value for %s_CHECKSUM: %q", prefix, val)
+			return fmt.Errorf("invalid boolean value for %s_CHECKSUM: %q", prefix, val)
 		}
 	}
 	if val := getEnv("ASYNC"); val != "" {
 		if b, err := strconv.ParseBool(val); err == nil {
 			config.Async = b
 		} else {
-			return nil, fmt.Errorf("invalid boolean value for %s_ASYNC: %q", prefix, val)
+			return fmt.Errorf("invalid boolean value for %s_ASYNC: %q", prefix, val)
 		}
 	}
 	if val := getEnv("LOCAL_TIME"); val != "" {
 		if b, err := strconv.ParseBool(val); err == nil {
 			config.LocalTime = b
 		} else {
-			return nil, fmt.Errorf("invalid boolean value for %s_LOCAL_TIME: %q", prefix, val)
+			return fmt.Errorf("invalid boolean value for %s_LOCAL_TIME: %q", prefix, val)
 		}
 	}
 	if val := getEnv("ADAPTIVE_FLUSH"); val != "" {
 		if b, err := strconv.ParseBool(val); err == nil {
 			config.AdaptiveFlush = b
 		} else {
-			return nil, fmt.Errorf("invalid boolean value for %s_ADAPTIVE_FLUSH: %q", prefix, val)
+			return fmt.Errorf("invalid boolean value for %s_ADAPTIVE_FLUSH: %q", prefix, val)
+		}
+	}
+	if val := getEnv("RETRY_JITTER"); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			config.RetryJitter = b
+		} else {
+			return fmt.Errorf("invalid boolean value for %s_RETRY_JITTER: %q", prefix, val)
 		}
 	}
 
@@ -373,21 +649,21 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 		if i, err := strconv.Atoi(val); err == nil {
 			config.MaxBackups = i
 		} else {
-			return nil, fmt.Errorf("invalid integer value for %s_MAX_BACKUPS: %q", prefix, val)
+			return fmt.Errorf("invalid integer value for %s_MAX_BACKUPS: %q", prefix, val)
 		}
 	}
 	if val := getEnv("BUFFER_SIZE"); val != "" {
 		if i, err := strconv.Atoi(val); err == nil {
 			config.BufferSize = i
 		} else {
-			return nil, fmt.Errorf("invalid integer value for %s_BUFFER_SIZE: %q", prefix, val)
+			return fmt.Errorf("invalid integer value for %s_BUFFER_SIZE: %q", prefix, val)
 		}
 	}
 	if val := getEnv("RETRY_COUNT"); val != "" {
 		if i, err := strconv.Atoi(val); err == nil {
 			config.RetryCount = i
 		} else {
-			return nil, fmt.Errorf("invalid integer value for %s_RETRY_COUNT: %q", prefix, val)
+			return fmt.Errorf("invalid integer value for %s_RETRY_COUNT: %q", prefix, val)
 		}
 	}
 
@@ -396,14 +672,21 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 		if d, err := time.ParseDuration(val); err == nil {
 			config.FlushInterval = d
 		} else {
-			return nil, fmt.Errorf("invalid duration value for %s_FLUSH_INTERVAL: %q", prefix, val)
+			return fmt.Errorf("invalid duration value for %s_FLUSH_INTERVAL: %q", prefix, val)
 		}
 	}
 	if val := getEnv("RETRY_DELAY"); val != "" {
 		if d, err := time.ParseDuration(val); err == nil {
 			config.RetryDelay = d
 		} else {
-			return nil, fmt.Errorf("invalid duration value for %s_RETRY_DELAY: %q", prefix, val)
+			return fmt.Errorf("invalid duration value for %s_RETRY_DELAY: %q", prefix, val)
+		}
+	}
+	if val := getEnv("RETRY_MAX_DELAY"); val != "" {
+		if d, err := time.ParseDuration(val); err == nil {
+			config.RetryMaxDelay = d
+		} else {
+			return fmt.Errorf("invalid duration value for %s_RETRY_MAX_DELAY: %q", prefix, val)
 		}
 	}
 
@@ -412,11 +695,51 @@ func LoadFromEnv(prefix string) (*LoggerConfig, error) {
 		if mode, err := strconv.ParseUint(val, 8, 32); err == nil {
 			config.FileMode = os.FileMode(mode)
 		} else {
-			return nil, fmt.Errorf("invalid file mode value for %s_FILE_MODE: %q", prefix, val)
+			return fmt.Errorf("invalid file mode value for %s_FILE_MODE: %q", prefix, val)
 		}
 	}
 
-	return config, nil
+	return nil
+}
+
+// NewFromEnv creates a Logger configured from {PREFIX}_* environment
+// variables, for 12-factor deployments that configure rotation without
+// code changes. Variables follow the LoadFromEnv mapping, plus
+// {PREFIX}_BACKPRESSURE as a short alias for {PREFIX}_BACKPRESSURE_POLICY.
+//
+// Unset variables fall back to the NewWithDefaults configuration.
+// {PREFIX}_FILENAME is required. Parse errors name the offending variable.
+//
+// Example:
+//
+//	// LETHE_FILENAME=/var/log/app.log LETHE_MAX_SIZE=50MB LETHE_COMPRESS=false
+//	logger, err := lethe.NewFromEnv("LETHE")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer logger.Close()
+func NewFromEnv(prefix string) (*Logger, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("env prefix cannot be empty")
+	}
+
+	config := defaultConfig("")
+	if err := applyEnv(prefix, config); err != nil {
+		return nil, err
+	}
+	if config.Filename == "" {
+		return nil, fmt.Errorf("%s_FILENAME must be set", prefix)
+	}
+
+	// Validate string forms here so the error names the variable
+	if _, err := ParseSize(config.MaxSizeStr); err != nil {
+		return nil, fmt.Errorf("invalid size value for %s_MAX_SIZE: %q: %w", prefix, config.MaxSizeStr, err)
+	}
+	if _, err := ParseDuration(config.MaxAgeStr); err != nil {
+		return nil, fmt.Errorf("invalid duration value for %s_MAX_AGE: %q: %w", prefix, config.MaxAgeStr, err)
+	}
+
+	return NewWithConfig(config)
 }
 
 // LoadFromSources loads LoggerConfig from multiple sources with precedence
@@ -467,6 +790,42 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		if jsonConfig.BackpressurePolicy != "" {
 			config.BackpressurePolicy = jsonConfig.BackpressurePolicy
 		}
+		if jsonConfig.Symlink != "" {
+			config.Symlink = jsonConfig.Symlink
+		}
+		if jsonConfig.Name != "" {
+			config.Name = jsonConfig.Name
+		}
+		if jsonConfig.FallbackFilename != "" {
+			config.FallbackFilename = jsonConfig.FallbackFilename
+		}
+		if jsonConfig.RotateAt != "" {
+			config.RotateAt = jsonConfig.RotateAt
+		}
+		if jsonConfig.RotationMode != "" {
+			config.RotationMode = jsonConfig.RotationMode
+		}
+		if jsonConfig.TimeZone != "" {
+			config.TimeZone = jsonConfig.TimeZone
+		}
+		if jsonConfig.PausePolicy != "" {
+			config.PausePolicy = jsonConfig.PausePolicy
+		}
+		if jsonConfig.OversizePolicy != "" {
+			config.OversizePolicy = jsonConfig.OversizePolicy
+		}
+		if jsonConfig.Compression != "" {
+			config.Compression = jsonConfig.Compression
+		}
+		if jsonConfig.CompressMinSize != 0 {
+			config.CompressMinSize = jsonConfig.CompressMinSize
+		}
+		if len(jsonConfig.CompressionTiers) > 0 {
+			config.CompressionTiers = jsonConfig.CompressionTiers
+		}
+		if jsonConfig.TempDir != "" {
+			config.TempDir = jsonConfig.TempDir
+		}
 		// Apply non-zero values for other fields
 		if jsonConfig.MaxSize > 0 {
 			config.MaxSize = jsonConfig.MaxSize
@@ -474,6 +833,9 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		if jsonConfig.MaxBackups > 0 {
 			config.MaxBackups = jsonConfig.MaxBackups
 		}
+		if jsonConfig.MaxLines > 0 {
+			config.MaxLines = jsonConfig.MaxLines
+		}
 		if jsonConfig.MaxAge > 0 {
 			config.MaxAge = jsonConfig.MaxAge
 		}
@@ -483,18 +845,84 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		if jsonConfig.BufferSize > 0 {
 			config.BufferSize = jsonConfig.BufferSize
 		}
+		if jsonConfig.MinBufferSize > 0 {
+			config.MinBufferSize = jsonConfig.MinBufferSize
+		}
+		if jsonConfig.ArenaSlotSize > 0 {
+			config.ArenaSlotSize = jsonConfig.ArenaSlotSize
+		}
+		if jsonConfig.MaxBufferBytes > 0 {
+			config.MaxBufferBytes = jsonConfig.MaxBufferBytes
+		}
+		if jsonConfig.MaxSpillBytes > 0 {
+			config.MaxSpillBytes = jsonConfig.MaxSpillBytes
+		}
 		if jsonConfig.RetryCount > 0 {
 			config.RetryCount = jsonConfig.RetryCount
 		}
 		if jsonConfig.FlushInterval > 0 {
 			config.FlushInterval = jsonConfig.FlushInterval
 		}
+		if jsonConfig.ConsumerBatchSize > 0 {
+			config.ConsumerBatchSize = jsonConfig.ConsumerBatchSize
+		}
+		if jsonConfig.StallTimeout > 0 {
+			config.StallTimeout = jsonConfig.StallTimeout
+		}
+		if jsonConfig.IdleTimeout > 0 {
+			config.IdleTimeout = jsonConfig.IdleTimeout
+		}
+		if jsonConfig.ChecksumInterval > 0 {
+			config.ChecksumInterval = jsonConfig.ChecksumInterval
+		}
+		if jsonConfig.InodeCheckInterval > 0 {
+			config.InodeCheckInterval = jsonConfig.InodeCheckInterval
+		}
+		if jsonConfig.RotationJitter > 0 {
+			config.RotationJitter = jsonConfig.RotationJitter
+		}
+		if jsonConfig.SampleRate > 0 {
+			config.SampleRate = jsonConfig.SampleRate
+		}
+		if jsonConfig.MaxWritesPerSecond > 0 {
+			config.MaxWritesPerSecond = jsonConfig.MaxWritesPerSecond
+		}
+		if jsonConfig.MaxMessageSize > 0 {
+			config.MaxMessageSize = jsonConfig.MaxMessageSize
+		}
+		if jsonConfig.RecentBufferSize > 0 {
+			config.RecentBufferSize = jsonConfig.RecentBufferSize
+		}
+		if jsonConfig.BackgroundWorkers > 0 {
+			config.BackgroundWorkers = jsonConfig.BackgroundWorkers
+		}
+		if jsonConfig.MaxCompressions > 0 {
+			config.MaxCompressions = jsonConfig.MaxCompressions
+		}
+		if jsonConfig.DedupWindow > 0 {
+			config.DedupWindow = jsonConfig.DedupWindow
+		}
 		if jsonConfig.RetryDelay > 0 {
 			config.RetryDelay = jsonConfig.RetryDelay
 		}
+		if jsonConfig.RetryMaxDelay > 0 {
+			config.RetryMaxDelay = jsonConfig.RetryMaxDelay
+		}
+		if jsonConfig.SyncInterval > 0 {
+			config.SyncInterval = jsonConfig.SyncInterval
+		}
+		if jsonConfig.SyncBufferSize > 0 {
+			config.SyncBufferSize = jsonConfig.SyncBufferSize
+		}
 		if jsonConfig.FileMode > 0 {
 			config.FileMode = jsonConfig.FileMode
 		}
+		if jsonConfig.BackupFileMode > 0 {
+			config.BackupFileMode = jsonConfig.BackupFileMode
+		}
+		if jsonConfig.DirMode > 0 {
+			config.DirMode = jsonConfig.DirMode
+		}
 
 		// Apply boolean values
 		config.Compress = jsonConfig.Compress
@@ -502,6 +930,22 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		config.Async = jsonConfig.Async
 		config.LocalTime = jsonConfig.LocalTime
 		config.AdaptiveFlush = jsonConfig.AdaptiveFlush
+		config.SyncOnWrite = jsonConfig.SyncOnWrite
+		config.PersistState = jsonConfig.PersistState
+		config.RecreateIfMissing = jsonConfig.RecreateIfMissing
+		config.DisableAutoScale = jsonConfig.DisableAutoScale
+		config.MultiProcess = jsonConfig.MultiProcess
+		config.Preallocate = jsonConfig.Preallocate
+		config.BufferedSync = jsonConfig.BufferedSync
+		config.Dedup = jsonConfig.Dedup
+		config.EnsureNewline = jsonConfig.EnsureNewline
+		config.CompressOnClose = jsonConfig.CompressOnClose
+		config.CompressActive = jsonConfig.CompressActive
+		config.Manifest = jsonConfig.Manifest
+		config.RetryJitter = jsonConfig.RetryJitter
+		if jsonConfig.AutoScale != nil {
+			config.AutoScale = jsonConfig.AutoScale
+		}
 
 		// Apply function if provided
 		if jsonConfig.ErrorCallback != nil {
@@ -543,6 +987,9 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		config.FlushInterval = envConfig.FlushInterval
 		config.RetryDelay = envConfig.RetryDelay
 		config.FileMode = envConfig.FileMode
+		if envConfig.RetryMaxDelay > 0 {
+			config.RetryMaxDelay = envConfig.RetryMaxDelay
+		}
 
 		// Apply boolean values (only if they were explicitly set in env)
 		// We need to check if the env var was actually set, not just the parsed value
@@ -570,6 +1017,9 @@ func LoadFromSources(source ConfigSource) (*LoggerConfig, error) {
 		if envVarMap[prefix+"ADAPTIVE_FLUSH"] {
 			config.AdaptiveFlush = envConfig.AdaptiveFlush
 		}
+		if envVarMap[prefix+"RETRY_JITTER"] {
+			config.RetryJitter = envConfig.RetryJitter
+		}
 
 		// Apply function if provided
 		if envConfig.ErrorCallback != nil {
@@ -0,0 +1,81 @@
+// consumer_batch_test.go: Tests for coalesced MPSC consumer writes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestConsumerBatchSize_PreservesOrderAndContent verifies coalesced writes
+// keep every message intact and in push order, for several batch sizes.
+func TestConsumerBatchSize_PreservesOrderAndContent(t *testing.T) {
+	for _, batchSize := range []int{0, 1, 7, 256} {
+		t.Run(fmt.Sprintf("batch=%d", batchSize), func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "batch.log")
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:          logFile,
+				Async:             true,
+				BufferSize:        4096,
+				ConsumerBatchSize: batchSize,
+			})
+
+			const n = 1000
+			for i := 0; i < n; i++ {
+				if _, err := fmt.Fprintf(logger, "line %04d\n", i); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			content, err := os.ReadFile(logFile)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+			if len(lines) != n {
+				t.Fatalf("got %d lines, want %d", len(lines), n)
+			}
+			for i, line := range lines {
+				if want := fmt.Sprintf("line %04d", i); line != want {
+					t.Fatalf("line %d = %q, want %q", i, line, want)
+				}
+			}
+		})
+	}
+}
+
+// TestConsumerBatchSize_WriteBatchCoalesces verifies a batch reaches the file
+// as one write and is accounted for once.
+func TestConsumerBatchSize_WriteBatchCoalesces(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "coalesce.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	// Open the file through the normal path
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	c := &MPSCConsumer{logger: logger}
+	c.writeBatch([][]byte{[]byte("a\n"), []byte("b\n"), []byte("c\n")})
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "first\na\nb\nc\n" {
+		t.Errorf("content = %q", content)
+	}
+	if got := logger.bytesWritten.Load(); got != uint64(len(content)) {
+		t.Errorf("bytesWritten = %d, want %d", got, len(content))
+	}
+}
@@ -0,0 +1,57 @@
+// current_test.go: Tests for CurrentFile and CurrentSize
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestCurrentFileAndSize(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "app:1.log"), DisableAutoScale: true})
+
+	if got := logger.CurrentFile(); got != "" {
+		t.Errorf("CurrentFile before first write = %q, want empty", got)
+	}
+
+	// Readers run concurrently with writers (exercised under -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, _ = logger.Write([]byte("0123456789\n"))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = logger.CurrentFile()
+				_ = logger.CurrentSize()
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := filepath.Join(dir, SanitizeFilename("app:1.log"))
+	if got := logger.CurrentFile(); got != want {
+		t.Errorf("CurrentFile = %q, want sanitized %q", got, want)
+	}
+	if got := logger.CurrentSize(); got != 4*50*11 {
+		t.Errorf("CurrentSize = %d, want %d", got, 4*50*11)
+	}
+
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	if got := logger.CurrentSize(); got != 0 {
+		t.Errorf("CurrentSize after rotation = %d, want 0", got)
+	}
+}
@@ -0,0 +1,134 @@
+// decompress.go: Restore compressed backups to plain files on disk
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotCompressed is returned by DecompressBackup when the source is not
+// in a registered compression format.
+var ErrNotCompressed = errors.New("lethe: not a recognized compressed backup")
+
+// DecompressBackup writes the decompressed contents of the backup at
+// srcPath to destPath, for tools that need a plain file rather than the
+// reader returned by OpenBackup. The codec is chosen like OpenBackup does:
+// by extension, or by magic bytes when the name does not match a
+// registered Compressor. A source in neither form, including an encrypted
+// ".enc" backup, fails with ErrNotCompressed.
+//
+// When Checksum left a ".sha256" sidecar, the backup is verified against
+// it while it is read and nothing is written to destPath on a mismatch.
+// The sidecar is looked up next to srcPath (covering the compressed bytes)
+// and next to the uncompressed backup name (covering the content, as
+// written when the checksum task ran before compression).
+//
+// The output is written to a temporary file next to destPath and renamed
+// into place, so destPath is either absent, its previous content, or the
+// complete backup. The restored file is created with mode 0600.
+//
+// Example:
+//
+//	err := lethe.DecompressBackup(
+//		"/var/log/app.log.2025-01-02-15-04-05.gz",
+//		"/tmp/restore/app.log")
+func DecompressBackup(srcPath, destPath string) error {
+	wantStored, err := readChecksumSidecar(srcPath + ".sha256")
+	if err != nil {
+		return fmt.Errorf("lethe: DecompressBackup: %w", err)
+	}
+	var wantContent []byte
+	if wantStored == nil {
+		if base := trimCompressedSuffix(srcPath); base != srcPath {
+			if wantContent, err = readChecksumSidecar(base + ".sha256"); err != nil {
+				return fmt.Errorf("lethe: DecompressBackup: %w", err)
+			}
+		}
+	}
+
+	src, err := os.Open(srcPath) // #nosec G304 -- path is supplied by the caller restoring their own backups
+	if err != nil {
+		return fmt.Errorf("lethe: DecompressBackup: %w", err)
+	}
+	defer func() { _ = src.Close() }() // Read-only; close error is not actionable
+
+	// Hash both sides of the codec in the same pass
+	storedHash, contentHash := sha256.New(), sha256.New()
+	var reader io.Reader = io.TeeReader(src, storedHash)
+
+	codec, ok := compressorForPath(srcPath)
+	if !ok {
+		reader, codec, ok = detectCompressor(reader)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotCompressed, srcPath)
+	}
+	cr, err := codec.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("lethe: DecompressBackup: open %s stream in %s: %w", codec.Name(), srcPath, err)
+	}
+	defer func() { _ = cr.Close() }() // Decompressor only; nothing to flush
+
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("lethe: DecompressBackup: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	_, copyErr := io.Copy(io.MultiWriter(tmp, contentHash), cr)
+	if copyErr == nil {
+		// Hash whatever the codec left unread (e.g., trailing padding)
+		_, copyErr = io.Copy(io.Discard, reader)
+	}
+	if copyErr == nil && (wantStored != nil && !bytes.Equal(storedHash.Sum(nil), wantStored) ||
+		wantContent != nil && !bytes.Equal(contentHash.Sum(nil), wantContent)) {
+		copyErr = fmt.Errorf("checksum mismatch for %s", srcPath)
+	}
+	if copyErr == nil {
+		copyErr = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr == nil {
+		copyErr = os.Rename(tmpName, destPath)
+	}
+	if copyErr != nil {
+		_ = os.Remove(tmpName) // Ignore remove error during cleanup
+		return fmt.Errorf("lethe: DecompressBackup: %w", copyErr)
+	}
+	return nil
+}
+
+// readChecksumSidecar returns the digest recorded in a ".sha256" sidecar
+// ("<hex>  <name>"), or nil when there is none.
+func readChecksumSidecar(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- sidecar of a caller-supplied backup path
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := bytes.Fields(data)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("checksum file %s is empty", path)
+	}
+	sum, err := hex.DecodeString(string(fields[0]))
+	if err != nil || len(sum) != sha256.Size {
+		return nil, fmt.Errorf("checksum file %s is malformed", path)
+	}
+	return sum, nil
+}
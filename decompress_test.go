@@ -0,0 +1,133 @@
+// decompress_test.go: Tests for DecompressBackup
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeGzipBackup writes content gzip-compressed to path and returns the
+// compressed bytes.
+func writeGzipBackup(t *testing.T, path, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompressBackup_RestoresContent(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.log.2025-01-02-15-04-05.gz")
+	compressed := writeGzipBackup(t, src, "line one\nline two\n")
+	sidecar := fmt.Sprintf("%x  %s\n", sha256.Sum256(compressed), filepath.Base(src))
+	if err := os.WriteFile(src+".sha256", []byte(sidecar), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	dest := filepath.Join(dir, "restored.log")
+	if err := DecompressBackup(src, dest); err != nil {
+		t.Fatalf("DecompressBackup: %v", err)
+	}
+	if got := readLog(t, dest); got != "line one\nline two\n" {
+		t.Errorf("restored = %q", got)
+	}
+	if entries := listDir(t, dir); len(entries) != 3 {
+		t.Errorf("directory = %v, want source, sidecar and restored file only", entries)
+	}
+}
+
+// TestDecompressBackup_DetectsRenamedArchive verifies the codec is found by
+// magic bytes when the extension does not name it.
+func TestDecompressBackup_DetectsRenamedArchive(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "archive.bin")
+	writeGzipBackup(t, src, "sniffed\n")
+
+	dest := filepath.Join(dir, "restored.log")
+	if err := DecompressBackup(src, dest); err != nil {
+		t.Fatalf("DecompressBackup: %v", err)
+	}
+	if got := readLog(t, dest); got != "sniffed\n" {
+		t.Errorf("restored = %q", got)
+	}
+}
+
+func TestDecompressBackup_ChecksumMismatch(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.log.gz")
+	writeGzipBackup(t, src, "tampered\n")
+	sidecar := fmt.Sprintf("%x  app.log.gz\n", sha256.Sum256([]byte("something else")))
+	if err := os.WriteFile(src+".sha256", []byte(sidecar), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	dest := filepath.Join(dir, "restored.log")
+	if err := DecompressBackup(src, dest); err == nil {
+		t.Fatal("DecompressBackup succeeded despite a checksum mismatch")
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Errorf("destination exists after a failed restore: %v", err)
+	}
+	if entries := listDir(t, dir); len(entries) != 2 {
+		t.Errorf("directory = %v, want the temporary file removed", entries)
+	}
+}
+
+// TestDecompressBackup_ContentSidecar verifies a sidecar written for the
+// uncompressed backup is checked against the decompressed content.
+func TestDecompressBackup_ContentSidecar(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "app.log.2025-01-02-15-04-05")
+	writeGzipBackup(t, base+".gz", "content\n")
+	sidecar := fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte("content\n")), filepath.Base(base))
+	if err := os.WriteFile(base+".sha256", []byte(sidecar), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	dest := filepath.Join(dir, "restored.log")
+	if err := DecompressBackup(base+".gz", dest); err != nil {
+		t.Fatalf("DecompressBackup: %v", err)
+	}
+
+	// Same sidecar, different content: must be rejected
+	writeGzipBackup(t, base+".gz", "altered\n")
+	if err := DecompressBackup(base+".gz", dest); err == nil {
+		t.Error("DecompressBackup succeeded despite a content checksum mismatch")
+	}
+	if got := readLog(t, dest); got != "content\n" {
+		t.Errorf("destination = %q, want the earlier restore untouched", got)
+	}
+}
+
+func TestDecompressBackup_NotCompressed(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "app.log.2025-01-02-15-04-05")
+	if err := os.WriteFile(src, []byte("plain text\n"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	err := DecompressBackup(src, filepath.Join(dir, "restored.log"))
+	if !errors.Is(err, ErrNotCompressed) {
+		t.Errorf("err = %v, want ErrNotCompressed", err)
+	}
+}
@@ -0,0 +1,120 @@
+// dedup.go: Suppression of consecutive identical writes (Dedup)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// defaultDedupWindow is the repeat window when DedupWindow is unset.
+const defaultDedupWindow = time.Second
+
+// dedupWindow returns the effective repeat window.
+func (l *Logger) dedupWindow() time.Duration {
+	if l.DedupWindow > 0 {
+		return l.DedupWindow
+	}
+	return defaultDedupWindow
+}
+
+// writeDeduped suppresses data if it repeats the previous message within the
+// window, otherwise writes any pending summary and then data via dispatch.
+// WHY release dedupMu before dispatch: holding it across the write would
+// serialize every writer whenever Dedup is on. Writers hold dedupWriting for
+// reading instead, and the summary takes it for writing, so a summary is
+// never written before the message it counts repeats of has been dispatched.
+func (l *Logger) writeDeduped(data []byte, dispatch func([]byte) (int, error)) (int, error) {
+	l.dedupMu.Lock()
+	now := l.timeCache.CachedTime()
+	if l.dedupLast != nil && now.Sub(l.dedupSeen) < l.dedupWindow() && bytes.Equal(data, l.dedupLast) {
+		l.dedupSeen = now
+		l.dedupRepeats.Add(1)
+		l.dedupSuppressed.Add(1)
+		l.dedupMu.Unlock()
+		return len(data), nil
+	}
+
+	l.flushDedupSummaryLocked()
+	// Copy: dispatch may hand data to the consumer, which recycles it
+	l.dedupLast = append(l.dedupLast[:0], data...)
+	l.dedupSeen = now
+	l.dedupWriting.RLock()
+	l.dedupMu.Unlock()
+
+	defer l.dedupWriting.RUnlock()
+	return dispatch(data)
+}
+
+// flushDedupSummaryLocked writes "last message repeated N times" for the
+// pending repeats, if any, once the repeated message itself is written.
+// Caller must hold dedupMu, so no new message can start meanwhile.
+func (l *Logger) flushDedupSummaryLocked() {
+	n := l.dedupRepeats.Swap(0)
+	if n == 0 {
+		return
+	}
+	l.dedupWriting.Lock()
+	defer l.dedupWriting.Unlock()
+	summary := make([]byte, 0, 48)
+	summary = append(summary, "last message repeated "...)
+	summary = strconv.AppendUint(summary, n, 10)
+	summary = append(summary, " times\n"...)
+	if _, err := l.dispatchOwned(summary); err != nil {
+		l.reportError("dedup_summary", err)
+	}
+}
+
+// startDedupLoop launches the goroutine that periodically summarizes an
+// ongoing run of repeats, so a crash loop still leaves a trace every
+// DedupWindow instead of only when it stops.
+func (l *Logger) startDedupLoop() {
+	if !l.Dedup || l.dedupLoop.Load() != nil {
+		return
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	if !l.dedupLoop.CompareAndSwap(nil, s) {
+		return // Someone else started it
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(l.dedupWindow())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if l.dedupRepeats.Load() == 0 {
+					continue // Lock-free idle check
+				}
+				l.dedupMu.Lock()
+				l.flushDedupSummaryLocked()
+				l.dedupMu.Unlock()
+			}
+		}
+	}()
+}
+
+// stopDedup stops the summary goroutine and writes any pending summary.
+func (l *Logger) stopDedup() {
+	if s := l.dedupLoop.Load(); s != nil {
+		s.stop()
+	}
+	if l.dedupRepeats.Load() == 0 {
+		return
+	}
+	l.dedupMu.Lock()
+	l.flushDedupSummaryLocked()
+	l.dedupMu.Unlock()
+}
@@ -0,0 +1,222 @@
+// dedup_test.go: Tests for suppression of repeated identical writes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newDedupLogger(t *testing.T, window time.Duration) (*Logger, string) {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:    logFile,
+		Dedup:       true,
+		DedupWindow: window,
+	})
+	return logger, logFile
+}
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return string(data)
+}
+
+func TestDedup_SummaryWhenMessageChanges(t *testing.T) {
+	logger, logFile := newDedupLogger(t, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if n, err := logger.Write([]byte("boom\n")); err != nil || n != 5 {
+			t.Fatalf("Write = (%d, %v), want (5, nil)", n, err)
+		}
+	}
+	if _, err := logger.Write([]byte("recovered\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := logger.Stats().DedupSuppressed; got != 4 {
+		t.Errorf("DedupSuppressed = %d, want 4", got)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := "boom\nlast message repeated 4 times\nrecovered\n"
+	if got := readLog(t, logFile); got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+func TestDedup_SummaryOnClose(t *testing.T) {
+	logger, logFile := newDedupLogger(t, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("boom\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	want := "boom\nlast message repeated 2 times\n"
+	if got := readLog(t, logFile); got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+func TestDedup_PeriodicSummaryDuringLongRun(t *testing.T) {
+	logger, logFile := newDedupLogger(t, 20*time.Millisecond)
+	defer func() { _ = logger.Close() }()
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if _, err := logger.Write([]byte("boom\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Summaries must appear while the run is still going, not only on Close
+	if got := readLog(t, logFile); !strings.Contains(got, "last message repeated") {
+		t.Errorf("no periodic summary in %q", got)
+	}
+}
+
+func TestDedup_RepeatAfterWindowIsWritten(t *testing.T) {
+	logger, logFile := newDedupLogger(t, 10*time.Millisecond)
+
+	if _, err := logger.Write([]byte("boom\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := logger.Write([]byte("boom\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "boom\nboom\n" {
+		t.Errorf("log = %q, want both copies", got)
+	}
+}
+
+func TestDedup_DisabledKeepsEveryByte(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("boom\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	stats := logger.Stats()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "boom\nboom\nboom\n" {
+		t.Errorf("log = %q", got)
+	}
+	if stats.TotalBytes != 15 || stats.DedupSuppressed != 0 {
+		t.Errorf("TotalBytes = %d, DedupSuppressed = %d; want 15, 0", stats.TotalBytes, stats.DedupSuppressed)
+	}
+}
+
+// TestDedup_WriteDoesNotHoldLock checks that a writer stuck in the file
+// write does not block other writers behind dedupMu.
+func TestDedup_WriteDoesNotHoldLock(t *testing.T) {
+	logger, _ := newDedupLogger(t, time.Minute)
+	entered, release := make(chan struct{}), make(chan struct{})
+	stuck := func(data []byte) (int, error) {
+		close(entered)
+		<-release
+		return len(data), nil
+	}
+	instant := func(data []byte) (int, error) { return len(data), nil }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = logger.writeDeduped([]byte("slow\n"), stuck)
+	}()
+	<-entered
+
+	wrote := make(chan struct{})
+	go func() {
+		defer close(wrote)
+		_, _ = logger.writeDeduped([]byte("fast\n"), instant)
+	}()
+	select {
+	case <-wrote:
+	case <-time.After(5 * time.Second):
+		t.Fatal("second writer blocked behind the first one's write")
+	}
+	close(release)
+	<-done
+}
+
+// TestDedup_SummaryWaitsForRepeatedMessage stalls the write of a message
+// while its repeats are counted and a new message ends the run: the
+// summary must not reach the file before the message it refers to.
+func TestDedup_SummaryWaitsForRepeatedMessage(t *testing.T) {
+	logger, logFile := newDedupLogger(t, time.Minute)
+	entered, release := make(chan struct{}), make(chan struct{})
+	stalled := func(data []byte) (int, error) {
+		close(entered)
+		<-release
+		return logger.dispatch(data)
+	}
+
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		if _, err := logger.writeDeduped([]byte("boom\n"), stalled); err != nil {
+			t.Errorf("writeDeduped: %v", err)
+		}
+	}()
+	<-entered
+
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write([]byte("boom\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	next := make(chan struct{})
+	go func() {
+		defer close(next)
+		if _, err := logger.Write([]byte("recovered\n")); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+	}()
+
+	select {
+	case <-next:
+		t.Fatal("summary written while the repeated message was still being written")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-first
+	<-next
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	want := "boom\nlast message repeated 2 times\nrecovered\n"
+	if got := readLog(t, logFile); got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
@@ -55,6 +55,9 @@
 //	// Weekly rotation: 200MB, 7d rotation, 4 backups
 //	logger, err := lethe.NewWeekly("weekly.log")
 //
+//	// Monthly rotation: 500MB, 30d rotation, 12 backups
+//	logger, err := lethe.NewMonthly("audit.log")
+//
 //	// Development: 10MB, 1h rotation, no compression, sync writes
 //	logger, err := lethe.NewDevelopment("debug.log")
 //
@@ -232,6 +235,28 @@
 // All Logger methods are thread-safe and can be called concurrently from multiple goroutines.
 // Lethe uses atomic operations and lock-free algorithms for maximum performance.
 //
+// # Write Atomicity
+//
+// Within a process, every Write lands in the file whole and contiguous,
+// whatever its size: no other write is interleaved with it or splits it.
+// In sync mode each message is passed to a single os.File.Write, which the
+// Go runtime serializes per file for the entire buffer; BufferedSync and
+// CompressActive writes are serialized by their buffer, and in async mode
+// a single consumer goroutine writes. Lethe never uses WriteAt on the
+// active file: it is opened with O_APPEND (FILE_APPEND_DATA on Windows),
+// so writes also never overwrite each other. The exceptions are writes
+// that fail part way: a failover (FallbackFilename) sends the unwritten
+// remainder to the fallback file.
+//
+// Across processes (MultiProcess, or another program appending to the
+// same file) the guarantee is the operating system's. O_APPEND makes the
+// seek-and-write of each write atomic, and local Linux filesystems do not
+// interleave concurrent writes to a regular file, but POSIX only promises
+// that for pipes up to PIPE_BUF bytes, and network filesystems such as
+// NFS give no such guarantee. Lethe does not split writes into PIPE_BUF
+// chunks: pieces of different processes' records could then interleave.
+// Keep records small, or give each process its own file, when that matters.
+//
 // # Performance Tips
 //
 // 1. Use NewWithDefaults() for most applications
@@ -0,0 +1,45 @@
+// drops.go: Per-cause accounting of discarded writes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "time"
+
+// dropReason names why a write was discarded, for the Dropped* Stats.
+type dropReason uint8
+
+const (
+	dropBufferFull  dropReason = iota // "drop" BackpressurePolicy on a full buffer
+	dropOverflowCap                   // "overflow" policy beyond MaxSpillBytes
+	dropSampled                       // SampleRate
+	dropRateLimited                   // MaxWritesPerSecond
+)
+
+// countDrop records one discarded write of size bytes. Lock-free: one
+// atomic add per counter.
+//
+// WHY keep the aggregates: DroppedOnFull (backpressure loss) and
+// SampledOut (intentional shedding) predate the breakdown and feed
+// Health and existing dashboards.
+func (l *Logger) countDrop(reason dropReason, size int) {
+	l.droppedBytes.Add(uint64(size)) // #nosec G115 -- write lengths are never negative
+	switch reason {
+	case dropBufferFull:
+		l.droppedFull.Add(1)
+	case dropOverflowCap:
+		l.droppedOverflow.Add(1)
+	case dropSampled:
+		l.droppedSampled.Add(1)
+		l.sampledOut.Add(1)
+		return
+	case dropRateLimited:
+		l.droppedRateLimited.Add(1)
+		l.sampledOut.Add(1)
+		return
+	}
+	l.droppedCount.Add(1)
+	l.lastDropTime.Store(time.Now().UnixNano())
+}
@@ -0,0 +1,48 @@
+// drops_test.go: Tests for the per-cause drop counters
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDropStats_BufferFull(t *testing.T) {
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           filepath.Join(t.TempDir(), "drops.log"),
+		Async:              true,
+		MaxBufferBytes:     64,
+		BackpressurePolicy: "drop",
+		Tee:                tee,
+	})
+	defer close(tee.release)
+
+	if _, err := logger.Write([]byte("line 000\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-tee.entered // The consumer is now stuck
+
+	const entry = "dropped on a full buffer\n"
+	for i := 0; i < 20; i++ {
+		if _, err := logger.Write([]byte(entry)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	stats := logger.Stats()
+	if stats.DroppedBufferFull == 0 || stats.DroppedBufferFull != stats.DroppedOnFull {
+		t.Errorf("DroppedBufferFull = %d, DroppedOnFull = %d; want equal and non-zero",
+			stats.DroppedBufferFull, stats.DroppedOnFull)
+	}
+	if want := stats.DroppedBufferFull * uint64(len(entry)); stats.DroppedBytes != want {
+		t.Errorf("DroppedBytes = %d, want %d", stats.DroppedBytes, want)
+	}
+	if stats.DroppedSampled+stats.DroppedRateLimited+stats.DroppedOverflowCap != 0 {
+		t.Errorf("drops attributed to other causes: %+v", stats)
+	}
+}
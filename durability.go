@@ -0,0 +1,94 @@
+// durability.go: fsync controls (SyncOnWrite, SyncInterval)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"time"
+)
+
+// fsync flushes file to stable storage and counts the call for Stats.
+func (l *Logger) fsync(file *os.File) error {
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	l.fsyncCount.Add(1)
+	l.lastSyncNano.Store(time.Now().UnixNano())
+	return nil
+}
+
+// markDirty records that data reached the file since the last fsync.
+// Only tracked when SyncInterval is set, to keep the default hot path untouched.
+func (l *Logger) markDirty() {
+	if l.SyncInterval > 0 {
+		l.syncDirty.Store(true)
+	}
+}
+
+// syncIfDue fsyncs the active file when SyncInterval has elapsed since the
+// last fsync and something was written in between. Safe to call from the
+// periodic loop and the MPSC consumer concurrently: the CAS on lastSyncNano
+// elects a single caller per interval.
+func (l *Logger) syncIfDue() {
+	if l.SyncInterval <= 0 || !l.syncDirty.Load() {
+		return
+	}
+
+	last := l.lastSyncNano.Load()
+	now := time.Now().UnixNano()
+	if now-last < int64(l.SyncInterval) {
+		return
+	}
+	if !l.lastSyncNano.CompareAndSwap(last, now) {
+		return // Another goroutine is syncing this interval
+	}
+	l.syncDirty.Store(false)
+
+	file := l.currentFile.Load()
+	if file == nil {
+		return
+	}
+	// WHY ignore closed-file errors: rotation may close the fd between the
+	// load above and Sync; rotation itself closes (and thus flushes) it.
+	if err := l.fsync(file); err != nil && !isFileAlreadyClosedError(err) {
+		l.reportWriteError("fsync", err)
+	}
+}
+
+// startSyncLoop launches the periodic fsync goroutine once per Logger.
+// Covers sync mode and idle async mode, where no consumer batch would
+// otherwise trigger the interval check.
+func (l *Logger) startSyncLoop() {
+	if l.SyncInterval <= 0 || l.syncLoop.Load() != nil {
+		return
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	if !l.syncLoop.CompareAndSwap(nil, s) {
+		return // Someone else started it
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(l.SyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				if l.enterFS() { // Skipped while paused
+					l.syncIfDue()
+					l.exitFS()
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+// durability_test.go: Tests for fsync controls (SyncOnWrite, SyncInterval)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSyncOnWrite_FsyncsEveryWrite verifies each sync-mode write is fsynced.
+func TestSyncOnWrite_FsyncsEveryWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:    filepath.Join(tmpDir, "durable.log"),
+		SyncOnWrite: true,
+	})
+
+	for i := 0; i < 5; i++ {
+		if _, err := logger.Write([]byte("durable entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if got := logger.Stats().FsyncCount; got != 5 {
+		t.Errorf("FsyncCount = %d, want 5", got)
+	}
+}
+
+// TestSyncInterval_PeriodicFsync verifies the background loop fsyncs dirty
+// data and stays idle when nothing new was written.
+func TestSyncInterval_PeriodicFsync(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:     filepath.Join(tmpDir, "interval.log"),
+		SyncInterval: 20 * time.Millisecond,
+	})
+
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.Stats().FsyncCount == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	synced := logger.Stats().FsyncCount
+	if synced == 0 {
+		t.Fatal("SyncInterval never fsynced dirty data")
+	}
+
+	// Nothing written since: further ticks must not fsync
+	time.Sleep(100 * time.Millisecond)
+	if got := logger.Stats().FsyncCount; got != synced {
+		t.Errorf("FsyncCount grew from %d to %d without new writes", synced, got)
+	}
+}
+
+// TestSyncOnWrite_AsyncConsumerFsyncsBatches verifies the MPSC consumer
+// fsyncs after flushing and that data reaches the file.
+func TestSyncOnWrite_AsyncConsumerFsyncsBatches(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "async-durable.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:    logFile,
+		Async:       true,
+		SyncOnWrite: true,
+	})
+
+	for i := 0; i < 10; i++ {
+		if _, err := logger.Write([]byte("async entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.Stats().FsyncCount == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if logger.Stats().FsyncCount == 0 {
+		t.Error("consumer never fsynced a flushed batch")
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(content) != 10*len("async entry\n") {
+		t.Errorf("file has %d bytes, want %d", len(content), 10*len("async entry\n"))
+	}
+}
+
+// TestSync_CountsFsync verifies explicit Sync calls are reflected in Stats.
+func TestSync_CountsFsync(t *testing.T) {
+	tmpDir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(tmpDir, "manual.log")})
+
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if got := logger.Stats().FsyncCount; got != 1 {
+		t.Errorf("FsyncCount = %d, want 1", got)
+	}
+}
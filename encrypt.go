@@ -0,0 +1,327 @@
+// encrypt.go: At-rest encryption of rotated backups (AES-256-GCM)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// encryptedSuffix is appended to backups written through an Encryptor.
+const encryptedSuffix = ".enc"
+
+// Encryptor encrypts rotated backups at rest.
+//
+// Encrypt streams src into dst; Decrypt returns a reader yielding the
+// original plaintext and must fail (not silently truncate) on tampered or
+// incomplete input. Implementations must be safe for concurrent use, since
+// several background workers may encrypt different backups at once.
+type Encryptor interface {
+	Encrypt(dst io.Writer, src io.Reader) error
+	Decrypt(src io.Reader) (io.Reader, error)
+}
+
+// AES-GCM stream format:
+//
+//	magic "LETHEGCM" | version (1 byte)
+//	frames: flag (1) | ciphertext length (4, big endian) | nonce (12) | ciphertext
+//
+// Each frame seals up to gcmChunkSize bytes. The frame index and the final
+// flag are authenticated as additional data, so reordered, dropped or
+// truncated frames fail to decrypt.
+const (
+	gcmMagic     = "LETHEGCM"
+	gcmVersion   = 1
+	gcmChunkSize = 64 * 1024
+	gcmFinal     = 1
+)
+
+// aesGCMEncryptor implements Encryptor with AES-256-GCM.
+type aesGCMEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMEncryptor returns an AES-256-GCM Encryptor for a 32-byte key.
+//
+// Example:
+//
+//	key := make([]byte, 32) // load from a KMS or secret store
+//	enc, err := lethe.NewAESGCMEncryptor(key)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	logger, err := lethe.NewWithConfig(&lethe.LoggerConfig{
+//		Filename:  "pii.log",
+//		Compress:  true,
+//		Encryptor: enc,
+//	})
+func NewAESGCMEncryptor(key []byte) (Encryptor, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("AES-256-GCM requires a 32-byte key, got %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesGCMEncryptor{aead: aead}, nil
+}
+
+// frameAAD binds a frame to its position and finality.
+func frameAAD(index uint64, flag byte) []byte {
+	var aad [9]byte
+	binary.BigEndian.PutUint64(aad[:8], index)
+	aad[8] = flag
+	return aad[:]
+}
+
+// Encrypt implements Encryptor.
+func (e *aesGCMEncryptor) Encrypt(dst io.Writer, src io.Reader) error {
+	if _, err := io.WriteString(dst, gcmMagic); err != nil {
+		return err
+	}
+	if _, err := dst.Write([]byte{gcmVersion}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, gcmChunkSize)
+	next := make([]byte, gcmChunkSize)
+	n, err := io.ReadFull(src, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	nonce := make([]byte, e.aead.NonceSize())
+	var header [5]byte
+	for index := uint64(0); ; index++ {
+		// Read ahead so the last frame can be flagged as final
+		var m int
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+		if !final {
+			m, err = io.ReadFull(src, next)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return err
+			}
+			final = m == 0
+		}
+
+		var flag byte
+		if final {
+			flag = gcmFinal
+		}
+		if _, rerr := rand.Read(nonce); rerr != nil {
+			return rerr
+		}
+		sealed := e.aead.Seal(nil, nonce, buf[:n], frameAAD(index, flag))
+
+		header[0] = flag
+		binary.BigEndian.PutUint32(header[1:], uint32(len(sealed))) // #nosec G115 -- bounded by gcmChunkSize + overhead
+		if _, werr := dst.Write(header[:]); werr != nil {
+			return werr
+		}
+		if _, werr := dst.Write(nonce); werr != nil {
+			return werr
+		}
+		if _, werr := dst.Write(sealed); werr != nil {
+			return werr
+		}
+
+		if final {
+			return nil
+		}
+		buf, next = next, buf
+		n = m
+	}
+}
+
+// Decrypt implements Encryptor.
+func (e *aesGCMEncryptor) Decrypt(src io.Reader) (io.Reader, error) {
+	header := make([]byte, len(gcmMagic)+1)
+	if _, err := io.ReadFull(src, header); err != nil {
+		return nil, fmt.Errorf("read encryption header: %w", err)
+	}
+	if string(header[:len(gcmMagic)]) != gcmMagic {
+		return nil, errors.New("not a lethe AES-GCM encrypted file")
+	}
+	if header[len(gcmMagic)] != gcmVersion {
+		return nil, fmt.Errorf("unsupported encryption version %d", header[len(gcmMagic)])
+	}
+	return &gcmReader{aead: e.aead, src: src}, nil
+}
+
+// gcmReader decrypts frames on demand.
+type gcmReader struct {
+	aead  cipher.AEAD
+	src   io.Reader
+	index uint64
+	plain []byte
+	done  bool
+	err   error
+}
+
+// Read implements io.Reader.
+func (r *gcmReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.nextFrame()
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// nextFrame reads, authenticates and decrypts a single frame.
+func (r *gcmReader) nextFrame() error {
+	var header [5]byte
+	if _, err := io.ReadFull(r.src, header[:]); err != nil {
+		return fmt.Errorf("encrypted backup truncated: %w", io.ErrUnexpectedEOF)
+	}
+	flag := header[0]
+	size := binary.BigEndian.Uint32(header[1:])
+	if flag&^gcmFinal != 0 || size > gcmChunkSize+uint32(r.aead.Overhead()) { // #nosec G115 -- Overhead is a small constant
+		return errors.New("corrupt encrypted frame header")
+	}
+
+	frame := make([]byte, r.aead.NonceSize()+int(size))
+	if _, err := io.ReadFull(r.src, frame); err != nil {
+		return fmt.Errorf("encrypted backup truncated: %w", io.ErrUnexpectedEOF)
+	}
+	nonce, sealed := frame[:r.aead.NonceSize()], frame[r.aead.NonceSize():]
+	plain, err := r.aead.Open(sealed[:0], nonce, sealed, frameAAD(r.index, flag))
+	if err != nil {
+		return fmt.Errorf("decrypt frame %d: %w", r.index, err)
+	}
+
+	r.index++
+	r.plain = plain
+	r.done = flag == gcmFinal
+	return nil
+}
+
+// encryptFile encrypts a rotated backup with crash consistency.
+// Runs on the background worker pool, so encryption never blocks writes.
+// Uses temp-file-then-rename: either the complete .enc exists or nothing does,
+// and the plaintext is removed only after the rename succeeds.
+func (l *Logger) encryptFile(filename string) error {
+	source, err := os.Open(filename) // #nosec G304 -- filename is internal backup file path, not user input
+	if err != nil {
+		return l.taskFailed("encrypt_open", err)
+	}
+
+	encryptedName := filename + encryptedSuffix
+	tempName := encryptedName + ".tmp"
+	target, err := os.OpenFile(tempName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 -- tempName is internally generated, not user input
+	if err != nil {
+		_ = source.Close() // Ignore close error during cleanup
+		return l.taskFailed("encrypt_create", err)
+	}
+
+	encErr := l.Encryptor.Encrypt(target, source)
+	_ = source.Close() // Read-only; close error is not actionable
+	closeErr := target.Close()
+	if encErr != nil || closeErr != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		if encErr == nil {
+			encErr = closeErr
+		}
+		return l.taskFailed("encrypt", fmt.Errorf("failed to encrypt %s: %v", filename, encErr))
+	}
+
+	if err := os.Rename(tempName, encryptedName); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.taskFailed("encrypt_rename", fmt.Errorf("failed to rename %s to %s: %v", tempName, encryptedName, err))
+	}
+
+	// Remove plaintext only after the encrypted file is in place
+	if err := os.Remove(filename); err != nil {
+		return l.taskFailed("encrypt_cleanup", err)
+	}
+	return nil
+}
+
+// OpenBackup opens a rotated backup for reading, transparently undoing
+// encryption (".enc", requires enc) and compression. The codec is chosen
+// from the file extension, or from the stream's magic bytes when the name
+// does not match a registered Compressor, so no configuration is needed.
+// The caller must Close the returned reader.
+//
+// Example:
+//
+//	r, err := lethe.OpenBackup("app.log.2025-01-02-15-04-05.gz.enc", enc)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer r.Close()
+//	io.Copy(os.Stdout, r)
+func OpenBackup(path string, enc Encryptor) (io.ReadCloser, error) {
+	file, err := os.Open(path) // #nosec G304 -- path is supplied by the caller reading their own backups
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader = file
+	name := path
+	if strings.HasSuffix(name, encryptedSuffix) {
+		if enc == nil {
+			_ = file.Close() // Ignore close error during cleanup
+			return nil, fmt.Errorf("backup %s is encrypted but no Encryptor was provided", path)
+		}
+		if reader, err = enc.Decrypt(reader); err != nil {
+			_ = file.Close() // Ignore close error during cleanup
+			return nil, err
+		}
+		name = strings.TrimSuffix(name, encryptedSuffix)
+	}
+
+	// The extension names the codec; otherwise sniff the stream so renamed
+	// or extension-less archives still open
+	codec, ok := compressorForPath(name)
+	if !ok {
+		reader, codec, ok = detectCompressor(reader)
+	}
+	if ok {
+		cr, err := codec.NewReader(reader)
+		if err != nil {
+			_ = file.Close() // Ignore close error during cleanup
+			return nil, fmt.Errorf("open %s stream in %s: %w", codec.Name(), path, err)
+		}
+		return &backupReader{Reader: cr, closers: []io.Closer{cr, file}}, nil
+	}
+	return &backupReader{Reader: reader, closers: []io.Closer{file}}, nil
+}
+
+// backupReader closes every layer opened by OpenBackup.
+type backupReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close implements io.Closer.
+func (b *backupReader) Close() error {
+	var first error
+	for _, c := range b.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,166 @@
+// encrypt_test.go: Tests for at-rest encryption of rotated backups
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEncryptor(t *testing.T, fill byte) Encryptor {
+	t.Helper()
+	enc, err := NewAESGCMEncryptor(bytes.Repeat([]byte{fill}, 32))
+	if err != nil {
+		t.Fatalf("NewAESGCMEncryptor: %v", err)
+	}
+	return enc
+}
+
+func TestAESGCMEncryptor_RoundTrip(t *testing.T) {
+	enc := testEncryptor(t, 0x42)
+
+	// Exercise empty input, sub-chunk, exact chunk and multi-chunk sizes
+	for _, size := range []int{0, 10, gcmChunkSize, 3*gcmChunkSize + 17} {
+		plain := bytes.Repeat([]byte("x"), size)
+		var sealed bytes.Buffer
+		if err := enc.Encrypt(&sealed, bytes.NewReader(plain)); err != nil {
+			t.Fatalf("Encrypt(%d): %v", size, err)
+		}
+		if size > 0 && bytes.Contains(sealed.Bytes(), plain[:min(size, 32)]) {
+			t.Errorf("size %d: ciphertext contains plaintext", size)
+		}
+
+		r, err := enc.Decrypt(&sealed)
+		if err != nil {
+			t.Fatalf("Decrypt(%d): %v", size, err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll(%d): %v", size, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: round trip mismatch (%d bytes back)", size, len(got))
+		}
+	}
+}
+
+func TestAESGCMEncryptor_RejectsTamperingAndTruncation(t *testing.T) {
+	enc := testEncryptor(t, 0x42)
+	var sealed bytes.Buffer
+	if err := enc.Encrypt(&sealed, bytes.NewReader(bytes.Repeat([]byte("y"), 2*gcmChunkSize+5))); err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	data := sealed.Bytes()
+
+	decryptAll := func(b []byte, e Encryptor) error {
+		r, err := e.Decrypt(bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadAll(r)
+		return err
+	}
+
+	tampered := append([]byte(nil), data...)
+	tampered[len(tampered)-1] ^= 0xff
+	if decryptAll(tampered, enc) == nil {
+		t.Error("tampered ciphertext decrypted")
+	}
+
+	// Drop the final frame entirely: must not look like a clean EOF
+	frameSize := 5 + 12 + gcmChunkSize + 16
+	if decryptAll(data[:len(gcmMagic)+1+2*frameSize], enc) == nil {
+		t.Error("truncated ciphertext decrypted without error")
+	}
+
+	if decryptAll(data, testEncryptor(t, 0x24)) == nil {
+		t.Error("wrong key decrypted")
+	}
+}
+
+func TestNewAESGCMEncryptor_KeyLength(t *testing.T) {
+	if _, err := NewAESGCMEncryptor(make([]byte, 16)); err == nil {
+		t.Error("expected error for 16-byte key")
+	}
+}
+
+// TestEncryptor_RotatedBackupsEncrypted verifies the background pipeline
+// produces .enc files (after compression) readable through OpenBackup.
+func TestEncryptor_RotatedBackupsEncrypted(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		name := "plain"
+		if compress {
+			name = "compressed"
+		}
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			logFile := filepath.Join(tmpDir, "pii.log")
+			enc := testEncryptor(t, 0x42)
+
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:        logFile,
+				Compress:        compress,
+				CompressMinSize: -1,
+				Encryptor:       enc,
+			})
+
+			secret := "ssn=123-45-6789\n"
+			if _, err := logger.Write([]byte(secret)); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := logger.Rotate(); err != nil {
+				t.Fatalf("Rotate: %v", err)
+			}
+			logger.WaitForBackgroundTasks()
+
+			matches, _ := filepath.Glob(logFile + ".*")
+			var backup string
+			for _, m := range matches {
+				if strings.HasSuffix(m, encryptedSuffix) {
+					backup = m
+				} else if !strings.HasSuffix(m, ".state") {
+					t.Errorf("unencrypted backup left behind: %s", m)
+				}
+			}
+			if backup == "" {
+				t.Fatalf("no encrypted backup among %v", matches)
+			}
+			if compress && !strings.HasSuffix(backup, ".gz"+encryptedSuffix) {
+				t.Errorf("backup %s: want .gz.enc when compressing", backup)
+			}
+
+			raw, err := os.ReadFile(backup)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(raw, []byte("123-45-6789")) {
+				t.Error("backup contains plaintext PII")
+			}
+
+			r, err := OpenBackup(backup, enc)
+			if err != nil {
+				t.Fatalf("OpenBackup: %v", err)
+			}
+			defer func() { _ = r.Close() }()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if string(got) != secret {
+				t.Errorf("OpenBackup content = %q, want %q", got, secret)
+			}
+
+			if _, err := OpenBackup(backup, nil); err == nil {
+				t.Error("OpenBackup without Encryptor should fail on .enc")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// errorcallback_test.go: Tests for SetErrorCallback
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestSetErrorCallback_SwapWhileRotating replaces the callback while the
+// consumer goroutine reports tee errors and size rotations fire; run with
+// -race to check the swap is synchronized.
+func TestSetErrorCallback_SwapWhileRotating(t *testing.T) {
+	var before, after atomic.Int64
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      filepath.Join(t.TempDir(), "app.log"),
+		MaxSizeStr:    "1KB",
+		Async:         true,
+		Tee:           failingWriter{},
+		ErrorCallback: func(string, error) { before.Add(1) },
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			_, _ = logger.Write([]byte("entry that fills the file quickly\n"))
+		}
+	}()
+	logger.SetErrorCallback(func(string, error) { after.Add(1) })
+	wg.Wait()
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if logger.Stats().SizeRotations == 0 {
+		t.Error("no rotation happened during the swap")
+	}
+	if after.Load() == 0 {
+		t.Errorf("replacement callback never called (old one called %d times)", before.Load())
+	}
+
+	// nil stops reports
+	logger.SetErrorCallback(nil)
+	seen := after.Load()
+	_, _ = logger.Write([]byte("unreported\n"))
+	_ = logger.Sync()
+	if got := after.Load(); got != seen {
+		t.Errorf("callback called %d more times after SetErrorCallback(nil)", got-seen)
+	}
+}
+
+func TestSetErrorCallback_CarriesOverToClone(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "app.log")})
+
+	var calls atomic.Int64
+	logger.SetErrorCallback(func(string, error) { calls.Add(1) })
+	clone, err := logger.Clone(filepath.Join(dir, "tenant.log"))
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	defer func() { _ = clone.Close() }()
+
+	clone.reportError("test", errors.New("boom"))
+	if calls.Load() != 1 {
+		t.Errorf("clone reported %d times to the callback set at runtime, want 1", calls.Load())
+	}
+}
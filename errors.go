@@ -0,0 +1,77 @@
+// errors.go: Typed errors for file, rotation and compression failures
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "fmt"
+
+// The error types below are returned by Write, Rotate* and Close, and passed
+// to ErrorCallback, so callers can branch with errors.As instead of matching
+// strings. Each wraps the underlying cause, so errors.Is also works, e.g.
+// errors.Is(err, fs.ErrPermission) or errors.Is(err, syscall.ENOSPC):
+//
+//	var openErr *lethe.FileOpenError
+//	if errors.As(err, &openErr) && errors.Is(err, syscall.ENOSPC) {
+//		alertDiskFull(openErr.Path)
+//	}
+
+// FileOpenError reports that the log file, or its directory, could not be
+// created, opened or inspected.
+type FileOpenError struct {
+	Op   string // "mkdir", "open" or "stat"
+	Path string // Log file, or directory for "mkdir"
+	Err  error  // Underlying cause
+}
+
+func (e *FileOpenError) Error() string {
+	switch e.Op {
+	case "mkdir":
+		return fmt.Sprintf("failed to create log directory %q: %v (check permissions and disk space)", e.Path, e.Err)
+	case "stat":
+		return fmt.Sprintf("failed to stat log file %q: %v", e.Path, e.Err)
+	default:
+		return fmt.Sprintf("failed to open log file %q: %v (check permissions and disk space)", e.Path, e.Err)
+	}
+}
+
+func (e *FileOpenError) Unwrap() error { return e.Err }
+
+// RotationError reports a failed step of rotating the active log file.
+type RotationError struct {
+	Op     string // "close", "rename" or "reopen"
+	Path   string // Active log file
+	Backup string // Backup name the file was being rotated to
+	Err    error  // Underlying cause
+}
+
+func (e *RotationError) Error() string {
+	switch e.Op {
+	case "close":
+		return fmt.Sprintf("failed to close current file: %v", e.Err)
+	case "rename":
+		return fmt.Sprintf("failed to rename log file %s to %s: %v", e.Path, e.Backup, e.Err)
+	case "reopen":
+		return fmt.Sprintf("failed to create new log file: %v", e.Err)
+	default:
+		return fmt.Sprintf("failed to rotate %s (%s): %v", e.Path, e.Op, e.Err)
+	}
+}
+
+func (e *RotationError) Unwrap() error { return e.Err }
+
+// CompressionError reports a failed compression of a rotated backup. The
+// ErrorCallback operation is "compress_" + Op.
+type CompressionError struct {
+	Op   string // Failed stage, e.g. "open", "copy", "finalize", "rename"
+	Path string // Backup being compressed
+	Err  error  // Underlying cause
+}
+
+func (e *CompressionError) Error() string {
+	return fmt.Sprintf("failed to compress %s (%s): %v", e.Path, e.Op, e.Err)
+}
+
+func (e *CompressionError) Unwrap() error { return e.Err }
@@ -0,0 +1,140 @@
+// errors_test.go: Tests for the typed errors
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFileOpenError_Mkdir(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var reported []error
+	l := &Logger{
+		Filename:   filepath.Join(blocker, "logs", "app.log"),
+		RetryCount: 1,
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if op == "directory_creation" {
+				reported = append(reported, err)
+			}
+		},
+	}
+	defer func() { _ = l.Close() }()
+
+	_, err := l.Write([]byte("x\n"))
+	var openErr *FileOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("Write error %v (%T) is not a *FileOpenError", err, err)
+	}
+	if openErr.Op != "mkdir" || openErr.Path != filepath.Join(blocker, "logs") || openErr.Unwrap() == nil {
+		t.Errorf("FileOpenError = %+v", openErr)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 1 || !errors.As(reported[0], &openErr) {
+		t.Errorf("ErrorCallback got %v, want the same *FileOpenError", reported)
+	}
+}
+
+func TestFileOpenError_Open(t *testing.T) {
+	dir := t.TempDir()
+	l := &Logger{Filename: filepath.Join(dir, "app.log"), RetryCount: 1}
+	defer func() { _ = l.Close() }()
+	if err := os.Mkdir(l.Filename, 0750); err != nil { // A directory cannot be opened for writing
+		t.Fatal(err)
+	}
+
+	_, err := l.Write([]byte("x\n"))
+	var openErr *FileOpenError
+	if !errors.As(err, &openErr) || openErr.Op != "open" {
+		t.Fatalf("Write error = %v, want a *FileOpenError for open", err)
+	}
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) {
+		t.Errorf("cause not reachable through the retry wrapper: %v", err)
+	}
+	if !strings.Contains(err.Error(), "failed to open log file") {
+		t.Errorf("message = %q", err.Error())
+	}
+}
+
+func TestRotationError_Close(t *testing.T) {
+	l := &Logger{Filename: filepath.Join(t.TempDir(), "app.log"), RetryCount: 1}
+	defer func() { _ = l.Close() }()
+	if _, err := l.Write([]byte("data\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	file := l.currentFile.Load()
+	_ = file.Close()
+	retryCount, retryDelay, fileMode := l.getRetryConfig()
+	err := l.closeAndRotateFile(file, l.Filename+".backup", retryCount, retryDelay, fileMode)
+
+	var rotErr *RotationError
+	if !errors.As(err, &rotErr) {
+		t.Fatalf("error %v (%T) is not a *RotationError", err, err)
+	}
+	if rotErr.Op != "close" || rotErr.Path != l.Filename || rotErr.Backup != l.Filename+".backup" {
+		t.Errorf("RotationError = %+v", rotErr)
+	}
+	if !errors.Is(err, os.ErrClosed) {
+		t.Errorf("errors.Is(err, os.ErrClosed) = false for %v", err)
+	}
+}
+
+func TestCompressionError_MissingBackup(t *testing.T) {
+	var mu sync.Mutex
+	var ops []string
+	l := &Logger{
+		Filename:        filepath.Join(t.TempDir(), "app.log"),
+		CompressMinSize: -1,
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, op)
+		},
+	}
+
+	err := l.compressFile(l.Filename + ".2025-01-01-00-00-00")
+	var compErr *CompressionError
+	if !errors.As(err, &compErr) {
+		t.Fatalf("error %v (%T) is not a *CompressionError", err, err)
+	}
+	if compErr.Op != "open" || !strings.HasSuffix(compErr.Path, ".2025-01-01-00-00-00") {
+		t.Errorf("CompressionError = %+v", compErr)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("errors.Is(err, fs.ErrNotExist) = false for %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ops) != 1 || ops[0] != "compress_open" {
+		t.Errorf("reported operations = %v, want [compress_open]", ops)
+	}
+}
+
+func TestRetryFileOperation_WrapsCause(t *testing.T) {
+	err := RetryFileOperation(func() error { return fs.ErrPermission }, 2, 1)
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("errors.Is(err, fs.ErrPermission) = false for %v", err)
+	}
+}
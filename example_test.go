@@ -349,8 +349,15 @@ func Example_cleanup() {
 
 // Example showing time-based rotation with MaxAgeStr
 func Example_timeBasedRotation() {
+	// The rotated backups go to a scratch directory, not the working directory
+	dir, err := os.MkdirTemp("", "lethe-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
 	config := &lethe.LoggerConfig{
-		Filename:   "time_rotation.log",
+		Filename:   filepath.Join(dir, "time_rotation.log"),
 		MaxSizeStr: "10MB", // Large enough to not trigger size rotation
 		MaxAgeStr:  "1s",   // Rotate every second for demo
 		MaxBackups: 3,
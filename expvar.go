@@ -0,0 +1,64 @@
+// expvar.go: Stats exposure through the standard library's expvar
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Names published by PublishExpvar, each bound to the Logger it reports.
+// WHY a binding instead of publishing the Logger directly: expvar has no
+// Unpublish, so a service that replaces its Logger (e.g. on config reload)
+// must be able to point the existing name at the new one.
+var (
+	expvarMu      sync.Mutex
+	expvarLoggers = map[string]*atomic.Pointer[Logger]{}
+)
+
+// PublishExpvar publishes l.Stats() as the expvar variable name, served
+// as JSON at /debug/vars once the expvar handler is mounted (importing
+// expvar registers it on http.DefaultServeMux). The value is computed on
+// every scrape from live counters.
+//
+// Publishing a name again, from this or another Logger, rebinds it to the
+// latest caller instead of panicking like expvar.Publish does. Returns an
+// error if name is empty or already published by someone other than Lethe.
+//
+// Example:
+//
+//	if err := logger.PublishExpvar("lethe_app"); err != nil {
+//		log.Printf("expvar: %v", err)
+//	}
+//	go http.ListenAndServe("localhost:6060", nil) // GET /debug/vars
+func (l *Logger) PublishExpvar(name string) error {
+	if name == "" {
+		return errors.New("lethe: PublishExpvar: name cannot be empty")
+	}
+
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if bound, ok := expvarLoggers[name]; ok {
+		bound.Store(l)
+		return nil
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("lethe: PublishExpvar: %q is already published", name)
+	}
+
+	bound := &atomic.Pointer[Logger]{}
+	bound.Store(l)
+	expvar.Publish(name, expvar.Func(func() any {
+		return bound.Load().Stats()
+	}))
+	expvarLoggers[name] = bound
+	return nil
+}
@@ -0,0 +1,85 @@
+// expvar_test.go: Tests for PublishExpvar
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"encoding/json"
+	"expvar"
+	"path/filepath"
+	"testing"
+)
+
+// expvarStats decodes the published value of name.
+func expvarStats(t *testing.T, name string) Stats {
+	t.Helper()
+	v := expvar.Get(name)
+	if v == nil {
+		t.Fatalf("expvar %q not published", name)
+	}
+	var stats Stats
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatalf("decode expvar %q: %v", name, err)
+	}
+	return stats
+}
+
+func TestPublishExpvar_ReportsLiveStats(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "expvar.log"),
+		DisableAutoScale: true,
+	})
+
+	if err := logger.PublishExpvar("lethe_test_live"); err != nil {
+		t.Fatalf("PublishExpvar: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if got := expvarStats(t, "lethe_test_live").WriteCount; got != 3 {
+		t.Errorf("published WriteCount = %d, want 3", got)
+	}
+}
+
+// TestPublishExpvar_Rebinds verifies publishing a name twice rebinds it
+// instead of panicking.
+func TestPublishExpvar_Rebinds(t *testing.T) {
+	dir := t.TempDir()
+	first := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "first.log")})
+	second := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "second.log")})
+
+	if err := first.PublishExpvar("lethe_test_rebind"); err != nil {
+		t.Fatalf("PublishExpvar: %v", err)
+	}
+	if err := second.PublishExpvar("lethe_test_rebind"); err != nil {
+		t.Fatalf("second PublishExpvar: %v", err)
+	}
+	if _, err := second.Write([]byte("to the second logger\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := expvarStats(t, "lethe_test_rebind").WriteCount; got != 1 {
+		t.Errorf("published WriteCount = %d, want 1 from the rebound Logger", got)
+	}
+}
+
+func TestPublishExpvar_RejectsForeignName(t *testing.T) {
+	if expvar.Get("lethe_test_foreign") == nil { // -count > 1 reuses the process
+		expvar.NewInt("lethe_test_foreign")
+	}
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "foreign.log")})
+
+	if err := logger.PublishExpvar("lethe_test_foreign"); err == nil {
+		t.Error("PublishExpvar took over a variable published by another package")
+	}
+	if err := logger.PublishExpvar(""); err == nil {
+		t.Error("PublishExpvar accepted an empty name")
+	}
+}
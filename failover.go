@@ -0,0 +1,160 @@
+// failover.go: Fallback output file when Filename becomes unwritable (FallbackFilename)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// failbackInterval bounds how often a failed-over Logger retries Filename.
+// WHY not on every write: each attempt is an open syscall, and a storage
+// outage rarely ends within milliseconds.
+const failbackInterval = 5 * time.Second
+
+// errFailedOver is returned by rotation while writes go to the fallback:
+// Filename may still be unwritable, and rotating the fallback would mix
+// its segments into the primary's backups.
+var errFailedOver = errors.New("rotation deferred: writing to FallbackFilename")
+
+// FailoverEvent describes a switch between Filename and FallbackFilename.
+type FailoverEvent struct {
+	// From is the path writes went to before the switch
+	From string
+
+	// To is the path writes go to from now on
+	To string
+
+	// Cause is the write error that triggered a failover; nil on failback
+	Cause error
+}
+
+// failover handles a write of rest that failed on file with cause. It
+// retries on a fresh handle to Filename (RetryCount/RetryDelay), then
+// switches to FallbackFilename. Returns how much of rest was written and
+// the error to report, nil once rest is safely written somewhere.
+//
+// Claims the rotation flag like ensureFilePresent, so a rotation cannot
+// swap the file underneath it.
+func (l *Logger) failover(file *os.File, rest []byte, cause error) (int, error) {
+	if l.FallbackFilename == "" || l.failedOver.Load() {
+		return 0, cause // The fallback itself failing has nowhere left to go
+	}
+	if !l.rotationFlag.CompareAndSwap(false, true) {
+		return 0, cause
+	}
+	defer l.rotationFlag.Store(false)
+
+	// Another writer already switched files: retry once on the new one
+	if current := l.currentFile.Load(); current != file && current != nil {
+		return writeFull(current, rest)
+	}
+
+	retryCount, retryDelay, fileMode := l.getRetryConfig()
+	var primary *os.File
+	err := l.retryFileOperation(func() error {
+		f, err := openAppend(l.Filename, fileMode)
+		if err != nil {
+			return err
+		}
+		if _, err := writeFull(f, rest); err != nil {
+			_ = f.Close() // Unusable handle; the write error is what matters
+			return err
+		}
+		primary = f
+		return nil
+	}, retryCount, retryDelay)
+	if err == nil {
+		// The old handle was bad, not the path (e.g., a failed reopen)
+		l.swapActiveFile(file, primary, len(rest))
+		return len(rest), nil
+	}
+
+	fallback, err := openAppend(l.FallbackFilename, fileMode)
+	if err != nil {
+		l.reportError("failover", fmt.Errorf("fallback %s unusable after primary failure (%v): %w", l.FallbackFilename, cause, err))
+		return 0, cause
+	}
+	n, err := writeFull(fallback, rest)
+	if err != nil {
+		_ = fallback.Close() // Neither path takes writes; keep the primary handle
+		l.reportError("failover", fmt.Errorf("fallback %s unwritable after primary failure (%v): %w", l.FallbackFilename, cause, err))
+		return 0, cause
+	}
+
+	l.failedOver.Store(true)
+	l.lastFailbackCheck.Store(time.Now().UnixNano())
+	l.swapActiveFile(file, fallback, n)
+	l.reportError("failover", fmt.Errorf("log file %s unwritable, writing to %s: %w", l.Filename, l.FallbackFilename, cause))
+	l.invokeOnFailover(FailoverEvent{From: l.Filename, To: l.FallbackFilename, Cause: cause})
+	return n, nil
+}
+
+// maybeFailBack returns to Filename once it can be opened again. Called
+// from both write paths; one caller per failbackInterval attempts it.
+func (l *Logger) maybeFailBack() {
+	if !l.failedOver.Load() {
+		return
+	}
+	last := l.lastFailbackCheck.Load()
+	now := time.Now().UnixNano()
+	if now-last < int64(failbackInterval) || !l.lastFailbackCheck.CompareAndSwap(last, now) {
+		return
+	}
+
+	if !l.rotationFlag.CompareAndSwap(false, true) {
+		return
+	}
+	defer l.rotationFlag.Store(false)
+
+	_, _, fileMode := l.getRetryConfig()
+	primary, err := openAppend(l.Filename, fileMode)
+	if err != nil {
+		return // Still unwritable; retried next interval
+	}
+	l.swapActiveFile(l.currentFile.Load(), primary, 0)
+	l.failedOver.Store(false)
+	l.invokeOnFailover(FailoverEvent{From: l.FallbackFilename, To: l.Filename})
+}
+
+// swapActiveFile makes next the active file and closes old. The caller
+// holds the rotation flag. bytesWritten follows next's size, so size
+// rotation resumes from what the file really holds, minus the pending
+// bytes already written to next that the write path still accounts for.
+func (l *Logger) swapActiveFile(old, next *os.File, pending int) {
+	var size uint64
+	if info, err := next.Stat(); err == nil && info.Size() > int64(pending) {
+		size = uint64(info.Size() - int64(pending)) // #nosec G115 -- checked positive above
+	}
+
+	release := l.holdSyncBuffer()
+	l.currentFile.Store(next)
+	if old != nil {
+		_ = old.Close() // The handle is being abandoned because it failed
+	}
+	release()
+
+	l.bytesWritten.Store(size)
+	l.lineCount.Store(0)
+}
+
+// invokeOnFailover calls OnFailover with panic recovery.
+// WHY: it runs on the write path; in async mode a panic would kill the
+// consumer goroutine.
+func (l *Logger) invokeOnFailover(event FailoverEvent) {
+	if l.OnFailover == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("on_failover_panic", fmt.Errorf("OnFailover callback panicked: %v", r))
+		}
+	}()
+	l.OnFailover(event)
+}
@@ -0,0 +1,112 @@
+// failover_test.go: Tests for FallbackFilename
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// breakPrimary closes the active handle and puts a directory where the
+// log file was, so neither the handle nor a reopen can take writes (even
+// as root, where permission bits would not stop us).
+func breakPrimary(t *testing.T, logger *Logger) {
+	t.Helper()
+	_ = logger.currentFile.Load().Close()
+	if err := os.Remove(logger.Filename); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := os.Mkdir(logger.Filename, 0755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+}
+
+func TestFailover_SwitchesToFallbackAndBack(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "primary.log")
+	fallbackFile := filepath.Join(dir, "fallback.log")
+
+	var events []FailoverEvent
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		FallbackFilename: fallbackFile,
+		RetryCount:       1,
+		RetryDelay:       1,
+		OnFailover:       func(event FailoverEvent) { events = append(events, event) },
+	})
+
+	if _, err := logger.Write([]byte("before outage\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	breakPrimary(t, logger)
+
+	if _, err := logger.Write([]byte("during outage\n")); err != nil {
+		t.Fatalf("Write during outage = %v, want it absorbed by the fallback", err)
+	}
+	if got := readLog(t, fallbackFile); got != "during outage\n" {
+		t.Errorf("fallback content = %q", got)
+	}
+	if len(events) != 1 || events[0].To != fallbackFile || events[0].Cause == nil {
+		t.Fatalf("events after failover = %+v", events)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Errorf("Rotate while failed over: %v", err)
+	}
+
+	// Storage is back; skip the wait for the next failback attempt
+	if err := os.Remove(logFile); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	logger.lastFailbackCheck.Store(0)
+	if _, err := logger.Write([]byte("after recovery\n")); err != nil {
+		t.Fatalf("Write after recovery: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "after recovery\n" {
+		t.Errorf("primary content after failback = %q", got)
+	}
+	if len(events) != 2 || events[1].To != logFile || events[1].Cause != nil {
+		t.Errorf("events after failback = %+v", events)
+	}
+}
+
+// TestFailover_BadHandleReopensPrimary verifies a failed handle on a
+// healthy path is replaced without failing over.
+func TestFailover_BadHandleReopensPrimary(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "primary.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		FallbackFilename: filepath.Join(dir, "fallback.log"),
+		OnFailover:       func(event FailoverEvent) { t.Errorf("unexpected failover: %+v", event) },
+	})
+
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	_ = logger.currentFile.Load().Close()
+	if _, err := logger.Write([]byte("second\n")); err != nil {
+		t.Fatalf("Write on a closed handle: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "first\nsecond\n" {
+		t.Errorf("log content = %q", got)
+	}
+}
+
+func TestFailover_ValidateConfig(t *testing.T) {
+	err := ValidateConfig(&LoggerConfig{Filename: "app.log", FallbackFilename: "./app.log"})
+	if err == nil || !strings.Contains(err.Error(), "FallbackFilename") {
+		t.Errorf("same path accepted: %v", err)
+	}
+	err = ValidateConfig(&LoggerConfig{Filename: "app.log", FallbackFilename: "/tmp/app.log", MultiProcess: true})
+	if err == nil {
+		t.Error("FallbackFilename accepted with MultiProcess")
+	}
+}
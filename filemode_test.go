@@ -0,0 +1,115 @@
+// filemode_test.go: Tests for backup file permissions
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestBackupFileMode_AppliedToBackupArtifacts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits not supported on Windows")
+	}
+
+	logFile := filepath.Join(t.TempDir(), "modes.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:       logFile,
+		FileMode:       0640,
+		BackupFileMode: 0600,
+		Checksum:       true,
+	})
+
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+
+	// Uncompressed backup plus checksum sidecar
+	backups, _ := filepath.Glob(logFile + ".*")
+	if len(backups) != 2 {
+		t.Fatalf("backups = %v, want backup and sidecar", backups)
+	}
+	for _, path := range backups {
+		assertMode(t, path, 0600)
+	}
+	assertMode(t, logFile, 0640)
+
+	// Compressed output
+	logger.Compress = true
+	logger.CompressMinSize = -1
+	logger.Checksum = false
+	if _, err := logger.Write([]byte("more\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	gz, _ := filepath.Glob(logFile + ".*.gz")
+	if len(gz) != 1 {
+		t.Fatalf("compressed backups = %v, want 1", gz)
+	}
+	assertMode(t, gz[0], 0600)
+}
+
+func TestBackupFileMode_DefaultsToFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits not supported on Windows")
+	}
+
+	logFile := filepath.Join(t.TempDir(), "default-mode.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		FileMode:        0600,
+		Compress:        true,
+		CompressMinSize: -1,
+	})
+
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	gz, _ := filepath.Glob(logFile + ".*.gz")
+	if len(gz) != 1 {
+		t.Fatalf("compressed backups = %v, want 1", gz)
+	}
+	assertMode(t, gz[0], 0600)
+}
+
+// assertMode fails the test if path's permission bits differ from want.
+func assertMode(t *testing.T, path string, want os.FileMode) {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat(%s): %v", path, err)
+	}
+	if got := info.Mode().Perm(); got != want {
+		t.Errorf("%s mode = %o, want %o", filepath.Base(path), got, want)
+	}
+}
+
+func TestDirMode_AppliedToCreatedDirectories(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits not supported on Windows")
+	}
+
+	root := t.TempDir()
+	logFile := filepath.Join(root, "a", "b", "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, DirMode: 0700})
+
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	assertMode(t, filepath.Join(root, "a"), 0700)
+	assertMode(t, filepath.Join(root, "a", "b"), 0700)
+}
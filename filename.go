@@ -0,0 +1,55 @@
+// filename.go: Host and process placeholders in log file names
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Filename placeholders expanded by ExpandFilename.
+const (
+	placeholderHost = "{host}" // os.Hostname(), resolved once per process
+	placeholderPID  = "{pid}"  // os.Getpid()
+)
+
+// cachedHostname resolves os.Hostname once; the name does not change for
+// the life of the process, and the syscall is not free.
+var cachedHostname = sync.OnceValue(func() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "unknown-host"
+	}
+	// A hostname must not split the path or escape the log directory
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(host)
+})
+
+// ExpandFilename replaces the {host} and {pid} placeholders in filename
+// with the machine's hostname and the current process ID, so instances
+// sharing a directory (e.g., an NFS mount) write to distinct files:
+//
+//	lethe.ExpandFilename("/mnt/logs/app-{host}-{pid}.log")
+//	// "/mnt/logs/app-web-3-4127.log"
+//
+// The constructors (New, NewSimple, NewWithConfig and the presets) expand
+// Filename once, before validation, so the name and its backups stay fixed
+// for the Logger's lifetime. Call it yourself when building a Logger as a
+// struct literal. Names without placeholders are returned unchanged.
+func ExpandFilename(filename string) string {
+	if !strings.Contains(filename, "{") {
+		return filename // Fast path: no placeholders
+	}
+	if strings.Contains(filename, placeholderHost) {
+		filename = strings.ReplaceAll(filename, placeholderHost, cachedHostname())
+	}
+	if strings.Contains(filename, placeholderPID) {
+		filename = strings.ReplaceAll(filename, placeholderPID, strconv.Itoa(os.Getpid()))
+	}
+	return filename
+}
@@ -0,0 +1,100 @@
+// filename_test.go: Tests for {host} and {pid} filename placeholders
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExpandFilename(t *testing.T) {
+	host := cachedHostname()
+	pid := strconv.Itoa(os.Getpid())
+
+	tests := []struct {
+		in, want string
+	}{
+		{"app.log", "app.log"},
+		{"app-{host}.log", "app-" + host + ".log"},
+		{"app-{pid}.log", "app-" + pid + ".log"},
+		{"{host}/app-{host}-{pid}.log", host + "/app-" + host + "-" + pid + ".log"},
+		{"app-{unknown}.log", "app-{unknown}.log"},
+	}
+	for _, tt := range tests {
+		if got := ExpandFilename(tt.in); got != tt.want {
+			t.Errorf("ExpandFilename(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if strings.ContainsAny(host, `/\`) {
+		t.Errorf("hostname %q contains a path separator", host)
+	}
+}
+
+func TestExpandFilename_StableAcrossLifetime(t *testing.T) {
+	dir := t.TempDir()
+	config := &LoggerConfig{Filename: filepath.Join(dir, "app-{host}-{pid}.log"), MaxBackups: 5}
+	want := ExpandFilename(config.Filename)
+
+	logger := newTestLogger(t, config)
+
+	if logger.Filename != want {
+		t.Fatalf("Filename = %q, want %q", logger.Filename, want)
+	}
+	if !strings.Contains(config.Filename, "{host}") {
+		t.Errorf("caller's config was modified: %q", config.Filename)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	}
+	if _, err := logger.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if logger.Filename != want {
+		t.Errorf("Filename changed to %q after rotation, want %q", logger.Filename, want)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("active file: %v", err)
+	}
+	backups, _ := filepath.Glob(want + ".*")
+	if len(backups) != 2 {
+		t.Errorf("backups = %v, want 2 named after %s", backups, filepath.Base(want))
+	}
+	if literal, _ := filepath.Glob(filepath.Join(dir, "*{*")); len(literal) != 0 {
+		t.Errorf("unexpanded files created: %v", literal)
+	}
+}
+
+func TestExpandFilename_SimpleConstructors(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app-{pid}.log")
+	want := ExpandFilename(name)
+
+	legacy, err := New(name, 1, 1)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer func() { _ = legacy.Close() }()
+	simple, err := NewSimple(name, "1MB", 1)
+	if err != nil {
+		t.Fatalf("NewSimple: %v", err)
+	}
+	defer func() { _ = simple.Close() }()
+
+	if legacy.Filename != want || simple.Filename != want {
+		t.Errorf("Filename = %q / %q, want %q", legacy.Filename, simple.Filename, want)
+	}
+}
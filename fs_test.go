@@ -0,0 +1,168 @@
+// fs_test.go: Fault-injecting FileSystem for rotation error-path tests
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+// faultFS is a FileSystem over the real disk that records every call and
+// fails the operations given an error. Install it with logger.fs = fs.
+//
+// FileSystem hands out *os.File, so it cannot be backed by memory; tests
+// run in t.TempDir() and inspect the result with listDir.
+type faultFS struct {
+	DefaultFileSystem
+
+	failCreate error // Returned by Create when set
+	failOpen   error // Returned by Open when set
+	failRename error // Returned by Rename when set
+	failRemove error // Returned by Remove when set
+	failStat   error // Returned by Stat when set
+
+	mu    sync.Mutex
+	calls []string // "op name" for each call, in order
+}
+
+func (fs *faultFS) record(op, name string) {
+	fs.mu.Lock()
+	fs.calls = append(fs.calls, op+" "+filepath.Base(name))
+	fs.mu.Unlock()
+}
+
+// count returns how many times op was called.
+func (fs *faultFS) count(op string) int {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n := 0
+	for _, call := range fs.calls {
+		if strings.HasPrefix(call, op+" ") {
+			n++
+		}
+	}
+	return n
+}
+
+func (fs *faultFS) Create(name string) (*os.File, error) {
+	fs.record("create", name)
+	if fs.failCreate != nil {
+		return nil, &os.PathError{Op: "create", Path: name, Err: fs.failCreate}
+	}
+	return fs.DefaultFileSystem.Create(name)
+}
+
+func (fs *faultFS) Open(name string) (*os.File, error) {
+	fs.record("open", name)
+	if fs.failOpen != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: fs.failOpen}
+	}
+	return fs.DefaultFileSystem.Open(name)
+}
+
+func (fs *faultFS) Rename(oldname, newname string) error {
+	fs.record("rename", oldname)
+	if fs.failRename != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: fs.failRename}
+	}
+	return fs.DefaultFileSystem.Rename(oldname, newname)
+}
+
+func (fs *faultFS) Remove(name string) error {
+	fs.record("remove", name)
+	if fs.failRemove != nil {
+		return &os.PathError{Op: "remove", Path: name, Err: fs.failRemove}
+	}
+	return fs.DefaultFileSystem.Remove(name)
+}
+
+func (fs *faultFS) Stat(name string) (os.FileInfo, error) {
+	fs.record("stat", name)
+	if fs.failStat != nil {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: fs.failStat}
+	}
+	return fs.DefaultFileSystem.Stat(name)
+}
+
+// listDir returns the sorted names of the entries in dir.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir %s: %v", dir, err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotate_RenameFailureIsReported(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "fail.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, RetryCount: 2})
+	fs := &faultFS{failRename: syscall.EACCES}
+	logger.fs = fs
+
+	if _, err := logger.Write([]byte("kept in place\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	err := logger.RotateSync()
+
+	var rotErr *RotationError
+	if !errors.As(err, &rotErr) || rotErr.Op != "rename" {
+		t.Fatalf("RotateSync error = %v, want a rename RotationError", err)
+	}
+	if !errors.Is(err, syscall.EACCES) {
+		t.Errorf("errors.Is(err, EACCES) = false for %v", err)
+	}
+	if n := fs.count("rename"); n != 2 {
+		t.Errorf("rename attempted %d times, want RetryCount (2)", n)
+	}
+
+	// Nothing was rotated away: the data is still under the active name
+	if names := listDir(t, dir); len(names) != 1 || names[0] != "fail.log" {
+		t.Errorf("directory = %v, want only fail.log", names)
+	}
+	if content, _ := os.ReadFile(logFile); string(content) != "kept in place\n" {
+		t.Errorf("active file content = %q", content)
+	}
+}
+
+func TestRotate_FaultFSPassesThrough(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "ok.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+	fs := &faultFS{}
+	logger.fs = fs
+
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write([]byte("segment\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	}
+
+	if n := fs.count("rename"); n != 2 {
+		t.Errorf("rename called %d times, want 2", n)
+	}
+	if names := listDir(t, dir); len(names) != 3 {
+		t.Errorf("directory = %v, want the active file and 2 backups", names)
+	}
+}
@@ -0,0 +1,78 @@
+// health.go: Operational health snapshot for readiness and liveness probes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "time"
+
+// HealthStatus is a snapshot of whether a Logger is able to do its job.
+type HealthStatus struct {
+	Healthy bool   `json:"healthy"`          // All checks below passed
+	Reason  string `json:"reason,omitempty"` // First failed check, empty when Healthy
+
+	FileOpen        bool `json:"file_open"`        // An active file is open (false before the first write)
+	ConsumerRunning bool `json:"consumer_running"` // The MPSC consumer is alive or parked (false in sync mode)
+	ConsumerStalled bool `json:"consumer_stalled"` // Stuck for StallTimeout with messages buffered
+
+	// ConsumerHeartbeat is when the consumer loop last ran; zero when no
+	// consumer exists. An idle consumer sleeps until data arrives, so an old
+	// heartbeat alone does not mean it is stuck.
+	ConsumerHeartbeat time.Time `json:"consumer_heartbeat"`
+
+	// DroppedRecently counts messages dropped on a full buffer since the
+	// previous Health call (since creation for the first call).
+	DroppedRecently uint64 `json:"dropped_recently"`
+}
+
+// Health reports whether the Logger is operational, for readiness or
+// liveness probes. It is a cheap snapshot of existing atomics and never
+// touches the filesystem. The Logger is unhealthy when it is closed, when
+// it has accepted writes but has no open file, when its MPSC consumer has
+// exited or is stalled (see StallTimeout), or when messages were dropped
+// since the previous call.
+//
+// DroppedRecently is consumed by each call, so use one prober per Logger;
+// concurrent callers split the count between them.
+//
+// Example:
+//
+//	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+//		if h := logger.Health(); !h.Healthy {
+//			http.Error(w, h.Reason, http.StatusServiceUnavailable)
+//		}
+//	})
+func (l *Logger) Health() HealthStatus {
+	dropped := l.droppedCount.Load()
+	status := HealthStatus{
+		FileOpen:        l.currentFile.Load() != nil,
+		DroppedRecently: dropped - l.healthDropped.Swap(dropped),
+	}
+
+	// initMPSC publishes the buffer before the consumer, so a buffer
+	// without a consumer is still starting up, not failed
+	consumer := l.consumer.Load()
+	if consumer != nil {
+		status.ConsumerRunning = consumer.running.Load()
+		status.ConsumerHeartbeat = time.Unix(0, consumer.heartbeat.Load())
+		status.ConsumerStalled = consumer.stalled.Load()
+	}
+
+	switch {
+	case l.closed.Load():
+		status.Reason = "logger is closed"
+	case !status.FileOpen && l.writeCount.Load() > 0:
+		status.Reason = "no open log file after writes"
+	case consumer != nil && !status.ConsumerRunning:
+		status.Reason = "MPSC consumer is not running"
+	case status.ConsumerStalled:
+		status.Reason = "MPSC consumer is stalled"
+	case status.DroppedRecently > 0:
+		status.Reason = "messages dropped on a full buffer"
+	default:
+		status.Healthy = true
+	}
+	return status
+}
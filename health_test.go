@@ -0,0 +1,63 @@
+// health_test.go: Tests for Logger.Health
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHealth_Lifecycle(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "health.log"), DisableAutoScale: true})
+
+	// No writes yet: no file is expected
+	if h := logger.Health(); !h.Healthy || h.FileOpen {
+		t.Errorf("fresh logger Health = %+v, want healthy without an open file", h)
+	}
+
+	if _, err := logger.Write([]byte("ok\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	h := logger.Health()
+	if !h.Healthy || !h.FileOpen || h.ConsumerRunning {
+		t.Errorf("sync logger Health = %+v, want healthy, file open, no consumer", h)
+	}
+
+	// Drops make one probe unhealthy; the next sees no new drops
+	logger.droppedCount.Add(3)
+	if h := logger.Health(); h.Healthy || h.DroppedRecently != 3 {
+		t.Errorf("after drops Health = %+v, want unhealthy with 3 recent drops", h)
+	}
+	if h := logger.Health(); !h.Healthy || h.DroppedRecently != 0 {
+		t.Errorf("next Health = %+v, want healthy with no recent drops", h)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if h := logger.Health(); h.Healthy || h.Reason != "logger is closed" {
+		t.Errorf("closed logger Health = %+v, want unhealthy (closed)", h)
+	}
+}
+
+func TestHealth_ConsumerRunning(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "async.log"), Async: true})
+
+	if _, err := logger.Write([]byte("queued\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	h := logger.Health()
+	if !h.Healthy || !h.ConsumerRunning || h.ConsumerHeartbeat.IsZero() {
+		t.Fatalf("async logger Health = %+v, want healthy with a running consumer", h)
+	}
+
+	// A consumer that exited while the Logger is open is a failure
+	logger.consumer.Load().stop()
+	if h := logger.Health(); h.Healthy || h.ConsumerRunning {
+		t.Errorf("after consumer exit Health = %+v, want unhealthy", h)
+	}
+}
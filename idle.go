@@ -0,0 +1,41 @@
+// idle.go: Parking of an idle MPSC consumer (IdleTimeout)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+// park publishes that the consumer goroutine is about to exit after
+// IdleTimeout without data. Returns false if work arrived meanwhile and the
+// loop must go on.
+//
+// WHY a flag both sides race on: the consumer sets parked and then checks
+// the buffer, a writer pushes and then checks parked. With sequentially
+// consistent atomics at least one of them sees the other, so a write is
+// never left in the ring with no goroutine to drain it. Whoever clears the
+// flag owns the restart: a writer via unpark, the consumer by carrying on.
+func (c *MPSCConsumer) park() bool {
+	buffer := c.ring()
+	// Set here rather than at creation: the ring may be a successor made
+	// by adaptive resize. parked publishes it to the writer that reads it.
+	buffer.unpark = c.unpark
+	buffer.parked.Store(true)
+	if buffer.queued() == 0 && !buffer.retired() && !c.logger.spilling() {
+		return true
+	}
+	// A writer that already cleared the flag is starting our replacement
+	return !buffer.parked.CompareAndSwap(true, false)
+}
+
+// unpark starts a new run loop for a parked consumer. Called by the writer
+// that cleared ringBuffer.parked.
+func (c *MPSCConsumer) unpark() {
+	c.parkMu.Lock()
+	defer c.parkMu.Unlock()
+	if c.ctx.Err() != nil {
+		return // Closing: stop flushes what is left
+	}
+	c.wg.Add(1)
+	go c.run()
+}
@@ -0,0 +1,94 @@
+// idle_test.go: Tests for parking an idle MPSC consumer (IdleTimeout)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitParked waits for the consumer of logger to park.
+func waitParked(t *testing.T, logger *Logger) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !logger.buffer.Load().parked.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("consumer did not park after IdleTimeout")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestIdleTimeout_ParksAndWakesOnWrite(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "idle.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:    logFile,
+		Async:       true,
+		IdleTimeout: 10 * time.Millisecond,
+	})
+
+	if _, err := logger.Write([]byte("before parking\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitParked(t, logger)
+	if h := logger.Health(); !h.ConsumerRunning || !h.Healthy {
+		t.Errorf("parked consumer reported as down: %+v", h)
+	}
+
+	// No Sync: the write alone must bring the consumer back
+	if _, err := logger.Write([]byte("after parking\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(readLog(t, logFile), "after parking") {
+		if time.Now().After(deadline) {
+			t.Fatal("write after parking never reached the file")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestIdleTimeout_NoLostWakeup parks and wakes the consumer constantly
+// while writers race it; every message must reach the file.
+func TestIdleTimeout_NoLostWakeup(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "race.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:    logFile,
+		Async:       true,
+		IdleTimeout: 50 * time.Microsecond,
+	})
+
+	const writers, perWriter = 4, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := logger.Write([]byte(fmt.Sprintf("w%d-%d\n", w, i))); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+				if i%10 == 0 {
+					time.Sleep(100 * time.Microsecond) // Let the consumer park
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := strings.Count(readLog(t, logFile), "\n"); got != writers*perWriter {
+		t.Errorf("lines = %d, want %d", got, writers*perWriter)
+	}
+}
@@ -0,0 +1,108 @@
+// inode_test.go: Tests for InodeCheckInterval
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newInodeLogger returns a Logger checking its path every 10ms and the
+// ops it reported.
+func newInodeLogger(t *testing.T, logFile string) (*Logger, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var ops []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           logFile,
+		InodeCheckInterval: 10 * time.Millisecond,
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, op)
+		},
+	})
+	return logger, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), ops...)
+	}
+}
+
+// TestInodeCheck_ReopensReplacedFile simulates an external rotator that
+// moves app.log away and creates a new one: later writes must land in the
+// new file, appended after what the rotator put there.
+func TestInodeCheck_ReopensReplacedFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be renamed on Windows")
+	}
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger, ops := newInodeLogger(t, logFile)
+
+	if _, err := logger.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Rename(logFile, logFile+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(logFile, []byte("header\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := logger.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "header\nafter\n" {
+		t.Errorf("new file content = %q, want %q", got, "header\nafter\n")
+	}
+	if got := readLog(t, logFile+".1"); got != "before\n" {
+		t.Errorf("moved file content = %q, want %q", got, "before\n")
+	}
+	if got := logger.Stats().CurrentFileSize; got != uint64(len("header\nafter\n")) {
+		t.Errorf("CurrentFileSize = %d, want the new file's size", got)
+	}
+
+	reported := ops()
+	if len(reported) != 1 || reported[0] != "inode_changed" {
+		t.Errorf("reported ops = %v, want [inode_changed]", reported)
+	}
+}
+
+// TestInodeCheck_LeavesMissingFile verifies a deleted path is not
+// recreated without RecreateIfMissing: it is not an inode change.
+func TestInodeCheck_LeavesMissingFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be deleted on Windows")
+	}
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger, ops := newInodeLogger(t, logFile)
+
+	if _, err := logger.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Remove(logFile); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := logger.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+		t.Errorf("log file recreated without RecreateIfMissing (err=%v)", err)
+	}
+	if reported := ops(); len(reported) != 0 {
+		t.Errorf("reported ops = %v, want none", reported)
+	}
+}
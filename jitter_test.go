@@ -0,0 +1,75 @@
+// jitter_test.go: Tests for RotationJitter
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotationJitter_DelaysAgeRotation(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "jitter.log"),
+		MaxAgeStr:        "1h",
+		RotationJitter:   30 * time.Minute,
+		DisableAutoScale: true,
+	})
+	clk := newFakeClock()
+	logger.setClock(clk)
+
+	jitter := logger.ageJitter()
+	if jitter < 0 || jitter > 30*time.Minute {
+		t.Fatalf("jitter = %v, want within [0, 30m]", jitter)
+	}
+	if again := logger.ageJitter(); again != jitter {
+		t.Fatalf("jitter changed from %v to %v; want one draw per Logger", jitter, again)
+	}
+
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	clk.Advance(time.Hour + jitter - time.Second)
+	if _, err := logger.Write([]byte("within the jittered age\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().AgeRotations; got != 0 {
+		t.Fatalf("AgeRotations = %d before MaxAge+jitter, want 0", got)
+	}
+
+	clk.Advance(2 * time.Second)
+	if _, err := logger.Write([]byte("aged out\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().AgeRotations; got != 1 {
+		t.Errorf("AgeRotations = %d after MaxAge+jitter, want 1", got)
+	}
+}
+
+func TestRotationJitter_SpreadsInstances(t *testing.T) {
+	dir := t.TempDir()
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 8; i++ {
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:       filepath.Join(dir, "fleet.log"),
+			MaxAgeStr:      "24h",
+			RotationJitter: time.Hour,
+		})
+		seen[logger.ageJitter()] = true
+		_ = logger.Close()
+	}
+	if len(seen) < 2 {
+		t.Errorf("8 instances drew %d distinct offsets, want them spread", len(seen))
+	}
+}
+
+func TestRotationJitter_RejectsNegative(t *testing.T) {
+	err := ValidateConfig(&LoggerConfig{Filename: "app.log", RotationJitter: -time.Second})
+	if err == nil {
+		t.Error("ValidateConfig accepted a negative RotationJitter")
+	}
+}
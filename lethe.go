@@ -7,13 +7,16 @@
 package lethe
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/agilira/go-timecache"
 )
@@ -21,8 +24,13 @@ import (
 // Pre-allocated errors to avoid allocations in hot paths
 var (
 	errNoCurrentFile = errors.New("no current file")
+	newline          = []byte{'\n'}
 )
 
+// ErrClosed is returned by writes made after Close (or CloseContext) has
+// been called. Writes never reopen the file of a closed Logger.
+var ErrClosed = errors.New("lethe: logger is closed")
+
 // Logger provides universal log rotation.
 // It offers zero locks, zero allocations in hot path, and is thread-safe by design.
 // Advanced features include MPSC mode for high-throughput scenarios.
@@ -50,6 +58,8 @@ type Logger struct {
 	// Filename is the log file to write to.
 	// If the file doesn't exist, it is created. If the path doesn't exist,
 	// it is created recursively with appropriate permissions.
+	// The constructors expand {host} and {pid} placeholders (see
+	// ExpandFilename), e.g. "app-{host}.log" on a shared volume.
 	Filename string `json:"filename"`
 
 	// MaxSize is the maximum size in MB before rotation.
@@ -75,18 +85,92 @@ type Logger struct {
 	// False (default) uses UTC. True uses the system's local timezone.
 	LocalTime bool `json:"local_time"`
 
-	// Compress enables gzip compression of rotated files.
-	// Compressed files have a .gz extension added.
+	// TimeZone is an IANA location name (e.g., "America/New_York") used for
+	// backup timestamps and RotateAt boundaries. When set it overrides LocalTime.
+	TimeZone string `json:"time_zone"`
+
+	// Compress enables compression of rotated files with the Compression
+	// codec. Compressed files get the codec's extension (".gz" for gzip).
 	Compress bool `json:"compress"`
 
+	// Compression names the codec used when Compress is true (default:
+	// "gzip"). Other codecs must first be added with RegisterCompressor.
+	Compression string `json:"compression"`
+
+	// CompressMinSize leaves backups smaller than this many bytes
+	// uncompressed, since codec overhead would make them larger (default:
+	// 1024; negative compresses every backup). Checksum, encryption and the
+	// manifest still apply to the plain backup; OnCompress does not fire.
+	CompressMinSize int64 `json:"compress_min_size"`
+
+	// CompressionTiers re-compresses backups as they age, for a hot/warm/
+	// cold lifecycle: after each rotation a background task moves every
+	// backup to the codec of the tier with the largest AfterAge not above
+	// its age (by mtime). For example, with Compress off, tiers
+	// {24h, "gzip"} and {720h, "zstd"} keep a day of plain backups for
+	// grep, gzip them after a day and switch to zstd after 30 days. With
+	// Compress on, tiers only change the codec of compressed backups.
+	// Encrypted backups are left alone. Checksum sidecars and manifest
+	// entries follow the new file, and the mtime is preserved.
+	CompressionTiers []CompressionTier `json:"compression_tiers"`
+
+	// TempDir is where compression writes its in-progress output (e.g., a
+	// fast local scratch disk when logs live on slow or network storage).
+	// The finished file is moved next to the backup, by copy when TempDir
+	// is on another filesystem. The directory must exist; temp files from
+	// an interrupted compression there are not cleaned up on restart.
+	// Empty (default) writes the temp file alongside the backup.
+	TempDir string `json:"temp_dir"`
+
+	// CompressOnClose rotates the final active file during Close and waits
+	// for it to be compressed (with checksum, encryption and retention as
+	// for any backup), so short-lived jobs leave a uniform archive and no
+	// plain Filename behind. Nothing happens if the file is empty. Requires
+	// Compress. With CloseContext, archiving counts toward the deadline: if
+	// ctx expires first, Close returns while archiving finishes in the
+	// background; an interrupted compression leaves the plain backup plus a
+	// .tmp file that is cleaned up on the next start.
+	CompressOnClose bool `json:"compress_on_close"`
+
+	// CompressActive writes the active file itself as a gzip stream, so
+	// large logs never sit uncompressed on disk. Each file and each open
+	// starts a new gzip member (concatenated members decode as one
+	// stream); output is flushed every second, so zcat and zgrep see
+	// everything but the last second while the file is written. Rotation
+	// finishes the member and renames the file straight to a ".gz" backup,
+	// with no compression pass. The tradeoffs: plain grep and tail no
+	// longer work on Filename (use zgrep, zcat), compression costs CPU on
+	// the write path, a crash loses the unflushed tail, and MaxSize counts
+	// uncompressed bytes. Do not switch an existing plain Filename to this
+	// mode, or vice versa, without rotating it first.
+	CompressActive bool `json:"compress_active"`
+
 	// Checksum enables SHA-256 checksum calculation for file integrity.
 	// Checksums are saved as separate files with .sha256 extension.
 	Checksum bool `json:"checksum"`
 
+	// ChecksumInterval keeps a SHA-256 of the active file in Filename +
+	// ".sha256" (sha256sum format), rewritten atomically every interval
+	// when the file grew and once more on Close, so an auditor can detect
+	// tampering with the live log, not only with backups. Only newly
+	// appended bytes are read each interval. The sidecar covers what had
+	// reached the file at the last update; verify it (e.g. with
+	// sha256sum -c) after Close or while the Logger is idle. Independent
+	// of Checksum, which covers rotated backups; 0 disables it.
+	ChecksumInterval time.Duration `json:"checksum_interval"`
+
 	// Async enables MPSC (Multi-Producer Single-Consumer) mode for high-throughput scenarios.
 	// Writes are buffered in a lock-free ring buffer and processed by a dedicated consumer.
 	Async bool `json:"async"`
 
+	// DisableAutoScale keeps a non-Async logger strictly synchronous: Write and
+	// WriteOwned never switch to MPSC under load. Trades peak throughput under
+	// contention for predictable ordering and durability semantics.
+	DisableAutoScale bool `json:"disable_auto_scale"`
+
+	// AutoScale tunes the sync -> MPSC switching thresholds (nil = defaults).
+	AutoScale *AutoScaleConfig `json:"auto_scale,omitempty"`
+
 	// MaxSizeStr is the maximum size as a string (e.g., "100MB", "2GB", "500KB").
 	// This field is preferred over MaxSize for greater flexibility.
 	// Supported formats: B, KB, MB, GB, TB (both 1000 and 1024 based).
@@ -97,22 +181,278 @@ type Logger struct {
 	// Supported formats: ns, us, ms, s, m, h, d, w.
 	MaxAgeStr string `json:"max_age_str"`
 
+	// RotationJitter delays age-based rotation (MaxAge / MaxAgeStr) by a
+	// random amount between 0 and RotationJitter, drawn once per Logger, so
+	// a fleet started together does not rotate, compress and upload in the
+	// same second. Size, line and RotateAt triggers are not affected.
+	// A value of 0 disables jitter.
+	RotationJitter time.Duration `json:"rotation_jitter"`
+
+	// RotationMode combines the size, line, age and RotateWhen limits:
+	// "any" (default) rotates when any enabled limit is reached, "all"
+	// only when every enabled limit is, e.g. MaxSizeStr "100MB" with
+	// MaxAgeStr "1d" rotates files that are both 100MB and a day old, so
+	// a quiet service does not leave a tiny file every day. Limits that are
+	// not set are ignored. RotateAt, Rotate and the other explicit
+	// rotations are not limits and always rotate.
+	RotationMode string `json:"rotation_mode"`
+
+	// MaxLines is the maximum number of lines before rotation.
+	// Lines are counted by newline bytes in each write; a partial line
+	// (no trailing newline) is counted when its newline arrives.
+	// Composes with size and age limits: whichever is reached first rotates.
+	// A value of 0 disables line-based rotation.
+	MaxLines int64 `json:"max_lines"`
+
+	// RotateAt aligns rotation to a wall-clock time of day ("00:00", "23:30:00"),
+	// independent of when the file was created. Boundaries are computed in
+	// TimeZone if set, otherwise local time when LocalTime is true, else UTC. Files with no data
+	// are not rotated at the boundary. Empty disables calendar rotation.
+	RotateAt string `json:"rotate_at"`
+
+	// RotateWhen is an optional custom rotation predicate, consulted after
+	// the size, line and age limits on every write. Returning true rotates
+	// the file, e.g. when a new deployment marker appears. It receives the
+	// active file's size in bytes and the time since it was created.
+	//
+	// It runs on the write path (on the consumer goroutine in async mode),
+	// so it must be fast and must not write to this Logger. Once it returns
+	// true it should return false for the new file until the next rotation
+	// is wanted, or every write will rotate. Panics are recovered, reported
+	// via ErrorCallback and treated as false. Not called when nil.
+	RotateWhen func(currentSize uint64, fileAge time.Duration) bool `json:"-"`
+
+	// RecordBoundary keeps multi-line records (stack traces, pretty-printed
+	// JSON) whole when they are written in several writes. It reports
+	// whether data, about to be written, starts a new record. Once a size,
+	// line, age or RotateWhen limit is reached, rotation waits for the next
+	// write that starts a record and happens just before it, so no record
+	// is split across files; the file may grow past the limit meanwhile.
+	// In async mode each buffered message is checked. Rotate, RotateAt and
+	// Close still rotate immediately. Runs on the write path like
+	// RotateWhen; panics are recovered, reported via ErrorCallback and
+	// treated as true. Nil (default) rotates as soon as a limit is reached.
+	//
+	// Example, for stack traces whose continuation lines are indented:
+	//
+	//	RecordBoundary: func(data []byte) bool {
+	//		return len(data) > 0 && data[0] != ' ' && data[0] != '\t'
+	//	},
+	RecordBoundary func(data []byte) bool `json:"-"`
+
+	// SampleRate keeps roughly this fraction of writes and discards the rest
+	// (0.1 keeps 10%). 0 or 1 keeps everything. Unlike the "drop"
+	// BackpressurePolicy this sheds load proactively, before the buffer
+	// fills. Discarded writes report success and are counted in
+	// Stats.SampledOut.
+	SampleRate float64 `json:"sample_rate"`
+
+	// MaxWritesPerSecond caps accepted writes per wall-clock second
+	// (0 = unlimited). Writes over the cap are discarded like sampled-out
+	// writes. Applied after SampleRate.
+	MaxWritesPerSecond int `json:"max_writes_per_second"`
+
+	// PausePolicy selects what writes do while the Logger is paused (see
+	// Pause): "buffer" (default) holds them in memory until Resume, "error"
+	// rejects them with ErrPaused.
+	PausePolicy string `json:"pause_policy"`
+
+	// Transform rewrites each write before it is buffered or written, e.g.
+	// to redact tokens or card numbers so they never reach disk. It may
+	// return data itself (edited in place or not) or a new slice, of any
+	// length; returning nil drops the write, counted in
+	// Stats.TransformDropped. The caller still sees len(data) bytes
+	// written. With WriteOwned the returned slice is owned by the Logger
+	// like data, so Transform must not keep either. WriteBatch transforms
+	// each chunk. Runs before PreWriteHook, on the write path of the
+	// calling goroutine, so keep it fast: precompile regexps and skip
+	// lines that cannot match with a cheap bytes.Contains first. A panic
+	// fails the write and is reported via ErrorCallback.
+	Transform func(data []byte) []byte `json:"-"`
+
+	// MaxMessageSize caps the size of a single write, checked after
+	// PreWriteHook and before the message reaches the buffer or file
+	// (0 = unlimited). A pathological write, such as an accidentally logged
+	// payload, otherwise lands whole in one over-limit file and, in async
+	// mode, in memory. What happens to oversized writes is set by
+	// OversizePolicy; either way ErrorCallback receives "message_too_large".
+	// A WriteBatch is limited as one message.
+	MaxMessageSize int64 `json:"max_message_size"`
+
+	// OversizePolicy selects what happens to writes over MaxMessageSize:
+	// "reject" (default) fails them with an error wrapping
+	// ErrMessageTooLarge; "truncate" writes the first MaxMessageSize bytes,
+	// ending in a newline if the original did, and reports success.
+	OversizePolicy string `json:"oversize_policy"`
+
+	// Dedup suppresses consecutive identical writes seen within DedupWindow
+	// of each other, like syslog: the first copy is written, repeats are
+	// counted, and a "last message repeated N times" line is written when a
+	// different message arrives, once per DedupWindow while repeats continue,
+	// and on Close. Suppressed writes report success and are counted in
+	// Stats.DedupSuppressed. Comparison is on the bytes after PreWriteHook.
+	// Writers are serialized while Dedup is on, so the summary line always
+	// follows the message it refers to.
+	Dedup bool `json:"dedup"`
+
+	// DedupWindow is the repeat window for Dedup (default: 1s).
+	DedupWindow time.Duration `json:"dedup_window"`
+
+	// EnsureNewline appends '\n' to every write that does not already end
+	// in one, so a caller that forgets the terminator cannot glue two
+	// records into one line. The byte is added after PreWriteHook, before
+	// MaxMessageSize and Dedup see the message, and is counted in the file
+	// size, but not in the count returned to the caller: Write still
+	// returns len(p), as io.Writer expects. Empty writes are left alone.
+	EnsureNewline bool `json:"ensure_newline"`
+
+	// RecentBufferSize, when > 0, keeps copies of the last N writes in
+	// memory, readable with RecentLogs, e.g. from a panic handler to
+	// capture context that never reached the disk. Each write costs one
+	// extra copy, and memory grows with N times the typical message size.
+	RecentBufferSize int `json:"recent_buffer_size"`
+
 	// ErrorCallback is an optional function called when errors occur.
 	// Useful for custom logging or error metrics.
 	// Parameters are the operation that failed and the specific error.
+	// It is read by background goroutines without synchronization, so
+	// set it before the first write; use SetErrorCallback to change it
+	// on a running Logger.
 	ErrorCallback func(operation string, err error) `json:"-"`
 
+	// OnError receives the same reports as ErrorCallback, tagged with the
+	// Logger's Name(), for processes that route several Loggers' errors
+	// into one handler. Either or both may be set. Panics are recovered.
+	OnError func(event ErrorEvent) `json:"-"`
+
 	// OnRotate is called after each successful log file rotation.
 	// WHY: enables forensic audit trails -- downstream systems can record
 	// every rotation in a tamper-evident chain. The callback receives a
 	// RotationEvent with the sealed segment's path, byte count, and
 	// monotonic sequence number. Panics are recovered safely.
+	//
+	// It fires for automatic (size, age, line, calendar) and manual Rotate()
+	// rotations alike, on the rotating goroutine, after the rename succeeds
+	// and before compression/cleanup are scheduled. Keep it fast, or hand
+	// the event off to another goroutine (e.g., to start an upload).
 	OnRotate func(event RotationEvent) `json:"-"`
 
+	// OnCompress is called after a rotated backup has been compressed and the
+	// compressed file atomically renamed into place. ratio is
+	// compressedSize/originalSize (lower is better; 1.0 for empty sources).
+	// Runs on a background worker goroutine. Panics are recovered safely.
+	OnCompress func(srcPath, gzPath string, ratio float64) `json:"-"`
+
+	// OnCleanup is called after retention cleanup removed one or more backup
+	// files, with the removed paths. Runs on a background worker goroutine.
+	// Panics are recovered safely.
+	OnCleanup func(removedPaths []string) `json:"-"`
+
+	// RecreateIfMissing reopens Filename when it is deleted or replaced
+	// externally (e.g., an operator runs rm app.log). Checked at most once
+	// per second from the write path; each recreation is reported as
+	// "file_vanished" via ErrorCallback.
+	RecreateIfMissing bool `json:"recreate_if_missing"`
+
+	// InodeCheckInterval, when > 0, stats Filename at most this often from
+	// the write path and reopens it when the path names a different file
+	// (device or inode changed), e.g. after an external rotator moved
+	// app.log away and created a new one. Each reopen is reported as
+	// "inode_changed" via ErrorCallback. A missing path is left to
+	// RecreateIfMissing, which then also checks at this interval.
+	// Off by default: every check costs two stat calls.
+	InodeCheckInterval time.Duration `json:"inode_check_interval"`
+
+	// FallbackFilename receives writes while Filename is unwritable (e.g.,
+	// permissions changed or the volume went away). A write that fails is
+	// retried on a fresh handle to Filename (RetryCount/RetryDelay); if
+	// that fails too, the Logger switches to FallbackFilename, reports
+	// "failover" via ErrorCallback and calls OnFailover. Every 5 seconds a
+	// write tries Filename again and switches back once it opens. The
+	// fallback is not rotated, and its contents stay where they are after
+	// failing back. Empty disables failover.
+	FallbackFilename string `json:"fallback_filename"`
+
+	// OnFailover is called on the writing goroutine when writes move to
+	// FallbackFilename or back to Filename. Panics are recovered safely.
+	OnFailover func(event FailoverEvent) `json:"-"`
+
+	// Encryptor, when set, encrypts rotated backups at rest (e.g., with
+	// NewAESGCMEncryptor), producing ".enc" files after compression.
+	// Encryption runs on the background worker pool and never blocks writes.
+	// Read backups back with OpenBackup.
+	Encryptor Encryptor `json:"-"`
+
+	// Tee, when set, receives a copy of every write after it reaches the log
+	// file (see AddTee for more targets). Tee errors are reported as
+	// "tee_write" via ErrorCallback and never fail the primary write.
+	//
+	// Ordering: tees are fed at flush time, not enqueue time. In async mode
+	// the single consumer writes each flushed batch to the file and then to
+	// the tees, so tees see exactly the file's byte order. In sync mode each
+	// Write is forwarded whole, but concurrent writers may reach the tees in
+	// a different order than the file. Calls to tee writers are serialized,
+	// so they need not be safe for concurrent use.
+	//
+	// Tees are synchronous: a slow tee slows the writer (sync) or the
+	// consumer (async), and a hung one stalls all logging. Wrap a sink
+	// that may block (e.g., a network connection) in a writer that queues
+	// and drops. A panic in a tee is recovered and reported as "tee_panic".
+	Tee io.Writer `json:"-"`
+
+	// Manifest maintains Filename + ".manifest", a JSON-lines inventory with
+	// one BackupInfo per backup (path, size, modtime, SHA-256, compressed),
+	// appended by a background worker once the backup reaches its final
+	// form and pruned when retention cleanup deletes it. Read it back with
+	// ReadManifest. Independent of Checksum, which writes .sha256 sidecars.
+	Manifest bool `json:"manifest"`
+
+	// PersistState saves the rotation sequence to a sidecar (Filename + ".state")
+	// after each rotation and restores it on startup, so sequence numbers and
+	// RotationCount continue monotonically across restarts. A missing or
+	// corrupt sidecar falls back to the number of existing backups.
+	PersistState bool `json:"persist_state"`
+
+	// MultiProcess serializes rotation across processes writing the same
+	// Filename (e.g., an app and a sidecar) with an advisory lock on
+	// Filename + ".lock" (flock on Unix, LockFileEx on Windows). A process
+	// that finds the file already rotated by a peer reopens it instead of
+	// rotating again. The lock is advisory and best-effort: it only
+	// coordinates processes that also use it, and is a no-op on platforms
+	// without file locking.
+	MultiProcess bool `json:"multi_process"`
+
+	// Preallocate reserves MaxSize bytes of disk space for each new active
+	// file with fallocate(FALLOC_FL_KEEP_SIZE), so appends do not allocate
+	// blocks one at a time and the file stays contiguous on disk. The file
+	// size is unchanged, so readers and size-based rotation see only what
+	// was written; the unused reservation is released when the file is
+	// rotated. Linux only (a no-op elsewhere and on filesystems without
+	// fallocate); requires a size limit and cannot be combined with
+	// MultiProcess.
+	Preallocate bool `json:"preallocate"`
+
+	// Symlink is an optional stable path (e.g., "current.log") that always
+	// points to the active log file. Relative paths are resolved against the
+	// directory of Filename. The link is repointed atomically after each
+	// rotation and removed on Close if Lethe created it.
+	// Not supported on Windows: a "symlink_unsupported" error is reported instead.
+	Symlink string `json:"symlink"`
+
 	// FileMode is the file permissions (default: 0644).
 	// Used when creating new log files.
 	FileMode os.FileMode `json:"file_mode"`
 
+	// BackupFileMode is the permissions of rotated backups, compressed
+	// backups and checksum sidecars (default: FileMode). Lets archives be
+	// stricter (e.g., 0600) than a group-readable active file.
+	BackupFileMode os.FileMode `json:"backup_file_mode"`
+
+	// DirMode is the permissions of log directories created for Filename
+	// (default: 0750). Use 0755 when a non-owner (e.g., a monitoring agent)
+	// must traverse into the directory. Existing directories are not changed.
+	DirMode os.FileMode `json:"dir_mode"`
+
 	// RetryCount is the number of retries for file operations (default: 3).
 	// Useful for handling temporary filesystem errors.
 	RetryCount int `json:"retry_count"`
@@ -121,15 +461,74 @@ type Logger struct {
 	// Wait time before retrying a failed operation.
 	RetryDelay time.Duration `json:"retry_delay"`
 
-	// BufferSize is the size of the MPSC ring buffer (default: 1024, must be power of 2).
+	// RetryMaxDelay enables exponential backoff: the delay after each
+	// failed attempt doubles, starting at RetryDelay, up to RetryMaxDelay.
+	// Zero keeps the delay fixed at RetryDelay.
+	RetryMaxDelay time.Duration `json:"retry_max_delay"`
+
+	// RetryJitter randomizes each retry delay between half and all of its
+	// value, so processes sharing a flaky mount do not retry in lockstep.
+	RetryJitter bool `json:"retry_jitter"`
+
+	// BackgroundWorkers is the number of goroutines running compression,
+	// checksum, encryption and cleanup tasks after rotation (default: 2).
+	// Raise it when many large backups are compressed and the task queue
+	// (Stats.TaskQueueDepth) keeps growing. Tasks submitted while the queue
+	// is full are dropped and reported as "task_dropped" via ErrorCallback.
+	BackgroundWorkers int `json:"background_workers"`
+
+	// MaxCompressions caps how many backups are compressed at once,
+	// including CompressionTiers re-compression (default: BackgroundWorkers,
+	// i.e. no extra limit). Set it to 1 so a burst of rotations cannot
+	// keep several cores busy compressing while latency-sensitive work
+	// waits; other background tasks keep running meanwhile.
+	// Stats.ActiveCompressions reports the compressions in progress.
+	MaxCompressions int `json:"max_compressions"`
+
+	// BufferSize is the size of the MPSC ring buffer in slots (default: 1024).
 	// Used only when Async is true. Larger sizes improve throughput
-	// but increase memory usage.
+	// but increase memory usage. It is rounded up to a power of 2 and to
+	// at least MinBufferSize; when that changes it, ErrorCallback receives
+	// "buffer_size_adjusted" once. Stats.BufferSize reports the result.
 	BufferSize int `json:"buffer_size"`
 
+	// MinBufferSize is the smallest ring BufferSize is rounded up to
+	// (default: 64). Lower it, down to 1, for tests that need backpressure
+	// after a handful of writes; small rings hurt throughput.
+	MinBufferSize int `json:"min_buffer_size"`
+
+	// ArenaSlotSize, when > 0, stores async messages inline in a contiguous
+	// byte arena of fixed cells of this many bytes instead of the pointer
+	// ring, so enqueueing and draining allocate nothing per message. The
+	// arena takes ring slots x ArenaSlotSize bytes up front. Messages larger
+	// than a cell take the BackpressurePolicy path as if the ring were full.
+	// Size it to cover typical records (e.g. 512 or 1024); 0 keeps the
+	// pointer ring.
+	ArenaSlotSize int `json:"arena_slot_size"`
+
+	// MaxBufferBytes caps the total bytes enqueued in the MPSC buffer,
+	// independent of slot count (0 = no byte limit). When a push would exceed
+	// it, BackpressurePolicy applies as if the buffer were full. Protects
+	// against OOM when a few messages are very large.
+	MaxBufferBytes int64 `json:"max_buffer_bytes"`
+
 	// BackpressurePolicy defines behavior when the buffer is full.
-	// Options: "fallback" (default, fall back to sync), "drop" (discard messages), "adaptive" (resize buffer).
+	// Options: "fallback" (default, fall back to sync), "drop" (discard messages), "adaptive" (resize buffer),
+	// "overflow" (spill to Filename + ".spill" and replay once the ring drains).
+	// "adaptive" doubles the ring up to 16384 slots; messages queued in the
+	// old ring are written before any in the new one, none are dropped.
+	// Beyond the maximum it falls back to sync, which may write a message
+	// ahead of queued ones.
+	// Constructors reject any other value; the empty string selects "fallback".
 	BackpressurePolicy string `json:"backpressure_policy"`
 
+	// MaxSpillBytes caps the spill file of the "overflow" policy (default:
+	// 64MB). Messages that would exceed it are dropped and counted in
+	// DroppedOnFull. While spilled messages wait, new writes queue behind
+	// them, so order is kept. The spill file is removed on Close; like the
+	// ring buffer, it does not survive a crash.
+	MaxSpillBytes int64 `json:"max_spill_bytes"`
+
 	// FlushInterval is the flush interval for the MPSC consumer (default: 1ms).
 	// Lower frequencies reduce latency but increase CPU overhead.
 	FlushInterval time.Duration `json:"flush_interval"`
@@ -138,15 +537,70 @@ type Logger struct {
 	// The consumer automatically adapts to write velocity to optimize performance.
 	AdaptiveFlush bool `json:"adaptive_flush"`
 
+	// ConsumerBatchSize is the maximum number of buffered messages the MPSC
+	// consumer coalesces into a single write syscall (default: 64).
+	// Set to 1 to write each message individually.
+	ConsumerBatchSize int `json:"consumer_batch_size"`
+
+	// StallTimeout reports a stuck MPSC consumer (e.g., a hung disk): when
+	// messages stay buffered and the consumer makes no progress for this
+	// long, ErrorCallback receives "consumer_stalled" with the stall
+	// duration, once per stall, and Health turns unhealthy until the
+	// consumer moves again. Not checked while paused. 0 disables the check.
+	StallTimeout time.Duration `json:"stall_timeout"`
+
+	// IdleTimeout parks the MPSC consumer after this long without writes:
+	// its goroutine exits and the next write starts a new one, so idle
+	// Loggers cost no goroutine or timer. The first write after parking
+	// pays a goroutine start (microseconds). 0 keeps the consumer running.
+	IdleTimeout time.Duration `json:"idle_timeout"`
+
+	// SyncOnWrite calls fsync after every write (after every flushed batch in
+	// async mode). Maximum durability at a large throughput cost: each write
+	// waits for the device, typically milliseconds on spinning disks.
+	SyncOnWrite bool `json:"sync_on_write"`
+
+	// SyncInterval fsyncs the active file periodically when data was written
+	// since the last fsync. Bounds the crash-loss window to roughly one interval
+	// while keeping writes fast. A value of 0 disables periodic fsync.
+	SyncInterval time.Duration `json:"sync_interval"`
+
+	// BufferedSync collects sync-mode writes in memory and writes them out
+	// in one syscall when the buffer fills, every FlushInterval, on Sync,
+	// before rotation and on Close. Cuts syscalls for chatty loggers that
+	// write from one goroutine and don't want Async; a crash loses what is
+	// still buffered. Disables auto-scaling. Cannot be combined with Async,
+	// SyncOnWrite or MultiProcess.
+	BufferedSync bool `json:"buffered_sync"`
+
+	// SyncBufferSize is the BufferedSync buffer size in bytes (default: 64KB).
+	SyncBufferSize int `json:"sync_buffer_size"`
+
 	// Thread-safe adaptive flush for hot reload (minimal race condition fix)
 	adaptiveFlushAtomic atomic.Bool
 
 	// Internal state (all atomic - ZERO LOCKS!)
 	currentFile  atomic.Pointer[os.File] // Current log file
-	bytesWritten atomic.Uint64           // Total bytes written
+	currentPath  atomic.Pointer[string]  // Sanitized Filename, published once the file is open
+	bytesWritten atomic.Uint64           // Size of the active file (reset on rotation)
+	totalWritten atomic.Uint64           // Bytes written by this Logger across all files (never reset)
 	rotationSeq  atomic.Uint64           // Rotation sequence number
 	rotationFlag atomic.Bool             // Rotation in progress flag
 	fileCreated  atomic.Int64            // Unix timestamp when current file was created
+	lineCount    atomic.Int64            // Newlines written to the current file (for MaxLines)
+	preallocated atomic.Int64            // Bytes reserved for the current file by Preallocate
+
+	// Completed rotations by trigger (Stats.SizeRotations, AgeRotations, ...)
+	sizeRotations     atomic.Uint64
+	ageRotations      atomic.Uint64
+	calendarRotations atomic.Uint64
+	lineRotations     atomic.Uint64
+	manualRotations   atomic.Uint64
+	customRotations   atomic.Uint64
+
+	// Compression totals (Stats.CompressedBytes / UncompressedBytes)
+	compressedBytes   atomic.Uint64
+	uncompressedBytes atomic.Uint64
 
 	// MPSC buffer state (lock-free)
 	buffer   atomic.Pointer[ringBuffer]   // Ring buffer for async writes
@@ -158,22 +612,80 @@ type Logger struct {
 	totalLatency    atomic.Uint64 // Total latency in nanoseconds
 	lastLatency     atomic.Uint64 // Last write latency in nanoseconds
 	droppedCount    atomic.Uint64 // Messages dropped due to full buffer
+	healthDropped   atomic.Uint64 // droppedCount at the previous Health call
+	droppedTasks    atomic.Uint64 // Background tasks dropped due to full task queue
+	sampledOut      atomic.Uint64 // Writes discarded by SampleRate / MaxWritesPerSecond
+
+	// Drop breakdown (see countDrop)
+	droppedFull        atomic.Uint64 // "drop" policy on a full buffer
+	droppedOverflow    atomic.Uint64 // "overflow" policy beyond MaxSpillBytes
+	droppedSampled     atomic.Uint64 // SampleRate
+	droppedRateLimited atomic.Uint64 // MaxWritesPerSecond
+	droppedBytes       atomic.Uint64 // Bytes of every write counted above
+	manifestMu         sync.Mutex    // Serializes manifest appends and rewrites
+	tierMu             sync.Mutex    // Held by the running CompressionTiers pass
+
+	// Pause state (see pause.go): paused stops the consumer, holdWrites
+	// diverts writes to held; pauseMu guards held/heldBytes and transitions
+	pauseMu    sync.Mutex
+	paused     atomic.Bool
+	holdWrites atomic.Bool
+	fsInFlight atomic.Int64 // Operations past the pause gate
+	resumeCh   atomic.Pointer[chan struct{}]
+	held       [][]byte
+	heldBytes  int64
+
+	// Dedup state: dedupMu guards dedupLast/dedupSeen and summary writes;
+	// dedupWriting is read-held while a new message is dispatched;
+	// dedupRepeats is the pending (unsummarized) count
+	dedupMu         sync.Mutex
+	dedupWriting    sync.RWMutex
+	dedupLast       []byte
+	dedupSeen       time.Time
+	dedupRepeats    atomic.Uint64
+	dedupSuppressed atomic.Uint64
+	dedupLoop       atomic.Pointer[backgroundLoop]
+
+	// Writes dropped by Transform returning nil
+	transformDropped atomic.Uint64
+
+	// Tee targets added with AddTee (copy-on-write); teeMu serializes tee writes
+	extraTees atomic.Pointer[[]io.Writer]
+	teeMu     sync.Mutex
+
+	// MaxWritesPerSecond window state (see admitWrite)
+	rateWindow atomic.Int64 // Unix second of the current window
+	rateCount  atomic.Int64 // Writes admitted in the current window
 
 	// Background worker pool
 	bgWorkers atomic.Pointer[BackgroundWorkers] // Worker pool for cleanup/compression
 
+	// MaxCompressions semaphore and its holders, see compresslimit.go
+	compressSem        atomic.Pointer[chan struct{}]
+	activeCompressions atomic.Int64
+
 	// High-performance time cache for reduced allocation overhead
 	timeCache     *timecache.TimeCache
+	clock         clock     // Test hook (see setClock); nil uses timeCache
 	timeCacheOnce sync.Once // guards lazy init of timeCache; all writers go through this
 
 	// File initialization protection
 	initMutex sync.Mutex
 
 	// Close protection
-	closeOnce sync.Once
+	closeOnce      sync.Once
+	closed         atomic.Bool // Set by Close once held writes are replayed; later writes fail with ErrClosed
+	drainAbandoned atomic.Bool // Set when CloseContext times out; the consumer stops writing
 
 	// Config cache (parsed once)
-	maxSizeBytes atomic.Int64 // MaxSize * MB in bytes (atomic: read by Stats() concurrent with shouldRotate() writes)
+	maxSizeBytes atomic.Int64 // MaxSize * MB in bytes (atomic: read by Stats() concurrent with shouldRotate() writes); -1 = disabled by SetMaxSize
+
+	// MaxAge set at runtime by SetMaxAge, in nanoseconds (0 = use MaxAge/MaxAgeStr, -1 = disabled)
+	maxAgeOverride atomic.Int64
+
+	// Set by SetErrorCallback; overrides ErrorCallback once non-nil (the
+	// func it points to may be nil, disabling the callback)
+	errorCallback atomic.Pointer[func(operation string, err error)]
 
 	// Pre-write hook for data transformation (set via LoggerConfig)
 	preWriteHook func(data []byte) ([]byte, error)
@@ -188,9 +700,92 @@ type Logger struct {
 	lastWriteTime atomic.Int64 // Unix nano of last write
 	lastDropTime  atomic.Int64 // Unix nano of last drop
 
+	// Failed writes, flushes and fsyncs of the log file, see writeerrors.go
+	writeErrors        atomic.Uint64
+	lastWriteErrorTime atomic.Int64 // Unix nano
+
 	// WHY atomic.Pointer: ReconfigureRetention must be safe under concurrent
 	// writes. Swapping a pointer is a single atomic op; no lock on the hot path.
 	retention atomic.Pointer[RetentionPolicy]
+
+	// Resolved TimeZone (loaded once, see location())
+	timeZone     *time.Location
+	timeZoneOnce sync.Once
+
+	// Logical name from LoggerConfig.Name (see Name())
+	name string
+
+	// This Logger's share of RotationJitter (drawn once, see ageJitter())
+	ageJitterValue time.Duration
+	ageJitterOnce  sync.Once
+
+	// Calendar-aligned rotation goroutine (started lazily in initFile)
+	scheduler atomic.Pointer[backgroundLoop]
+
+	// Live checksum of the active file (ChecksumInterval)
+	activeSum     activeChecksum
+	activeSumLoop atomic.Pointer[backgroundLoop]
+
+	// Durability state (SyncOnWrite / SyncInterval)
+	syncLoop     atomic.Pointer[backgroundLoop] // Periodic fsync goroutine
+	syncBuf      atomic.Pointer[syncBuffer]     // BufferedSync write buffer
+	syncBufLoop  atomic.Pointer[backgroundLoop] // Periodic BufferedSync flush
+	activeGz     atomic.Pointer[activeGzip]     // CompressActive gzip stream
+	activeGzLoop atomic.Pointer[backgroundLoop] // Periodic CompressActive flush
+	fsyncCount   atomic.Uint64                  // Successful fsync calls
+	lastSyncNano atomic.Int64                   // Unix nano of last fsync
+	syncDirty    atomic.Bool                    // Data written since last fsync
+
+	// Unix nano of the last RecreateIfMissing/InodeCheckInterval path check
+	lastFileCheck atomic.Int64
+
+	// FallbackFilename state (see failover.go)
+	failedOver        atomic.Bool  // Writes go to FallbackFilename
+	lastFailbackCheck atomic.Int64 // Unix nano of the last attempt to return to Filename
+
+	// Bytes currently enqueued in the MPSC buffer (for MaxBufferBytes)
+	bufferedBytes atomic.Int64
+
+	// rotationReason reached while waiting for RecordBoundary, see boundary.go
+	deferredRotation atomic.Uint32
+
+	// On-disk overflow of the ring ("overflow" policy), see spill.go
+	spill        atomic.Pointer[spillQueue]
+	spilledCount atomic.Uint64
+
+	// Last RecentBufferSize writes, see recent.go
+	recent atomic.Pointer[recentRing]
+
+	// Messages the MPSC consumer wrote, and the drain report of Close
+	// derived from it, see shutdown.go
+	consumedCount   atomic.Uint64
+	shutdownPending atomic.Uint64
+	shutdownFlushed atomic.Uint64
+	shutdownLost    atomic.Uint64
+	shutdownDrainNs atomic.Uint64
+
+	// Set once an unknown BackpressurePolicy has been reported
+	policyReported atomic.Bool
+
+	// Auto-scaling state (sync <-> MPSC), see scale.go
+	autoScaled          atomic.Bool                    // MPSC engaged by auto-scaling (not Async)
+	scaleDrain          atomic.Pointer[scaleDrain]     // Downscale in progress; writers wait
+	asyncInFlight       atomic.Int64                   // Auto-scaled writers not yet pushed
+	scaleUps            atomic.Uint64                  // sync -> MPSC transitions
+	scaleDowns          atomic.Uint64                  // MPSC -> sync transitions
+	scaleMonitor        atomic.Pointer[backgroundLoop] // Downscale monitor goroutine
+	scaleBaseWrites     atomic.Uint64                  // writeCount at last downscale
+	scaleBaseContention atomic.Uint64                  // contentionCount at last downscale
+	scaleBaseLatency    atomic.Uint64                  // totalLatency at last downscale
+	scaleCheckInterval  time.Duration                  // Sample window (0 = default)
+
+	// symlinkOwned records that Lethe created (or took over) the Symlink,
+	// so Close only removes links it is responsible for.
+	symlinkOwned atomic.Bool
+
+	// fs performs rotation renames (nil = DefaultFileSystem); replaced in
+	// tests to simulate filesystem failures.
+	fs FileSystem
 }
 
 // New creates a new Logger with safe defaults and validates configuration.
@@ -214,7 +809,7 @@ func New(filename string, maxSizeMB int, maxBackups int) (*Logger, error) {
 	}
 
 	logger := &Logger{
-		Filename:   filename,
+		Filename:   ExpandFilename(filename),
 		MaxBackups: maxBackups,
 
 		// Safe defaults
@@ -242,7 +837,8 @@ func New(filename string, maxSizeMB int, maxBackups int) (*Logger, error) {
 //
 // Parameters:
 //   - filename: Path to the log file (required)
-//   - maxSize: Maximum file size as string (e.g., "100MB", "1GB")
+//   - maxSize: Maximum file size as string (e.g., "100MB", "1GB"); an
+//     unparsable size is an error (see ParseSize)
 //   - maxBackups: Number of backup files to keep (0 = keep all)
 //
 // Features enabled by default:
@@ -261,9 +857,16 @@ func NewSimple(filename, maxSize string, maxBackups int) (*Logger, error) {
 	if filename == "" {
 		return nil, errors.New("filename cannot be empty")
 	}
+	// Fail now: a bad size would otherwise only reach ErrorCallback at the
+	// first write, leaving the file to grow without bound
+	if maxSize != "" {
+		if _, err := ParseSize(maxSize); err != nil {
+			return nil, fmt.Errorf("invalid maxSize: %w", err)
+		}
+	}
 
 	logger := &Logger{
-		Filename:   filename,
+		Filename:   ExpandFilename(filename),
 		MaxSizeStr: maxSize,
 		MaxBackups: maxBackups,
 
@@ -316,7 +919,13 @@ func NewWithDefaults(filename string) (*Logger, error) {
 		return nil, errors.New("filename cannot be empty")
 	}
 
-	config := &LoggerConfig{
+	return NewWithConfig(defaultConfig(filename))
+}
+
+// defaultConfig returns the production configuration used by NewWithDefaults
+// and as the base for NewFromEnv.
+func defaultConfig(filename string) *LoggerConfig {
+	return &LoggerConfig{
 		Filename:           filename,
 		MaxSizeStr:         "100MB",
 		MaxAgeStr:          "7d",
@@ -326,8 +935,6 @@ func NewWithDefaults(filename string) (*Logger, error) {
 		BackpressurePolicy: "adaptive",
 		LocalTime:          true,
 	}
-
-	return NewWithConfig(config)
 }
 
 // NewDaily creates a Logger that rotates daily.
@@ -400,6 +1007,42 @@ func NewWeekly(filename string) (*Logger, error) {
 	return NewWithConfig(config)
 }
 
+// NewMonthly creates a Logger that rotates monthly.
+// Suited to low-volume logs kept for a long time, such as audit trails.
+//
+// Configuration optimized for monthly rotation:
+//   - MaxSizeStr: "500MB" (room for a month of accumulation)
+//   - MaxAgeStr: "30d" (rotates every 30 days; RotateAt only aligns to a
+//     time of day, so rotation is not pinned to calendar months)
+//   - MaxBackups: 12 (keeps one year of monthly logs)
+//   - Compress: true (essential for larger files)
+//   - Async: true (better performance)
+//   - LocalTime: true (monthly rotation aligned with local timezone)
+//
+// Parameters:
+//   - filename: Path to the log file (required)
+//
+// Example:
+//
+//	logger, err := lethe.NewMonthly("audit.log")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer logger.Close()
+func NewMonthly(filename string) (*Logger, error) {
+	config := &LoggerConfig{
+		Filename:           filename,
+		MaxSizeStr:         "500MB", // Room for a month of logs
+		MaxAgeStr:          "30d",   // Rotate roughly monthly
+		MaxBackups:         12,      // Keep a year of logs
+		Compress:           true,
+		Async:              true,
+		BackpressurePolicy: "adaptive",
+		LocalTime:          true,
+	}
+	return NewWithConfig(config)
+}
+
 // NewDevelopment creates a Logger optimized for development and debugging.
 // Designed for immediate visibility of logs with frequent rotation and no compression.
 //
@@ -474,11 +1117,17 @@ func NewDevelopment(filename string) (*Logger, error) {
 //	}
 //	defer logger.Close()
 func NewWithConfig(config *LoggerConfig) (*Logger, error) {
-	if config == nil {
-		return nil, errors.New("config cannot be nil")
+	// Expand {host}/{pid} once, so the name stays fixed for the Logger's
+	// lifetime; the caller's config is left untouched
+	if config != nil {
+		if filename := ExpandFilename(config.Filename); filename != config.Filename {
+			expanded := *config
+			expanded.Filename = filename
+			config = &expanded
+		}
 	}
-	if config.Filename == "" {
-		return nil, errors.New("filename cannot be empty")
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
 	}
 
 	logger := &Logger{
@@ -487,22 +1136,85 @@ func NewWithConfig(config *LoggerConfig) (*Logger, error) {
 		MaxBackups:         config.MaxBackups,
 		MaxAge:             config.MaxAge,
 		MaxFileAge:         config.MaxFileAge,
+		RotationJitter:     config.RotationJitter,
+		RotationMode:       config.RotationMode,
 		LocalTime:          config.LocalTime,
+		TimeZone:           config.TimeZone,
 		Compress:           config.Compress,
+		Compression:        config.Compression,
+		CompressOnClose:    config.CompressOnClose,
+		CompressActive:     config.CompressActive,
+		CompressMinSize:    config.CompressMinSize,
+		CompressionTiers:   append([]CompressionTier(nil), config.CompressionTiers...),
+		TempDir:            config.TempDir,
+		Manifest:           config.Manifest,
 		Checksum:           config.Checksum,
+		ChecksumInterval:   config.ChecksumInterval,
 		Async:              config.Async,
 		MaxSizeStr:         config.MaxSizeStr,
 		MaxAgeStr:          config.MaxAgeStr,
+		MaxLines:           config.MaxLines,
+		RotateAt:           config.RotateAt,
 		ErrorCallback:      config.ErrorCallback,
 		BackpressurePolicy: config.BackpressurePolicy,
 		AdaptiveFlush:      config.AdaptiveFlush,
 		FileMode:           config.FileMode,
+		BackupFileMode:     config.BackupFileMode,
+		DirMode:            config.DirMode,
+		MultiProcess:       config.MultiProcess,
+		Preallocate:        config.Preallocate,
+		SampleRate:         config.SampleRate,
+		MaxWritesPerSecond: config.MaxWritesPerSecond,
+		Dedup:              config.Dedup,
+		EnsureNewline:      config.EnsureNewline,
+		PausePolicy:        config.PausePolicy,
+		MaxMessageSize:     config.MaxMessageSize,
+		OversizePolicy:     config.OversizePolicy,
+		DedupWindow:        config.DedupWindow,
 		RetryCount:         config.RetryCount,
 		RetryDelay:         config.RetryDelay,
+		RetryMaxDelay:      config.RetryMaxDelay,
+		RetryJitter:        config.RetryJitter,
 		BufferSize:         config.BufferSize,
+		MinBufferSize:      config.MinBufferSize,
+		ArenaSlotSize:      config.ArenaSlotSize,
 		FlushInterval:      config.FlushInterval,
+		SyncOnWrite:        config.SyncOnWrite,
+		SyncInterval:       config.SyncInterval,
+		BufferedSync:       config.BufferedSync,
+		SyncBufferSize:     config.SyncBufferSize,
 		preWriteHook:       config.PreWriteHook,
+		Transform:          config.Transform,
+		RecentBufferSize:   config.RecentBufferSize,
+		RotateWhen:         config.RotateWhen,
+		RecordBoundary:     config.RecordBoundary,
 		OnRotate:           config.OnRotate,
+		OnError:            config.OnError,
+		name:               loggerName(config),
+		OnCompress:         config.OnCompress,
+		OnCleanup:          config.OnCleanup,
+		Symlink:            config.Symlink,
+		PersistState:       config.PersistState,
+		RecreateIfMissing:  config.RecreateIfMissing,
+		InodeCheckInterval: config.InodeCheckInterval,
+		FallbackFilename:   config.FallbackFilename,
+		OnFailover:         config.OnFailover,
+		ConsumerBatchSize:  config.ConsumerBatchSize,
+		StallTimeout:       config.StallTimeout,
+		IdleTimeout:        config.IdleTimeout,
+		BackgroundWorkers:  config.BackgroundWorkers,
+		MaxCompressions:    config.MaxCompressions,
+		MaxBufferBytes:     config.MaxBufferBytes,
+		MaxSpillBytes:      config.MaxSpillBytes,
+		Encryptor:          config.Encryptor,
+		Tee:                config.Tee,
+		DisableAutoScale:   config.DisableAutoScale,
+	}
+
+	// Copy thresholds so later edits to the caller's config have no effect
+	if config.AutoScale != nil {
+		thresholds := *config.AutoScale
+		logger.AutoScale = &thresholds
 	}
 
 	// Apply safe defaults for unset values
@@ -525,12 +1237,7 @@ func NewWithConfig(config *LoggerConfig) (*Logger, error) {
 		logger.FlushInterval = 1 * time.Millisecond
 	}
 
-	// Parse string-based configurations
-	// Validate that both MaxAge and MaxAgeStr are not specified simultaneously
-	if logger.MaxAge > 0 && logger.MaxAgeStr != "" {
-		return nil, fmt.Errorf("cannot specify both MaxAge and MaxAgeStr; use MaxAgeStr for string-based configuration")
-	}
-
+	// Parse string-based configurations (already validated by ValidateConfig)
 	if logger.MaxAgeStr != "" {
 		duration, err := ParseDuration(logger.MaxAgeStr)
 		if err != nil {
@@ -539,6 +1246,16 @@ func NewWithConfig(config *LoggerConfig) (*Logger, error) {
 		logger.MaxAge = duration
 	}
 
+	if logger.TimeZone != "" {
+		loc, err := time.LoadLocation(logger.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TimeZone %q: %w", logger.TimeZone, err)
+		}
+		logger.timeZone = loc
+		logger.timeZoneOnce.Do(func() {}) // Already resolved
+	}
+	logger.ageJitter() // Draw the offset now, not on the first age check
+
 	// Initialize time cache for performance
 	logger.timeCache = timecache.NewWithResolution(time.Millisecond)
 
@@ -576,10 +1293,15 @@ type RotationEvent struct {
 	// Timestamp is when the rotation completed
 	Timestamp time.Time
 
-	// PreviousFile is the path to the sealed (rotated) log segment
+	// PreviousFile is the path to the sealed (rotated) log segment.
+	// This is always the uncompressed name: when Compress is enabled the
+	// compressed file does not exist yet at callback time, and PreviousFile
+	// is later replaced by PreviousFile + ".gz" (or the Compression codec's
+	// extension) on a background worker.
 	PreviousFile string
 
 	// NewFile is the path to the newly created active log file
+	// ("" for the final rotation performed by CompressOnClose)
 	NewFile string
 
 	// Sequence is the monotonic rotation counter (starts at 1)
@@ -587,13 +1309,20 @@ type RotationEvent struct {
 
 	// BytesWritten is the total bytes written to the sealed segment
 	BytesWritten uint64
+
+	// Reason is what triggered the rotation: "size" (MaxSize), "lines"
+	// (MaxLines), "age" (MaxAge), "calendar" (RotateAt), "manual" (Rotate,
+	// RotateSync, RotateNamed), "custom" (RotateWhen) or "close"
+	// (CompressOnClose)
+	Reason string
 }
 
 // LoggerConfig holds configuration options for creating a Logger.
 // This struct provides a clear, documented way to configure all Logger options.
 type LoggerConfig struct {
 	// Basic configuration
-	Filename   string `json:"filename"`
+	Filename   string `json:"filename"` // {host} and {pid} are expanded (see ExpandFilename)
+	Name       string `json:"name"`     // Logical name for registries and metrics; default: base of Filename
 	MaxSize    int64  `json:"max_size"`
 	MaxBackups int    `json:"max_backups"`
 
@@ -601,18 +1330,74 @@ type LoggerConfig struct {
 	MaxSizeStr string `json:"max_size_str"`
 	MaxAgeStr  string `json:"max_age_str"`
 
+	// Line-based rotation
+	MaxLines int64 `json:"max_lines"`
+
+	// How limits combine: "any" (default) or "all" (see Logger.RotationMode)
+	RotationMode string `json:"rotation_mode"`
+
+	// Calendar-aligned rotation (time of day, e.g. "00:00")
+	RotateAt string `json:"rotate_at"`
+
+	// Custom rotation predicate, consulted on every write (see Logger.RotateWhen)
+	RotateWhen func(currentSize uint64, fileAge time.Duration) bool `json:"-"`
+
+	// Defers threshold rotation until a write starts a record (see Logger.RecordBoundary)
+	RecordBoundary func(data []byte) bool `json:"-"`
+
 	// Time-based rotation
-	MaxAge     time.Duration `json:"max_age"`
-	MaxFileAge time.Duration `json:"max_file_age"`
-	LocalTime  bool          `json:"local_time"`
+	MaxAge         time.Duration `json:"max_age"`
+	MaxFileAge     time.Duration `json:"max_file_age"`
+	LocalTime      bool          `json:"local_time"`
+	TimeZone       string        `json:"time_zone"`       // IANA zone name; overrides LocalTime
+	RotationJitter time.Duration `json:"rotation_jitter"` // Random extra MaxAge per instance, up to this
 
 	// Features
-	Compress bool `json:"compress"`
-	Checksum bool `json:"checksum"`
-	Async    bool `json:"async"`
+	Compress        bool   `json:"compress"`
+	Compression     string `json:"compression"`       // Codec name; default "gzip"
+	CompressOnClose bool   `json:"compress_on_close"` // Archive the final file on Close
+	CompressMinSize int64  `json:"compress_min_size"` // Skip smaller backups; default 1024, <0 = none
+	CompressActive  bool   `json:"compress_active"`   // Write the active file as a gzip stream
+	TempDir         string `json:"temp_dir"`          // Scratch directory for compression; default alongside the backup
+	Checksum        bool   `json:"checksum"`
+	Async           bool   `json:"async"`
+
+	// Age-based re-compression of backups (see Logger.CompressionTiers)
+	CompressionTiers []CompressionTier `json:"compression_tiers"`
+
+	// Live SHA-256 sidecar of the active file, refreshed this often; 0 = off
+	ChecksumInterval time.Duration `json:"checksum_interval"`
+
+	// Load shedding: keep a fraction of writes and/or cap writes per second
+	SampleRate         float64 `json:"sample_rate"`
+	MaxWritesPerSecond int     `json:"max_writes_per_second"`
+
+	// Behavior of writes during Pause: "buffer" (default) or "error"
+	PausePolicy string `json:"pause_policy"`
+
+	// Single-write size limit and what to do above it: "reject" (default) or "truncate"
+	MaxMessageSize int64  `json:"max_message_size"`
+	OversizePolicy string `json:"oversize_policy"`
+
+	// Suppression of consecutive identical writes
+	Dedup       bool          `json:"dedup"`
+	DedupWindow time.Duration `json:"dedup_window"`
+
+	// Writes kept in memory for RecentLogs; 0 = off
+	RecentBufferSize int `json:"recent_buffer_size"`
+
+	// Guaranteed line termination
+	EnsureNewline bool `json:"ensure_newline"`
+
+	// DisableAutoScale prevents transparent sync -> MPSC switching.
+	DisableAutoScale bool `json:"disable_auto_scale"`
+
+	// AutoScale tunes when sync -> MPSC switching happens (nil = defaults).
+	AutoScale *AutoScaleConfig `json:"auto_scale,omitempty"`
 
 	// Error handling
 	ErrorCallback func(operation string, err error) `json:"-"`
+	OnError       func(event ErrorEvent)            `json:"-"` // ErrorCallback tagged with Name()
 
 	// Pre-write hook for data transformation
 	// PreWriteHook is called before each write to transform data.
@@ -621,16 +1406,42 @@ type LoggerConfig struct {
 	// If hook returns error, Write fails with that error.
 	PreWriteHook func(data []byte) ([]byte, error) `json:"-"`
 
+	// Redaction or rewriting before PreWriteHook; nil drops the write (see Logger.Transform)
+	Transform func(data []byte) []byte `json:"-"`
+
 	// File operations
-	FileMode   os.FileMode   `json:"file_mode"`
-	RetryCount int           `json:"retry_count"`
-	RetryDelay time.Duration `json:"retry_delay"`
+	FileMode       os.FileMode   `json:"file_mode"`
+	BackupFileMode os.FileMode   `json:"backup_file_mode"` // Default: FileMode
+	DirMode        os.FileMode   `json:"dir_mode"`         // Default: 0750
+	RetryCount     int           `json:"retry_count"`
+	RetryDelay     time.Duration `json:"retry_delay"`
+	RetryMaxDelay  time.Duration `json:"retry_max_delay"` // Default: 0 (fixed delay)
+	RetryJitter    bool          `json:"retry_jitter"`
+
+	// Background worker pool size for post-rotation tasks (default: 2)
+	BackgroundWorkers int `json:"background_workers"`
+
+	// Compressions running at once; default BackgroundWorkers
+	MaxCompressions int `json:"max_compressions"`
 
 	// MPSC configuration
 	BufferSize         int           `json:"buffer_size"`
+	MinBufferSize      int           `json:"min_buffer_size"` // Floor for BufferSize rounding; default 64
+	ArenaSlotSize      int           `json:"arena_slot_size"` // Inline cell size; 0 = pointer ring
+	MaxBufferBytes     int64         `json:"max_buffer_bytes"`
 	BackpressurePolicy string        `json:"backpressure_policy"`
+	MaxSpillBytes      int64         `json:"max_spill_bytes"` // "overflow" spill file cap; default 64MB
 	FlushInterval      time.Duration `json:"flush_interval"`
 	AdaptiveFlush      bool          `json:"adaptive_flush"`
+	ConsumerBatchSize  int           `json:"consumer_batch_size"`
+	StallTimeout       time.Duration `json:"stall_timeout"` // Report a consumer stuck this long; 0 = off
+	IdleTimeout        time.Duration `json:"idle_timeout"`  // Park an idle consumer after this long; 0 = off
+
+	// Durability (fsync) controls
+	SyncOnWrite    bool          `json:"sync_on_write"`
+	SyncInterval   time.Duration `json:"sync_interval"`
+	BufferedSync   bool          `json:"buffered_sync"`    // Batch sync-mode writes in memory
+	SyncBufferSize int           `json:"sync_buffer_size"` // BufferedSync buffer; default 64KB
 
 	// Metrics export for monitoring (Prometheus, StatsD, etc.)
 	// MetricsCallback is called periodically with current stats.
@@ -649,6 +1460,52 @@ type LoggerConfig struct {
 	// CRITICAL: callback must be fast (<1ms) to avoid blocking writers.
 	// Panics in the callback are recovered and reported via ErrorCallback.
 	OnRotate func(event RotationEvent) `json:"-"`
+
+	// OnCompress is called on a background worker after each backup is
+	// compressed, with the source path, final compressed path, and size ratio.
+	OnCompress func(srcPath, gzPath string, ratio float64) `json:"-"`
+
+	// OnCleanup is called on a background worker with the backup paths
+	// removed by retention cleanup (MaxBackups / MaxFileAge).
+	OnCleanup func(removedPaths []string) `json:"-"`
+
+	// Symlink is an optional stable path that always points to the active
+	// log file (e.g., "current.log" for tail -F and monitoring agents).
+	Symlink string `json:"symlink"`
+
+	// RecreateIfMissing reopens Filename after external deletion.
+	RecreateIfMissing bool `json:"recreate_if_missing"`
+
+	// InodeCheckInterval reopens Filename when it is replaced externally;
+	// 0 = off.
+	InodeCheckInterval time.Duration `json:"inode_check_interval"`
+
+	// FallbackFilename receives writes while Filename is unwritable;
+	// OnFailover is told about each switch.
+	FallbackFilename string                    `json:"fallback_filename"`
+	OnFailover       func(event FailoverEvent) `json:"-"`
+
+	// Encryptor encrypts rotated backups at rest (".enc").
+	Encryptor Encryptor `json:"-"`
+
+	// Tee mirrors every write to a secondary writer (see Logger.Tee).
+	Tee io.Writer `json:"-"`
+
+	// Manifest keeps a JSON-lines inventory of backups in
+	// Filename + ".manifest" (see ReadManifest).
+	Manifest bool `json:"manifest"`
+
+	// PersistState keeps rotation sequence numbers across restarts
+	// via a Filename + ".state" sidecar.
+	PersistState bool `json:"persist_state"`
+
+	// MultiProcess takes an advisory Filename + ".lock" lock around rotation
+	// so processes sharing Filename never rotate concurrently.
+	MultiProcess bool `json:"multi_process"`
+
+	// Preallocate reserves MaxSize bytes of disk space for each active
+	// file (Linux, fallocate); incompatible with MultiProcess.
+	Preallocate bool `json:"preallocate"`
 }
 
 // Write implements io.Writer interface for universal compatibility.
@@ -698,6 +1555,10 @@ type LoggerConfig struct {
 //	// With frameworks
 //	logrus.SetOutput(logger)
 func (l *Logger) Write(data []byte) (int, error) {
+	if l.closed.Load() {
+		return 0, ErrClosed
+	}
+
 	// WHY: timeCache must be initialized before any goroutine proceeds to
 	// initFileState() or generateBackupName() which both read l.timeCache.
 	// Write() is the single entry point for all goroutines, so placing the
@@ -707,10 +1568,24 @@ func (l *Logger) Write(data []byte) (int, error) {
 		l.timeCache = timecache.NewWithResolution(time.Millisecond)
 	})
 
+	// Proactive load shedding (SampleRate / MaxWritesPerSecond)
+	if !l.admitWrite(len(data)) {
+		return len(data), nil
+	}
+
 	// Increment write counter for auto-scaling metrics
 	l.writeCount.Add(1)
 
-	// Apply pre-write hook if configured
+	if l.Transform != nil {
+		return l.writeTransformed(data, l.dispatch)
+	}
+	return l.submit(data, l.dispatch)
+}
+
+// submit applies PreWriteHook and EnsureNewline, then delivers data.
+func (l *Logger) submit(data []byte, dispatch func([]byte) (int, error)) (int, error) {
+	// Apply pre-write hook if configured. In WriteOwned the hook may
+	// return a new slice, breaking the zero-copy guarantee
 	if l.preWriteHook != nil {
 		var err error
 		data, err = l.preWriteHook(data)
@@ -719,12 +1594,67 @@ func (l *Logger) Write(data []byte) (int, error) {
 		}
 	}
 
+	// n counts the caller's bytes, not the appended '\n', so callers
+	// checking n == len(data) keep working
+	if l.unterminated(data) {
+		n, err := l.deliver(terminate(data), dispatch)
+		return min(n, len(data)), err
+	}
+	return l.deliver(data, dispatch)
+}
+
+// deliver applies MaxMessageSize, records data for RecentLogs and applies
+// Dedup, then hands data to dispatch.
+func (l *Logger) deliver(data []byte, dispatch func([]byte) (int, error)) (int, error) {
+	if l.oversized(data) {
+		return l.writeOversized(data, dispatch)
+	}
+	l.recordRecent(data)
+
+	if l.Dedup {
+		return l.writeDeduped(data, dispatch)
+	}
+
+	return dispatch(data)
+}
+
+// dispatch routes a caller-owned write to the sync or MPSC path, or to
+// the pause policy while paused.
+func (l *Logger) dispatch(data []byte) (int, error) {
+	if !l.enterFS() {
+		return l.writePaused(data, false, l.dispatch)
+	}
+	defer l.exitFS()
+	return l.route(data)
+}
+
+// WriteString writes s like Write, without first converting it to a
+// []byte. It implements io.StringWriter, so io.WriteString(logger, s) and
+// fmt.Fprint-style helpers that look for it avoid the copy as well.
+//
+// In sync mode the string's bytes go straight to the file; in async mode
+// they are copied once, into the ring buffer. With a Transform or
+// PreWriteHook, which may modify their input in place, s is copied first.
+func (l *Logger) WriteString(s string) (int, error) {
+	// WHY no-copy view: every path below Write treats data as read-only
+	// and copies it before retaining it (ring buffer, pause buffer, dedup),
+	// so aliasing the immutable string memory is safe
+	data := unsafe.Slice(unsafe.StringData(s), len(s)) // #nosec G103 -- read-only view, never retained
+	if l.preWriteHook != nil || l.Transform != nil {
+		data = []byte(s)
+	}
+	return l.Write(data)
+}
+
+// route is dispatch without the pause gate.
+func (l *Logger) route(data []byte) (int, error) {
 	if l.Async {
 		return l.writeAsync(data)
 	}
 
 	// Auto-scaling logic: detect high concurrency and switch to MPSC
-	if l.shouldScaleToMPSC() {
+	if !l.DisableAutoScale && l.routeAutoScaled() {
+		defer l.doneInFlight()
 		return l.writeAsync(data)
 	}
 
@@ -736,6 +1666,19 @@ func (l *Logger) Write(data []byte) (int, error) {
 // This enables zero-copy optimization in MPSC mode, improving performance
 // for systems that can transfer ownership of pre-allocated buffers.
 //
+// Ownership rules:
+//   - Only data[:len(data)] is transferred; its spare capacity is never
+//     read or written.
+//   - When the message is enqueued in the MPSC buffer, or held by Pause,
+//     the Logger owns data until it is written, and afterwards recycles
+//     it internally; it is never handed back.
+//   - Every other outcome consumes data before WriteOwned returns: sync
+//     mode, a sync fallback (backpressure, or MPSC initialization failure),
+//     a spill, a drop, a rejection, or an error.
+//
+// The caller cannot tell which path a write took, so the only safe use is
+// to give up data for good: never return it to a pool or write into it.
+//
 // This is particularly useful for integration with web frameworks like Iris
 // or high-performance systems that manage their own buffer pools.
 //
@@ -752,6 +1695,10 @@ func (l *Logger) Write(data []byte) (int, error) {
 //
 // Returns the number of bytes written and any error encountered.
 func (l *Logger) WriteOwned(data []byte) (int, error) {
+	if l.closed.Load() {
+		return 0, ErrClosed
+	}
+
 	// WHY: WriteOwned is a separate public entry point (zero-copy path).
 	// It must run timeCacheOnce.Do() for the same reason as Write(): direct
 	// &Logger{} construction leaves timeCache nil, and writeSync reads it.
@@ -759,25 +1706,39 @@ func (l *Logger) WriteOwned(data []byte) (int, error) {
 		l.timeCache = timecache.NewWithResolution(time.Millisecond)
 	})
 
+	// Proactive load shedding (SampleRate / MaxWritesPerSecond)
+	if !l.admitWrite(len(data)) {
+		return len(data), nil
+	}
+
 	// Increment write counter for auto-scaling metrics
 	l.writeCount.Add(1)
 
-	// Apply pre-write hook if configured
-	// Note: Hook may return a new slice, breaking zero-copy guarantee
-	if l.preWriteHook != nil {
-		var err error
-		data, err = l.preWriteHook(data)
-		if err != nil {
-			return 0, fmt.Errorf("pre-write hook failed: %w", err)
-		}
+	if l.Transform != nil {
+		return l.writeTransformed(data, l.dispatchOwned)
+	}
+	return l.submit(data, l.dispatchOwned)
+}
+
+// dispatchOwned routes an owned, already-hooked message to the async or
+// sync path, the shared tail of WriteOwned and WriteBatch.
+func (l *Logger) dispatchOwned(data []byte) (int, error) {
+	if !l.enterFS() {
+		return l.writePaused(data, true, l.dispatchOwned)
 	}
+	defer l.exitFS()
+	return l.routeOwned(data)
+}
 
+// routeOwned is dispatchOwned without the pause gate.
+func (l *Logger) routeOwned(data []byte) (int, error) {
 	if l.Async {
 		return l.writeAsyncOwned(data)
 	}
 
 	// Auto-scaling logic: detect high concurrency and switch to MPSC
-	if l.shouldScaleToMPSC() {
+	if !l.DisableAutoScale && l.routeAutoScaled() {
+		defer l.doneInFlight()
 		return l.writeAsyncOwned(data)
 	}
 
@@ -793,6 +1754,11 @@ func (l *Logger) WriteOwned(data []byte) (int, error) {
 //   - Graceful shutdown without hanging
 //   - Request-scoped cancellation propagation
 //
+// ctx is checked once, before the write is submitted. No BackpressurePolicy
+// waits for buffer space (a full buffer falls back to a direct write, drops
+// or grows), so a submitted write returns without blocking on the buffer;
+// a direct write already in progress on a slow disk is not interrupted.
+//
 // Usage example:
 //
 //	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -853,42 +1819,56 @@ func (l *Logger) writeAsyncOwned(data []byte) (int, error) {
 		}
 	}
 
+	// WHY no copy on the sync fallbacks below: writeSync is done with data
+	// when it returns (BufferedSync and CompressActive copy what they keep),
+	// so only a successful pushOwned retains the caller's slice
 	buffer := l.buffer.Load()
 	if buffer == nil {
 		return l.writeSync(data) // Fallback if still nil
 	}
 
-	// Try to push to ring buffer with ownership transfer
-	if buffer.pushOwned(data) {
-		return len(data), nil
+	// Once messages spilled, later ones queue behind them to keep order
+	if l.spilling() {
+		return l.writeSpill(buffer, data)
+	}
+
+	// Try to push to ring buffer with ownership transfer, within the byte budget
+	overBudget := !l.reserveBufferBytes(len(data))
+	if !overBudget {
+		if buffer.pushOwned(data) {
+			return len(data), nil
+		}
+		l.releaseBufferBytes(len(data))
 	}
 
 	// Buffer full - apply backpressure policy
 	l.contentionCount.Add(1)
 
-	policy := l.BackpressurePolicy
-	if policy == "" {
-		policy = "fallback" // Default policy
-	}
+	policy := l.backpressurePolicy()
 
 	switch policy {
 	case "drop":
 		// Drop-on-full policy: silently discard the message
-		l.droppedCount.Add(1)
-		l.lastDropTime.Store(time.Now().UnixNano())
+		l.countDrop(dropBufferFull, len(data))
 		return len(data), nil
 
 	case "adaptive":
-		// Adaptive resize: try to expand buffer on pressure
-		if l.tryAdaptiveResize(buffer) {
-			// Retry with expanded buffer
-			if buffer.pushOwned(data) {
+		// Adaptive resize: try to expand buffer on pressure.
+		// More slots cannot help when the byte budget is the limit,
+		// nor when the message is larger than an arena cell.
+		if !overBudget && buffer.fits(len(data)) && l.reserveBufferBytes(len(data)) {
+			if l.pushResized(buffer, data, true) {
 				return len(data), nil
 			}
+			l.releaseBufferBytes(len(data))
 		}
-		// If resize failed or push still failed, fallback to sync
+		// The ring cannot grow: fall back to sync, which may overtake
+		// queued messages as with "fallback"
 		return l.writeSync(data)
 
+	case "overflow":
+		return l.writeSpill(buffer, data)
+
 	default: // "fallback"
 		// Original behavior: fallback to sync write
 		return l.writeSync(data)
@@ -906,15 +1886,19 @@ func (l *Logger) writeAsyncOwned(data []byte) (int, error) {
 // - Contention: Detected when rotation flag is set during writes
 // - Latency: High latency indicates filesystem bottlenecks
 // - Write frequency: High frequency benefits from batching
+//
+// Counters are measured from the last downscale (see scaleDown), so a
+// logger that returned to sync mode must show fresh pressure to scale up again.
 func (l *Logger) shouldScaleToMPSC() bool {
-	writeCount := l.writeCount.Load()
-	contentionCount := l.contentionCount.Load()
-	totalLatency := l.totalLatency.Load()
+	writeCount := l.writeCount.Load() - l.scaleBaseWrites.Load()
+	contentionCount := l.contentionCount.Load() - l.scaleBaseContention.Load()
+	totalLatency := l.totalLatency.Load() - l.scaleBaseLatency.Load()
 	lastLatency := l.lastLatency.Load()
+	thresholds := l.autoScaleThresholds()
 
 	// Need minimum sample size for reliable metrics
-	// Why 100: Avoids premature scaling during application startup
-	if writeCount < 100 {
+	// Why 100 by default: Avoids premature scaling during application startup
+	if writeCount < thresholds.MinSamples {
 		return false
 	}
 
@@ -925,26 +1909,26 @@ func (l *Logger) shouldScaleToMPSC() bool {
 
 	// 1. High contention detected
 	// Why: Contention indicates multiple goroutines competing for file access
-	if contentionCount > 0 && writeCount > 1000 {
+	if contentionCount > 0 && writeCount > thresholds.MinSamples*scaleContentionSampleFactor {
 		return true
 	}
 
-	// 2. High average latency (> 1ms indicates slow writes)
+	// 2. High average latency (> 1ms by default indicates slow writes)
 	// Why: 1ms is threshold where MPSC overhead becomes beneficial
-	if avgLatency > 1_000_000 { // 1ms in nanoseconds
+	if avgLatency > uint64(thresholds.AvgLatencyThreshold.Nanoseconds()) { // #nosec G115 -- positive after defaults
 		return true
 	}
 
-	// 3. Recent spike in latency (last write > 5ms)
+	// 3. Recent spike in latency (last write > 5ms by default)
 	// Why: Reactive scaling for sudden performance degradation
-	if lastLatency > 5_000_000 { // 5ms in nanoseconds
+	if lastLatency > uint64(thresholds.SpikeLatencyThreshold.Nanoseconds()) { // #nosec G115 -- positive after defaults
 		return true
 	}
 
 	// 4. High frequency with degrading performance
 	// Why: 10% contention ratio indicates significant competition
 	contentionRatio := float64(contentionCount) / float64(writeCount)
-	if contentionRatio > 0.1 { // 10% contention rate
+	if contentionRatio > thresholds.ContentionRatioThreshold { // 10% contention rate by default
 		return true
 	}
 
@@ -973,6 +1957,11 @@ func (l *Logger) writeSync(data []byte) (int, error) {
 		l.initMutex.Lock()
 		// Double-check pattern
 		if l.currentFile.Load() == nil {
+			// A write that raced Close must not resurrect the file
+			if l.closed.Load() {
+				l.initMutex.Unlock()
+				return 0, ErrClosed
+			}
 			if err := l.initFile(); err != nil {
 				l.initMutex.Unlock()
 				return 0, err
@@ -981,6 +1970,12 @@ func (l *Logger) writeSync(data []byte) (int, error) {
 		l.initMutex.Unlock()
 	}
 
+	l.ensureFilePresent()
+	l.maybeFailBack()
+	if l.RecordBoundary != nil {
+		l.rotateAtBoundary(data)
+	}
+
 	// Atomic load current file
 	file := l.currentFile.Load()
 	if file == nil {
@@ -992,29 +1987,75 @@ func (l *Logger) writeSync(data []byte) (int, error) {
 		l.contentionCount.Add(1)
 	}
 
-	// Write to file (filesystem provides locking)
-	n, err := file.Write(data)
+	// Write to file. Each message is one os.File.Write, which Go
+	// serializes per file for the whole buffer (short writes included),
+	// so concurrent writers never interleave within a line; see
+	// "Write Atomicity" in doc.go
+	var n int
+	var err error
+	if l.BufferedSync {
+		n, err = l.writeBuffered(file, data)
+	} else if l.CompressActive {
+		n, err = l.writeCompressed(data)
+	} else {
+		n, err = writeFull(file, data)
+		if err != nil {
+			var m int
+			m, err = l.failover(file, data[n:], err)
+			n += m
+		}
+	}
+	if n > 0 {
+		l.tee(data[:n])
+
+		// Track last write time for observability
+		l.lastWriteTime.Store(time.Now().UnixNano())
+	}
+
+	// Account for partially written data too: it is in the file either way
+	newSize := l.bytesWritten.Add(uint64(n)) // #nosec G115 -- writeFull never returns a negative count
+	l.totalWritten.Add(uint64(n))            // #nosec G115 -- writeFull never returns a negative count
+	l.countLines(data[:n])
+	l.markDirty()
 	if err != nil {
+		l.reportWriteError("write", err)
 		return n, err
 	}
 
-	// Track last write time for observability
-	l.lastWriteTime.Store(time.Now().UnixNano())
-
-	// Atomic update size (n from Write() is always >= 0, but be safe)
-	if n < 0 {
-		n = 0
+	// Durable write: fsync before reporting success
+	if l.SyncOnWrite {
+		if err := l.fsync(file); err != nil {
+			l.reportWriteError("fsync", err)
+			return n, err
+		}
 	}
-	newSize := l.bytesWritten.Add(uint64(n)) // #nosec G115 -- n checked for negative values above
 
 	// Check rotation (lock-free)
-	if l.shouldRotate(newSize) {
-		l.triggerRotation()
-	}
+	l.checkRotation(newSize)
 
 	return n, nil
 }
 
+// writeFull writes all of p to w, retrying after short writes: io.Writer
+// allows n < len(p) with a nil error, and on some filesystems and pipes a
+// single write does stop early. Returns the bytes actually written, and
+// io.ErrShortWrite if w makes no progress without reporting an error.
+func writeFull(w io.Writer, p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		n, err := w.Write(p[written:])
+		n = min(max(n, 0), len(p)-written) // Never trust n outside [0, remaining]
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
+
 // writeAsync handles high-throughput MPSC writes with configurable backpressure
 func (l *Logger) writeAsync(data []byte) (int, error) {
 	// Lazy initialization of MPSC buffer
@@ -1030,44 +2071,90 @@ func (l *Logger) writeAsync(data []byte) (int, error) {
 		return l.writeSync(data) // Fallback if still nil
 	}
 
-	// Try to push to ring buffer
-	if buffer.push(data) {
-		return len(data), nil
+	// Once messages spilled, later ones queue behind them to keep order
+	if l.spilling() {
+		return l.writeSpill(buffer, data)
+	}
+
+	// Try to push to ring buffer, within the byte budget
+	overBudget := !l.reserveBufferBytes(len(data))
+	if !overBudget {
+		if buffer.push(data) {
+			return len(data), nil
+		}
+		l.releaseBufferBytes(len(data))
 	}
 
 	// Buffer full - apply backpressure policy
 	l.contentionCount.Add(1)
 
-	policy := l.BackpressurePolicy
-	if policy == "" {
-		policy = "fallback" // Default policy
-	}
+	policy := l.backpressurePolicy()
 
 	switch policy {
 	case "drop":
 		// Drop-on-full policy: silently discard the message
 		// Useful for high-frequency telemetry/access logs
-		l.droppedCount.Add(1)
-		l.lastDropTime.Store(time.Now().UnixNano())
+		l.countDrop(dropBufferFull, len(data))
 		return len(data), nil
 
 	case "adaptive":
-		// Adaptive resize: try to expand buffer on pressure
-		if l.tryAdaptiveResize(buffer) {
-			// Retry with expanded buffer
-			if buffer.push(data) {
+		// Adaptive resize: try to expand buffer on pressure.
+		// More slots cannot help when the byte budget is the limit,
+		// nor when the message is larger than an arena cell.
+		if !overBudget && buffer.fits(len(data)) && l.reserveBufferBytes(len(data)) {
+			if l.pushResized(buffer, data, false) {
 				return len(data), nil
 			}
+			l.releaseBufferBytes(len(data))
 		}
-		// If resize failed or push still failed, fallback to sync
+		// The ring cannot grow: fall back to sync, which may overtake
+		// queued messages as with "fallback"
 		return l.writeSync(data)
 
+	case "overflow":
+		return l.writeSpill(buffer, data)
+
 	default: // "fallback"
 		// Original behavior: fallback to sync write
 		return l.writeSync(data)
 	}
 }
 
+// backpressurePolicy returns the effective BackpressurePolicy.
+// Constructors reject unknown values; for struct-literal Loggers an unknown
+// policy is reported once and treated as "fallback", so a typo such as
+// "droop" never silently changes behavior unnoticed.
+func (l *Logger) backpressurePolicy() string {
+	policy := l.BackpressurePolicy
+	if policy == "" {
+		return "fallback" // Default policy
+	}
+	if !validBackpressurePolicy(policy) {
+		if l.policyReported.CompareAndSwap(false, true) {
+			l.reportError("backpressure_policy", fmt.Errorf("unknown BackpressurePolicy %q; using \"fallback\"", policy))
+		}
+		return "fallback"
+	}
+	return policy
+}
+
+// reserveBufferBytes accounts n bytes about to be enqueued.
+// Returns false (reserving nothing) if that would exceed MaxBufferBytes.
+func (l *Logger) reserveBufferBytes(n int) bool {
+	size := int64(n)
+	total := l.bufferedBytes.Add(size)
+	if l.MaxBufferBytes > 0 && total > l.MaxBufferBytes {
+		l.bufferedBytes.Add(-size)
+		return false
+	}
+	return true
+}
+
+// releaseBufferBytes returns n bytes to the budget once they leave the buffer.
+func (l *Logger) releaseBufferBytes(n int) {
+	l.bufferedBytes.Add(-int64(n))
+}
+
 // initMPSC initializes the MPSC buffer and consumer goroutine
 func (l *Logger) initMPSC() error {
 	// Get buffer size from configuration (default: 1024)
@@ -1077,22 +2164,28 @@ func (l *Logger) initMPSC() error {
 	}
 
 	// Create ring buffer with configured size
-	if bufferSize < 0 {
-		bufferSize = 1024 // Safety fallback
-	}
-	buffer := newRingBuffer(uint64(bufferSize)) // #nosec G115 -- bufferSize checked for negative values above
+	slots := l.ringSlots(bufferSize)
+	buffer := l.newRing(slots)
 
 	// Try to atomically set the buffer
 	if !l.buffer.CompareAndSwap(nil, buffer) {
 		// Someone else initialized it
 		return nil
 	}
+	if l.BufferSize > 0 && uint64(l.BufferSize) != slots {
+		l.reportError("buffer_size_adjusted", fmt.Errorf("BufferSize %d rounded up to %d slots (power of 2, MinBufferSize %d)",
+			l.BufferSize, slots, l.ringSlots(1)))
+	}
 
 	// Initialize file if needed (thread-safe)
 	if l.currentFile.Load() == nil {
 		l.initMutex.Lock()
 		// Double-check pattern
 		if l.currentFile.Load() == nil {
+			if l.closed.Load() {
+				l.initMutex.Unlock()
+				return ErrClosed
+			}
 			if err := l.initFile(); err != nil {
 				l.initMutex.Unlock()
 				return err
@@ -1108,60 +2201,186 @@ func (l *Logger) initMPSC() error {
 	return nil
 }
 
-// tryAdaptiveResize attempts to resize the MPSC buffer dynamically
-// Returns true if resize was successful, false otherwise
-func (l *Logger) tryAdaptiveResize(currentBuffer *ringBuffer) bool {
-	// Adaptive resize policy: double buffer size up to a maximum
-	currentSize := uint64(len(currentBuffer.buffer))
-	maxSize := uint64(16384) // Max 16K entries to prevent excessive memory usage
-
-	if currentSize >= maxSize {
-		return false // Already at maximum size
+// pushResized pushes data into successors of the full ring buffer,
+// growing the chain as long as each one is full in turn. Returns false
+// once the ring is at its maximum size.
+//
+// WHY not fall back to sync as soon as one retry fails: a sync write
+// lands ahead of everything still queued, so a writer whose earlier
+// messages wait in the ring would see its own lines reordered. Staying
+// in the chain keeps them in write order (see MPSCConsumer.ring).
+func (l *Logger) pushResized(buffer *ringBuffer, data []byte, owned bool) bool {
+	for buffer = l.tryAdaptiveResize(buffer); buffer != nil; buffer = l.tryAdaptiveResize(buffer) {
+		pushed := false
+		if owned {
+			pushed = buffer.pushOwned(data)
+		} else {
+			pushed = buffer.push(data)
+		}
+		if pushed {
+			return true
+		}
 	}
+	return false
+}
 
-	newSize := currentSize * 2
-	if newSize > maxSize {
-		newSize = maxSize
-	}
+// tryAdaptiveResize replaces the full MPSC ring with one twice its size
+// and returns the ring to retry the push on: the newest in the chain,
+// whether this call or a concurrent one created it. Returns nil only at
+// the maximum size, or once scale-down has torn the MPSC path down.
+//
+// WHY the old ring is not copied: producers that loaded it before the swap
+// may still push into it, and the consumer may be popping from it, so any
+// copy races both and strands or drops messages. Instead the old ring is
+// linked to its successor and retired, which makes every further push into
+// it fail, and the consumer drains it to the end before following the
+// link (see MPSCConsumer.ring). Nothing is moved, so nothing can be lost.
+func (l *Logger) tryAdaptiveResize(currentBuffer *ringBuffer) *ringBuffer {
+	if currentBuffer.next.Load() == nil {
+		// Adaptive resize policy: double buffer size up to a maximum
+		currentSize := currentBuffer.slots()
+		maxSize := uint64(16384) // Max 16K entries to prevent excessive memory usage
+
+		if currentSize >= maxSize {
+			return nil // Already at maximum size
+		}
 
-	// Create new larger buffer
-	newBuffer := newRingBuffer(newSize)
+		newSize := currentSize * 2
+		if newSize > maxSize {
+			newSize = maxSize
+		}
+
+		// The loser of this race simply follows the winner's link below
+		if currentBuffer.next.CompareAndSwap(nil, l.newRing(newSize)) {
+			currentBuffer.retire()
+			// Wake a consumer waiting or parked on the old ring: new
+			// pushes signal only the new one
+			currentBuffer.signalDataAvailable()
+		}
+	}
+	return l.advanceRing()
+}
 
-	// Drain ALL messages from current buffer into new buffer
-	// We must not lose any messages during resize
+// advanceRing moves l.buffer to the newest ring of its chain and returns
+// it, or nil when there is no ring (scale-down, whose final flush has
+// already run, so the caller must not queue).
+//
+// WHY every resizer advances l.buffer rather than only the one that
+// created the ring: resizes can complete out of order. A writer may link
+// and retire ring B while the creator of B has not yet swapped it in, so
+// its own swap from B would fail and l.buffer would be left on a retired
+// ring, failing every push once before finding the live one.
+func (l *Logger) advanceRing() *ringBuffer {
 	for {
-		data, ok := currentBuffer.pop()
-		if !ok {
-			break // Current buffer empty
+		current := l.buffer.Load()
+		if current == nil {
+			return nil
 		}
-		if !newBuffer.push(data) {
-			// New buffer full (shouldn't happen since we're doubling size)
-			// Put the data back - this is a best effort
-			// In practice this path should never be hit
-			safeBufferPool.Put(data)
-			return false
+		newest := current.newest()
+		// A lost swap means another writer moved l.buffer on: reload
+		if newest == current || l.buffer.CompareAndSwap(current, newest) {
+			return newest
 		}
 	}
+}
+
+// rotationReason records which limit triggered a rotation, for Stats and
+// RotationEvent.Reason.
+type rotationReason uint8
+
+const (
+	rotateNone     rotationReason = iota // No limit reached
+	rotateSize                           // MaxSize / MaxSizeStr
+	rotateLines                          // MaxLines
+	rotateAge                            // MaxAge / MaxAgeStr
+	rotateCalendar                       // RotateAt
+	rotateManual                         // Rotate, RotateSync, RotateNamed
+	rotateCustom                         // RotateWhen
+	rotateClose                          // CompressOnClose
+)
+
+// String returns the RotationEvent.Reason name of r.
+func (r rotationReason) String() string {
+	switch r {
+	case rotateSize:
+		return "size"
+	case rotateLines:
+		return "lines"
+	case rotateAge:
+		return "age"
+	case rotateCalendar:
+		return "calendar"
+	case rotateManual:
+		return "manual"
+	case rotateCustom:
+		return "custom"
+	case rotateClose:
+		return "close"
+	}
+	return ""
+}
 
-	// Atomically replace buffer
-	return l.buffer.CompareAndSwap(currentBuffer, newBuffer)
+// Rotation modes
+const (
+	RotationModeAny = "any" // Rotate when any enabled limit is reached (default)
+	RotationModeAll = "all" // Rotate only when every enabled limit is reached
+)
+
+// rotationCheck combines the enabled limits for shouldRotate per
+// RotationMode.
+type rotationCheck struct {
+	all    bool
+	reason rotationReason
 }
 
-// shouldRotate checks if rotation is needed (lock-free)
-func (l *Logger) shouldRotate(currentSize uint64) bool {
+// add folds in one enabled limit and reports whether the outcome is
+// decided: by a reached limit in "any" mode, by an unreached one in "all"
+// mode. In "all" mode the first limit is the reason reported.
+func (c *rotationCheck) add(reached bool, reason rotationReason) (decided bool) {
+	switch {
+	case reached && !c.all:
+		c.reason = reason
+		return true
+	case !reached && c.all:
+		c.reason = rotateNone
+		return true
+	case reached && c.reason == rotateNone:
+		c.reason = reason
+	}
+	return false
+}
+
+// shouldRotate checks if rotation is needed (lock-free) and reports the
+// first limit reached (with RotationMode "all", the first limit once all
+// are reached), or rotateNone.
+func (l *Logger) shouldRotate(currentSize uint64) rotationReason {
 	// WHY: delegate to initSizeConfig() instead of duplicating logic.
 	// initSizeConfig() is idempotent and uses atomic.Int64 for thread safety.
 	l.initSizeConfig()
 
+	// The fallback is not rotated (see errFailedOver)
+	if l.failedOver.Load() {
+		return rotateNone
+	}
+	check := rotationCheck{all: l.RotationMode == RotationModeAll}
+
 	// Check size-based rotation
 	maxSize := l.maxSizeBytes.Load()
-	if maxSize > 0 && currentSize >= uint64(maxSize) {
-		return true
+	if maxSize > 0 && check.add(currentSize >= uint64(maxSize), rotateSize) {
+		return check.reason
+	}
+
+	// Check line-based rotation
+	if l.MaxLines > 0 && check.add(l.lineCount.Load() >= l.MaxLines, rotateLines) {
+		return check.reason
 	}
 
 	// Check time-based rotation (supports both old and new formats)
 	var maxAge time.Duration
-	if l.MaxAgeStr != "" {
+	if override := l.maxAgeOverride.Load(); override != 0 {
+		// Set at runtime by SetMaxAge (negative = disabled)
+		maxAge = time.Duration(override)
+	} else if l.MaxAgeStr != "" {
 		// Use new string-based configuration
 		if duration, err := ParseDuration(l.MaxAgeStr); err == nil {
 			maxAge = duration
@@ -1172,16 +2391,49 @@ func (l *Logger) shouldRotate(currentSize uint64) bool {
 	}
 
 	if maxAge > 0 {
+		maxAge += l.ageJitter()
 		createdTime := l.fileCreated.Load()
-		if createdTime > 0 {
-			elapsed := time.Since(time.Unix(createdTime, 0))
-			if elapsed >= maxAge {
-				return true
-			}
+		reached := createdTime > 0 && l.now().Sub(time.Unix(createdTime, 0)) >= maxAge
+		if check.add(reached, rotateAge) {
+			return check.reason
 		}
 	}
 
-	return false
+	// Consulted last, so in "all" mode only once every other limit is reached
+	if l.RotateWhen != nil && check.add(l.safeInvokeRotateWhen(currentSize), rotateCustom) {
+		return check.reason
+	}
+
+	return check.reason
+}
+
+// safeInvokeRotateWhen calls the RotateWhen predicate with panic recovery.
+// WHY: it runs on the write path; in async mode a panic would kill the
+// consumer goroutine and silently stop all writes.
+func (l *Logger) safeInvokeRotateWhen(currentSize uint64) (rotate bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("rotate_when_panic", fmt.Errorf("RotateWhen callback panicked: %v", r))
+			rotate = false
+		}
+	}()
+
+	var fileAge time.Duration
+	if created := l.fileCreated.Load(); created > 0 {
+		fileAge = l.now().Sub(time.Unix(created, 0))
+	}
+	return l.RotateWhen(currentSize, fileAge)
+}
+
+// countLines adds the newlines in data to the current file's line counter.
+// Skipped entirely when MaxLines is unset to keep the hot path free of the scan.
+func (l *Logger) countLines(data []byte) {
+	if l.MaxLines <= 0 {
+		return
+	}
+	if lines := bytes.Count(data, newline); lines > 0 {
+		l.lineCount.Add(int64(lines))
+	}
 }
 
 // triggerRotation initiates rotation (lock-free, single-threaded)
@@ -1194,18 +2446,47 @@ func (l *Logger) shouldRotate(currentSize uint64) bool {
 // - No blocking (mutex blocks other writers)
 // - Cache-friendly (atomic operations are CPU-optimized)
 // - Wait-free for non-rotating goroutines
-func (l *Logger) triggerRotation() {
+//
+// It reports whether this call performed the rotation, successful or not;
+// false means it was skipped because the Logger is paused or another
+// rotation is in progress.
+func (l *Logger) triggerRotation(reason rotationReason) bool {
+	// Deferred while paused; the next write past the threshold retries
+	if !l.enterFS() {
+		return false
+	}
+	defer l.exitFS()
+
 	// CAS to claim rotation - only one goroutine can succeed
 	// Others continue writing to old file until rotation completes
 	if !l.rotationFlag.CompareAndSwap(false, true) {
-		return // Someone else is rotating
+		return false // Someone else is rotating
 	}
 	defer l.rotationFlag.Store(false)
 
 	// Perform rotation
-	if err := l.performRotation(); err != nil {
+	if err := l.performRotation(reason); err != nil {
 		l.reportError("rotation", err)
 	}
+	return true
+}
+
+// countRotation attributes a completed rotation to its trigger.
+func (l *Logger) countRotation(reason rotationReason) {
+	switch reason {
+	case rotateSize:
+		l.sizeRotations.Add(1)
+	case rotateLines:
+		l.lineRotations.Add(1)
+	case rotateAge:
+		l.ageRotations.Add(1)
+	case rotateCalendar:
+		l.calendarRotations.Add(1)
+	case rotateManual:
+		l.manualRotations.Add(1)
+	case rotateCustom:
+		l.customRotations.Add(1)
+	}
 }
 
 // initFile and performRotation are implemented in rotation.go
@@ -1220,7 +2501,9 @@ func (l *Logger) triggerRotation() {
 //   - Stop the time cache
 //   - Close the current log file
 //
-// After calling Close, the logger should not be used for further writes.
+// Close is idempotent: only the first call does any work. After it, Write,
+// WriteOwned, WriteBatch and their variants return ErrClosed instead of
+// reopening the file.
 //
 // Example:
 //
@@ -1243,6 +2526,7 @@ func (l *Logger) triggerRotation() {
 //
 // Important: Always call Close when shutting down to prevent data loss.
 // Use defer immediately after logger creation for automatic cleanup.
+// Close waits as long as draining takes; use CloseContext to bound it.
 //
 // Parameters: None
 //
@@ -1261,37 +2545,162 @@ func (l *Logger) triggerRotation() {
 //	logger.Write([]byte("Application shutting down\n"))
 //	// Close() called automatically via defer
 func (l *Logger) Close() error {
+	return l.CloseContext(context.Background())
+}
+
+// CloseContext is Close with a bound on how long shutdown may take.
+// It waits for the MPSC consumer to flush and for background tasks to
+// finish until ctx is done. On cancellation or deadline an error wrapping
+// ctx.Err() is returned, the consumer stops writing, and messages still
+// buffered at that point are lost; a task still running on a stuck disk
+// is abandoned. Stats.ShutdownLost (see CloseStats) counts them. The file
+// is closed anyway, so the descriptor never leaks: right away, or, if a
+// write to it was still in progress, as soon as that write returns.
+//
+// Like Close, only the first call does any work.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := logger.CloseContext(ctx); errors.Is(err, context.DeadlineExceeded) {
+//		log.Printf("log shutdown timed out: %v", err)
+//	}
+func (l *Logger) CloseContext(ctx context.Context) error {
 	var closeErr error
 	l.closeOnce.Do(func() {
-		// Stop metrics callback if running
-		if l.metricsStop != nil {
-			close(l.metricsStop)
-			l.metricsWg.Wait()
-		}
-
-		// Stop MPSC consumer if running
-		if consumer := l.consumer.Load(); consumer != nil {
-			consumer.stop()
-		}
+		// A paused Logger resumes so held writes are drained like any other
+		l.Resume()
+		l.closed.Store(true)
+		start, pending, consumed := time.Now(), l.pendingMessages(), l.consumedCount.Load()
+
+		writersDone, drained := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(drained)
+			l.stopWriters()
+			close(writersDone)
+			l.stopWorkers()
+		}()
 
-		// Stop background workers if running
-		if workers := l.bgWorkers.Load(); workers != nil {
-			workers.stop()
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			closeErr = fmt.Errorf("close did not finish draining: %w", ctx.Err())
+			l.drainAbandoned.Store(true)
 		}
+		l.recordShutdown(start, pending, consumed)
 
 		// Stop time cache if running
 		if l.timeCache != nil {
 			l.timeCache.Stop()
 		}
 
-		// Close file
-		if file := l.currentFile.Load(); file != nil {
-			closeErr = file.Close()
+		select {
+		case <-writersDone:
+			if err := l.closeFile(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		default:
+			// WHY not close now: the consumer or a rotation is still inside
+			// a write; it would hit a closed descriptor, and a rotation
+			// reopening the file would leave the new one open. The consumer
+			// stops at its next batch now that drainAbandoned is set, and
+			// the file is closed as soon as it does.
+			go func() {
+				<-writersDone
+				if err := l.closeFile(); err != nil {
+					l.reportError("close", err)
+				}
+			}()
 		}
 	})
 	return closeErr
 }
 
+// closeFile writes out BufferedSync data and closes the active file
+// (already closed if CompressOnClose archived it), then removes the
+// stable symlink. Called once nothing writes to the file any more.
+func (l *Logger) closeFile() error {
+	var closeErr error
+	if err := l.flushSyncBuffer(); err != nil && !isFileAlreadyClosedError(err) {
+		closeErr = err
+	}
+	if err := l.closeActiveGzip(); err != nil && !isFileAlreadyClosedError(err) && closeErr == nil {
+		closeErr = err
+	}
+	if l.ChecksumInterval > 0 {
+		l.updateActiveChecksum() // Final state; failures are reported
+	}
+	if file := l.currentFile.Load(); file != nil {
+		if err := file.Close(); err != nil && !isFileAlreadyClosedError(err) && closeErr == nil {
+			closeErr = err
+		}
+	}
+
+	// Remove the stable symlink if we created it
+	l.removeSymlink()
+	return closeErr
+}
+
+// stopWriters stops the Logger's goroutines other than the worker pool,
+// flushing the MPSC buffer. Once it returns nothing writes to or rotates
+// the active file.
+func (l *Logger) stopWriters() {
+	// Stop dedup first and write its pending summary while the consumer
+	// and file are still live
+	l.stopDedup()
+
+	// Stop metrics callback if running
+	if l.metricsStop != nil {
+		close(l.metricsStop)
+		l.metricsWg.Wait()
+	}
+
+	// Stop the auto-scaling monitor first so no downscale races shutdown
+	if m := l.scaleMonitor.Load(); m != nil {
+		m.stop()
+	}
+
+	// Stop calendar rotation scheduler if running
+	if s := l.scheduler.Load(); s != nil {
+		s.stop()
+	}
+
+	// Stop periodic fsync and BufferedSync flush if running
+	if s := l.syncLoop.Load(); s != nil {
+		s.stop()
+	}
+	if s := l.syncBufLoop.Load(); s != nil {
+		s.stop()
+	}
+	if s := l.activeGzLoop.Load(); s != nil {
+		s.stop()
+	}
+	if s := l.activeSumLoop.Load(); s != nil {
+		s.stop()
+	}
+
+	// Stop MPSC consumer if running; its final flush drains the spill file
+	if consumer := l.consumer.Load(); consumer != nil {
+		consumer.stop()
+	}
+	l.closeSpill()
+
+	// Archive the final file now that every buffered write has landed,
+	// while the worker pool can still compress it
+	if l.CompressOnClose {
+		l.archiveOnClose()
+	}
+}
+
+// stopWorkers waits for running background tasks (compression, cleanup,
+// checksums) and stops the worker pool. None of them touch the active file.
+func (l *Logger) stopWorkers() {
+	if workers := l.bgWorkers.Load(); workers != nil {
+		workers.stop()
+	}
+}
+
 // WaitForBackgroundTasks waits for all background tasks (compression, cleanup, checksums) to complete.
 // This is useful in tests to ensure all operations have finished before checking results.
 //
@@ -1306,6 +2715,24 @@ func (l *Logger) WaitForBackgroundTasks() {
 	}
 }
 
+// CurrentFile returns the path of the active log file: Filename after
+// SanitizeFilename, which may differ from the configured value. Returns ""
+// until the first write opens the file. Safe to call concurrently with
+// writes, unlike reading Filename directly.
+func (l *Logger) CurrentFile() string {
+	if path := l.currentPath.Load(); path != nil {
+		return *path
+	}
+	return ""
+}
+
+// CurrentSize returns the number of bytes in the active log file as
+// tracked by the Logger (existing content plus writes since it was
+// opened). It drops to zero on rotation. Safe to call concurrently with writes.
+func (l *Logger) CurrentSize() uint64 {
+	return l.bytesWritten.Load()
+}
+
 // Stats represents comprehensive logger statistics for telemetry and monitoring.
 // These metrics provide insights into logger performance, buffer utilization,
 // and system behavior for operational monitoring and performance tuning.
@@ -1313,9 +2740,12 @@ func (l *Logger) WaitForBackgroundTasks() {
 // The statistics are collected with minimal overhead and are safe to query
 // frequently for real-time monitoring dashboards.
 type Stats struct {
+	// Name() of the Logger, for labeling metrics from several Loggers
+	Name string `json:"name"`
+
 	// Write statistics
 	WriteCount    uint64 `json:"write_count"`     // Total number of writes
-	TotalBytes    uint64 `json:"total_bytes"`     // Total bytes written
+	TotalBytes    uint64 `json:"total_bytes"`     // Bytes written to disk by this Logger, across rotations
 	AvgLatencyNs  uint64 `json:"avg_latency_ns"`  // Average write latency in nanoseconds
 	LastLatencyNs uint64 `json:"last_latency_ns"` // Last write latency in nanoseconds
 
@@ -1324,19 +2754,68 @@ type Stats struct {
 	ContentionRatio float64 `json:"contention_ratio"` // Contention ratio (0.0-1.0)
 
 	// Rotation statistics
-	RotationCount   uint64 `json:"rotation_count"`    // Number of rotations performed
-	CurrentFileSize uint64 `json:"current_file_size"` // Current file size in bytes
+	RotationCount     uint64 `json:"rotation_count"`     // Number of rotations performed
+	SizeRotations     uint64 `json:"size_rotations"`     // Rotations triggered by MaxSize
+	TimeRotations     uint64 `json:"time_rotations"`     // AgeRotations + CalendarRotations
+	AgeRotations      uint64 `json:"age_rotations"`      // Rotations triggered by MaxAge
+	CalendarRotations uint64 `json:"calendar_rotations"` // Rotations triggered by RotateAt
+	LineRotations     uint64 `json:"line_rotations"`     // Rotations triggered by MaxLines
+	ManualRotations   uint64 `json:"manual_rotations"`   // Rotate, RotateSync and RotateNamed calls
+	CustomRotations   uint64 `json:"custom_rotations"`   // Rotations triggered by RotateWhen
+	CurrentFileSize   uint64 `json:"current_file_size"`  // Current file size in bytes
 
 	// MPSC buffer statistics
-	BufferSize    uint64 `json:"buffer_size"`     // Current buffer size
+	BufferSize    uint64 `json:"buffer_size"`     // Ring capacity in slots: BufferSize rounded up to a power of 2 and MinBufferSize
 	BufferFill    uint64 `json:"buffer_fill"`     // Current buffer fill level (tail-head)
 	IsMPSCActive  bool   `json:"is_mpsc_active"`  // Whether MPSC mode is active
 	DroppedOnFull uint64 `json:"dropped_on_full"` // Messages dropped due to full buffer
+	SampledOut    uint64 `json:"sampled_out"`     // Writes discarded by SampleRate / MaxWritesPerSecond
+	SpilledCount  uint64 `json:"spilled_count"`   // Messages spilled to disk by the "overflow" policy
+	SpillBytes    int64  `json:"spill_bytes"`     // Current size of the spill file
+	BufferedBytes int64  `json:"buffered_bytes"`  // Bytes currently enqueued (not yet written)
+
+	DedupSuppressed  uint64 `json:"dedup_suppressed"`  // Identical writes suppressed by Dedup
+	TransformDropped uint64 `json:"transform_dropped"` // Writes dropped by Transform returning nil
+
+	// Drop breakdown: DroppedOnFull is DroppedBufferFull + DroppedOverflowCap,
+	// SampledOut is DroppedSampled + DroppedRateLimited
+	DroppedBufferFull  uint64 `json:"dropped_buffer_full"`  // "drop" BackpressurePolicy on a full buffer
+	DroppedOverflowCap uint64 `json:"dropped_overflow_cap"` // "overflow" policy beyond MaxSpillBytes
+	DroppedSampled     uint64 `json:"dropped_sampled"`      // Discarded by SampleRate
+	DroppedRateLimited uint64 `json:"dropped_rate_limited"` // Discarded by MaxWritesPerSecond
+	DroppedBytes       uint64 `json:"dropped_bytes"`        // Bytes of every write counted above
+
+	// Auto-scaling statistics
+	EffectiveMode  string `json:"effective_mode"`   // "mpsc" or "sync"
+	ScaleUpCount   uint64 `json:"scale_up_count"`   // Auto-scale sync -> MPSC transitions
+	ScaleDownCount uint64 `json:"scale_down_count"` // Auto-scale MPSC -> sync transitions
+
+	// Durability statistics
+	FsyncCount  uint64 `json:"fsync_count"`  // Number of fsync calls performed
+	WriteErrors uint64 `json:"write_errors"` // Failed writes, flushes and fsyncs of the log file (I/O errors)
+
+	// Compression statistics
+	UncompressedBytes uint64  `json:"uncompressed_bytes"` // Size of backups before compression, in total
+	CompressedBytes   uint64  `json:"compressed_bytes"`   // Size of the same backups after compression
+	CompressionRatio  float64 `json:"compression_ratio"`  // CompressedBytes / UncompressedBytes (0 before the first compression)
+
+	// Background task statistics
+	TaskQueueDepth     int    `json:"task_queue_depth"`    // Post-rotation tasks waiting for a worker
+	DroppedTasks       uint64 `json:"dropped_tasks"`       // Tasks dropped because the queue was full
+	ActiveCompressions int64  `json:"active_compressions"` // Compressions in progress (see MaxCompressions)
+
+	// Shutdown statistics, filled in by Close (see CloseStats)
+	ShutdownPending uint64 `json:"shutdown_pending"`  // Messages buffered when Close began
+	ShutdownFlushed uint64 `json:"shutdown_flushed"`  // Buffered messages written while draining
+	ShutdownLost    uint64 `json:"shutdown_lost"`     // Buffered messages not written (drain failed or timed out)
+	ShutdownDrainNs uint64 `json:"shutdown_drain_ns"` // Time spent draining, in nanoseconds
 
 	// Timestamps for observability
 	LastWriteTime time.Time `json:"last_write_time"` // Time of last successful write
 	LastDropTime  time.Time `json:"last_drop_time"`  // Time of last message drop (if any)
 
+	LastWriteErrorTime time.Time `json:"last_write_error_time"` // Time of the last failure counted in WriteErrors
+
 	// Configuration
 	MaxSizeBytes       int64   `json:"max_size_bytes"`      // Configured max file size
 	BackpressurePolicy string  `json:"backpressure_policy"` // Current backpressure policy
@@ -1368,13 +2847,24 @@ type Stats struct {
 //
 // Metrics include:
 //   - WriteCount: Total number of Write() calls
-//   - TotalBytes: Cumulative bytes written
+//   - TotalBytes: Exact bytes written by this Logger, across all files
 //   - AvgLatencyNs: Average write latency in nanoseconds
 //   - ContentionRatio: Ratio of contended writes (0.0-1.0)
 //   - BufferSize: MPSC buffer capacity
 //   - BufferFill: Current buffer utilization
 //   - DroppedOnFull: Messages dropped due to buffer overflow
+//   - DroppedBufferFull, DroppedOverflowCap, DroppedSampled,
+//     DroppedRateLimited, DroppedBytes: Discarded writes by cause, and
+//     their total size; separates intentional shedding from data loss
 //   - RotationCount: Number of file rotations performed
+//   - SizeRotations, AgeRotations, CalendarRotations, LineRotations,
+//     ManualRotations, CustomRotations: Rotations triggered by MaxSize,
+//     MaxAge, RotateAt, MaxLines, the Rotate methods and RotateWhen. Each
+//     rotation counts under exactly one of them (the final CompressOnClose
+//     rotation under none), so they show which limit does the work
+//   - TimeRotations: AgeRotations + CalendarRotations
+//   - UncompressedBytes / CompressedBytes / CompressionRatio: Totals over
+//     every compressed backup; 1 - CompressionRatio is the space saved
 //
 // Performance monitoring example:
 //
@@ -1412,15 +2902,16 @@ func (l *Logger) Stats() Stats {
 	var bufferSize, bufferFill uint64
 	isMPSCActive := false
 	if buffer := l.buffer.Load(); buffer != nil {
-		bufferSize = uint64(len(buffer.buffer))
+		bufferSize = buffer.slots()
 		isMPSCActive = true
 
 		// Calculate buffer fill level
-		head := buffer.head.Load()
-		tail := buffer.tail.Load()
-		if tail >= head {
-			bufferFill = tail - head
-		}
+		bufferFill = buffer.queued()
+	}
+
+	effectiveMode := "sync"
+	if isMPSCActive {
+		effectiveMode = "mpsc"
 	}
 
 	flushIntervalMs := float64(l.FlushInterval.Nanoseconds()) / 1e6
@@ -1428,15 +2919,6 @@ func (l *Logger) Stats() Stats {
 		flushIntervalMs = 1.0 // Default 1ms
 	}
 
-	// Calculate total bytes as a rough estimate
-	// Note: This is current file size + estimated bytes from rotations
-	totalBytes := l.bytesWritten.Load()
-	maxSize := l.maxSizeBytes.Load()
-	if rotationCount := l.rotationSeq.Load(); rotationCount > 0 && maxSize > 0 {
-		// Rough estimate: rotations * average file size
-		totalBytes += rotationCount * uint64(maxSize)
-	}
-
 	// Convert timestamps from atomic int64 (unix nano) to time.Time
 	var lastWriteTime, lastDropTime time.Time
 	if lwt := l.lastWriteTime.Load(); lwt > 0 {
@@ -1445,23 +2927,80 @@ func (l *Logger) Stats() Stats {
 	if ldt := l.lastDropTime.Load(); ldt > 0 {
 		lastDropTime = time.Unix(0, ldt)
 	}
+	var lastWriteErrorTime time.Time
+	if lwe := l.lastWriteErrorTime.Load(); lwe > 0 {
+		lastWriteErrorTime = time.Unix(0, lwe)
+	}
+
+	var taskQueueDepth int
+	if workers := l.bgWorkers.Load(); workers != nil {
+		taskQueueDepth = len(workers.taskQueue)
+	}
+
+	var spillBytes int64
+	if s := l.spill.Load(); s != nil {
+		spillBytes = s.size.Load()
+	}
+
+	uncompressed, compressed := l.uncompressedBytes.Load(), l.compressedBytes.Load()
+	var compressionRatio float64
+	if uncompressed > 0 {
+		compressionRatio = float64(compressed) / float64(uncompressed)
+	}
+
+	ageRotations, calendarRotations := l.ageRotations.Load(), l.calendarRotations.Load()
 
 	return Stats{
+		Name:               l.Name(),
 		WriteCount:         writeCount,
-		TotalBytes:         totalBytes,
+		TotalBytes:         l.totalWritten.Load(),
 		AvgLatencyNs:       avgLatency,
 		LastLatencyNs:      l.lastLatency.Load(),
 		ContentionCount:    contentionCount,
 		ContentionRatio:    contentionRatio,
 		RotationCount:      l.rotationSeq.Load(),
+		SizeRotations:      l.sizeRotations.Load(),
+		TimeRotations:      ageRotations + calendarRotations,
+		AgeRotations:       ageRotations,
+		CalendarRotations:  calendarRotations,
+		LineRotations:      l.lineRotations.Load(),
+		ManualRotations:    l.manualRotations.Load(),
+		CustomRotations:    l.customRotations.Load(),
 		CurrentFileSize:    l.bytesWritten.Load(),
 		BufferSize:         bufferSize,
 		BufferFill:         bufferFill,
 		IsMPSCActive:       isMPSCActive,
 		DroppedOnFull:      l.droppedCount.Load(),
+		SampledOut:         l.sampledOut.Load(),
+		SpilledCount:       l.spilledCount.Load(),
+		SpillBytes:         spillBytes,
+		DedupSuppressed:    l.dedupSuppressed.Load(),
+		TransformDropped:   l.transformDropped.Load(),
+		DroppedBufferFull:  l.droppedFull.Load(),
+		DroppedOverflowCap: l.droppedOverflow.Load(),
+		DroppedSampled:     l.droppedSampled.Load(),
+		DroppedRateLimited: l.droppedRateLimited.Load(),
+		DroppedBytes:       l.droppedBytes.Load(),
+		BufferedBytes:      l.bufferedBytes.Load(),
+		EffectiveMode:      effectiveMode,
+		ScaleUpCount:       l.scaleUps.Load(),
+		ScaleDownCount:     l.scaleDowns.Load(),
+		FsyncCount:         l.fsyncCount.Load(),
+		WriteErrors:        l.writeErrors.Load(),
+		UncompressedBytes:  uncompressed,
+		CompressedBytes:    compressed,
+		CompressionRatio:   compressionRatio,
+		TaskQueueDepth:     taskQueueDepth,
+		DroppedTasks:       l.droppedTasks.Load(),
+		ActiveCompressions: l.activeCompressions.Load(),
+		ShutdownPending:    l.shutdownPending.Load(),
+		ShutdownFlushed:    l.shutdownFlushed.Load(),
+		ShutdownLost:       l.shutdownLost.Load(),
+		ShutdownDrainNs:    l.shutdownDrainNs.Load(),
 		LastWriteTime:      lastWriteTime,
 		LastDropTime:       lastDropTime,
-		MaxSizeBytes:       l.maxSizeBytes.Load(),
+		LastWriteErrorTime: lastWriteErrorTime,
+		MaxSizeBytes:       max(l.maxSizeBytes.Load(), 0),
 		BackpressurePolicy: l.BackpressurePolicy,
 		FlushIntervalMs:    flushIntervalMs,
 	}
@@ -1517,10 +3056,91 @@ func (l *Logger) Stats() Stats {
 //	logger.Rotate() // Create fresh log for maintenance
 //	logger.Write([]byte("Maintenance completed\n"))
 func (l *Logger) Rotate() error {
-	l.triggerRotation()
+	l.triggerRotation(rotateManual)
 	return nil
 }
 
+// RotateSync rotates like Rotate, then blocks until the background tasks
+// scheduled by this rotation (cleanup, checksum, compression, encryption)
+// have finished. Unlike Rotate it never skips: if another rotation is in
+// progress it waits for it and then rotates again.
+//
+// Use it in tooling that rotates and then inspects the resulting files,
+// instead of sleeping. Tasks from other rotations are not waited for.
+//
+// Returns the rotation error, or the errors of this rotation's background
+// tasks joined with errors.Join. All errors are also reported via ErrorCallback.
+//
+// Example:
+//
+//	if err := logger.RotateSync(); err != nil {
+//		log.Printf("Rotation failed: %v", err)
+//	}
+//	// The backup is now compressed and checksummed
+func (l *Logger) RotateSync() error {
+	if !l.enterFS() {
+		return ErrPaused
+	}
+	defer l.exitFS()
+
+	l.claimRotation()
+	tasks := &rotationTasks{}
+	_, err := l.performRotationWith(tasks, rotateManual)
+	l.rotationFlag.Store(false)
+	if err != nil {
+		l.reportError("rotation", err)
+		return err
+	}
+	return tasks.wait()
+}
+
+// RotateNamed rotates like Rotate and returns the path of the backup it
+// created, so custom archival can move or upload exactly that file.
+// If another rotation is in progress it waits for it and then rotates again.
+//
+// The returned name is always the uncompressed one: with Compress (or an
+// Encryptor) the background worker later replaces it with name + ".gz"
+// (or the Compression codec's extension, and/or ".enc"); use RotateSync instead if the final file must exist.
+//
+// Returns "" and a nil error when there was nothing to rotate: no file has
+// been opened yet, the active file is empty, or (with MultiProcess) another
+// process rotated it first. Async writes still in the buffer do not count;
+// call Sync first to include them.
+//
+// Example:
+//
+//	backup, err := logger.RotateNamed()
+//	if err == nil && backup != "" {
+//		go upload(backup)
+//	}
+func (l *Logger) RotateNamed() (string, error) {
+	if !l.enterFS() {
+		return "", ErrPaused
+	}
+	defer l.exitFS()
+
+	l.claimRotation()
+	defer l.rotationFlag.Store(false)
+
+	if l.currentFile.Load() == nil || l.bytesWritten.Load() == 0 {
+		return "", nil
+	}
+	backup, err := l.performRotationWith(nil, rotateManual)
+	if err != nil {
+		l.reportError("rotation", err)
+		return "", err
+	}
+	return backup, nil
+}
+
+// claimRotation waits until this goroutine owns the rotation flag.
+// The caller must clear it when done.
+func (l *Logger) claimRotation() {
+	for !l.rotationFlag.CompareAndSwap(false, true) {
+		time.Sleep(time.Millisecond) // Another rotation is running
+	}
+}
+
 // Sync ensures all buffered data is written to disk.
 // For async mode, drains the ring buffer and calls fsync.
 // For sync mode, just calls fsync on the current file.
@@ -1534,6 +3154,11 @@ func (l *Logger) Rotate() error {
 //	logger.Sync() // Ensure event is on disk
 //	// Now safe to proceed
 func (l *Logger) Sync() error {
+	if !l.enterFS() {
+		return ErrPaused
+	}
+	defer l.exitFS()
+
 	// For async mode, flush the ring buffer first
 	if l.Async {
 		if consumer := l.consumer.Load(); consumer != nil {
@@ -1541,10 +3166,18 @@ func (l *Logger) Sync() error {
 		}
 	}
 
+	if err := l.flushSyncBuffer(); err != nil {
+		l.reportWriteError("buffered_flush", err)
+		return err
+	}
+
 	// Call fsync on the file
 	file := l.currentFile.Load()
 	if file != nil {
-		return file.Sync()
+		if err := l.fsync(file); err != nil {
+			l.reportWriteError("fsync", err)
+			return err
+		}
 	}
 	return nil
 }
@@ -1572,8 +3205,11 @@ func (l *Logger) FlushAndRotate() error {
 
 // reportError invokes the error callback if set
 func (l *Logger) reportError(operation string, err error) {
-	if l.ErrorCallback != nil {
-		l.ErrorCallback(operation, err)
+	if callback := l.errorCallbackFunc(); callback != nil {
+		callback(operation, err)
+	}
+	if l.OnError != nil {
+		l.invokeOnError(operation, err)
 	}
 }
 
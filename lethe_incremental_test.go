@@ -34,9 +34,17 @@ func TestWriteAsyncOwned_MissingBranches(t *testing.T) {
 			BackpressurePolicy: "fallback",
 		}
 
-		logger, err := NewWithConfig(config)
-		if err != nil {
-			t.Fatalf("Error creating logger: %v", err)
+		// Rejected up front by ValidateConfig...
+		if _, err := NewWithConfig(config); err == nil {
+			t.Fatal("NewWithConfig accepted a negative BufferSize")
+		}
+
+		// ...while a struct-literal Logger falls back to the default size
+		logger := &Logger{
+			Filename:           config.Filename,
+			Async:              config.Async,
+			BufferSize:         config.BufferSize,
+			BackpressurePolicy: config.BackpressurePolicy,
 		}
 		defer func() { _ = logger.Close() }()
 
@@ -1260,7 +1268,7 @@ func TestTriggerRotation_AllBranches(t *testing.T) {
 		logger.rotationFlag.Store(true)
 
 		// Now trigger rotation - should fail CAS and return immediately
-		logger.triggerRotation()
+		logger.triggerRotation(rotateManual)
 
 		// Flag should still be true (we set it manually)
 		if !logger.rotationFlag.Load() {
@@ -1301,7 +1309,7 @@ func TestTriggerRotation_AllBranches(t *testing.T) {
 		}
 
 		// Trigger rotation - should handle error gracefully
-		logger.triggerRotation()
+		logger.triggerRotation(rotateManual)
 
 		// Verify that error was captured (might be nil if rotation succeeds unexpectedly)
 		if capturedOperation == "rotation" && capturedError != nil {
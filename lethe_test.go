@@ -18,6 +18,22 @@ func generateTestFile(testName string) string {
 	return filepath.Join(os.TempDir(), fmt.Sprintf("lethe_test_%s_%d.log", testName, time.Now().UnixNano()))
 }
 
+// newTestLogger creates a Logger from config and closes it when the test
+// ends. An empty Filename becomes app.log in a fresh t.TempDir(); the path
+// used is left in config.Filename.
+func newTestLogger(t *testing.T, config *LoggerConfig) *Logger {
+	t.Helper()
+	if config.Filename == "" {
+		config.Filename = filepath.Join(t.TempDir(), "app.log")
+	}
+	logger, err := NewWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewWithConfig: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	return logger
+}
+
 // cleanupTestFiles removes all test log files
 func cleanupTestFiles() {
 	patterns := []string{"test*.log*", "*.log"}
@@ -1118,6 +1134,8 @@ func TestRotation_TimeBasedTrigger(t *testing.T) {
 		MaxSize:  100,                    // Large size so rotation is only time-based
 		MaxAge:   200 * time.Millisecond, // Very short for testing
 	}
+	clk := newFakeClock()
+	logger.setClock(clk)
 
 	// Write initial data
 	_, err := logger.Write([]byte("Initial log entry\n"))
@@ -1125,8 +1143,8 @@ func TestRotation_TimeBasedTrigger(t *testing.T) {
 		t.Fatalf("Initial write failed: %v", err)
 	}
 
-	// Wait for time-based rotation to trigger
-	time.Sleep(250 * time.Millisecond)
+	// Move past MaxAge without sleeping
+	clk.Advance(time.Second)
 
 	// Write another entry which should trigger rotation due to age
 	_, err = logger.Write([]byte("Entry after age threshold\n"))
@@ -1134,9 +1152,6 @@ func TestRotation_TimeBasedTrigger(t *testing.T) {
 		t.Fatalf("Second write failed: %v", err)
 	}
 
-	// Give rotation some time to complete
-	time.Sleep(50 * time.Millisecond)
-
 	// Should have created a backup file due to age
 	matches, err := filepath.Glob(testFile + ".*")
 	if err != nil {
@@ -1351,6 +1366,7 @@ func TestNewFunctions(t *testing.T) {
 		{"NewWithDefaults", NewWithDefaults},
 		{"NewDaily", NewDaily},
 		{"NewWeekly", NewWeekly},
+		{"NewMonthly", NewMonthly},
 		{"NewDevelopment", NewDevelopment},
 	}
 
@@ -1423,6 +1439,7 @@ func TestNewFunctionsEmptyFilename(t *testing.T) {
 		{"NewWithDefaults", func() (*Logger, error) { return NewWithDefaults("") }},
 		{"NewDaily", func() (*Logger, error) { return NewDaily("") }},
 		{"NewWeekly", func() (*Logger, error) { return NewWeekly("") }},
+		{"NewMonthly", func() (*Logger, error) { return NewMonthly("") }},
 		{"NewDevelopment", func() (*Logger, error) { return NewDevelopment("") }},
 		{"NewSimple", func() (*Logger, error) { return NewSimple("", "100MB", 5) }},
 	}
@@ -3727,11 +3744,15 @@ func TestRotationTriggerAndPerform(t *testing.T) {
 			t.Fatalf("Failed to init file: %v", err)
 		}
 
+		// Hold the claim as an in-flight rotation would; rotation is too
+		// fast for goroutine start-up alone to make the triggers overlap
+		logger.rotationFlag.Store(true)
+
 		// Simulate concurrent rotation triggers
 		done := make(chan bool, 10)
 		for i := 0; i < 10; i++ {
 			go func() {
-				logger.triggerRotation()
+				logger.triggerRotation(rotateManual)
 				done <- true
 			}()
 		}
@@ -3741,10 +3762,15 @@ func TestRotationTriggerAndPerform(t *testing.T) {
 			<-done
 		}
 
-		// Only one rotation should have occurred
-		rotationCount := logger.rotationSeq.Load()
-		if rotationCount > 1 {
-			t.Errorf("Expected at most 1 rotation, got %d", rotationCount)
+		// None may start while another rotation holds the claim
+		if rotationCount := logger.rotationSeq.Load(); rotationCount != 0 {
+			t.Errorf("Expected no rotation while claimed, got %d", rotationCount)
+		}
+
+		logger.rotationFlag.Store(false)
+		logger.triggerRotation(rotateManual)
+		if rotationCount := logger.rotationSeq.Load(); rotationCount != 1 {
+			t.Errorf("Expected 1 rotation once released, got %d", rotationCount)
 		}
 	})
 
@@ -3756,7 +3782,7 @@ func TestRotationTriggerAndPerform(t *testing.T) {
 		defer func() { _ = logger.Close() }()
 
 		// Don't initialize file, so currentFile is nil
-		err := logger.performRotation()
+		err := logger.performRotation(rotateManual)
 		if err == nil {
 			t.Error("Expected error when no current file exists")
 		}
@@ -3780,7 +3806,7 @@ func TestRotationTriggerAndPerform(t *testing.T) {
 		}
 
 		// Perform rotation
-		err := logger.performRotation()
+		err := logger.performRotation(rotateManual)
 		if err != nil {
 			t.Fatalf("performRotation failed: %v", err)
 		}
@@ -3860,7 +3886,7 @@ func TestCompressionAndBackgroundTasks(t *testing.T) {
 		}
 
 		// Perform rotation to trigger compression
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Fatalf("performRotation failed: %v", err)
 		}
 
@@ -3902,7 +3928,7 @@ func TestCompressionAndBackgroundTasks(t *testing.T) {
 		}
 
 		// Trigger rotation to initialize background workers
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Fatalf("performRotation failed: %v", err)
 		}
 
@@ -4478,7 +4504,7 @@ func TestCriticalFunctionsCoverage(t *testing.T) {
 		}
 
 		// Force rotation - this should trigger compression
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation failed (expected for compression test): %v", err)
 		}
 
@@ -4586,7 +4612,7 @@ func TestCriticalFunctionsCoverage(t *testing.T) {
 		}
 
 		// Force rotation - this should trigger checksum generation
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation with checksum failed: %v", err)
 		}
 
@@ -4836,7 +4862,7 @@ func TestCompressFile_ErrorPaths(t *testing.T) {
 		}
 
 		// Force rotation to trigger compression
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation failed (may be expected): %v", err)
 		}
 
@@ -5308,7 +5334,7 @@ func TestUltraCriticalCoverage(t *testing.T) {
 		}
 
 		// Force rotation to create a backup file
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation failed: %v", err)
 		}
 
@@ -5333,7 +5359,7 @@ func TestUltraCriticalCoverage(t *testing.T) {
 			t.Fatalf("Write failed: %v", err)
 		}
 
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation failed: %v", err)
 		}
 
@@ -6048,7 +6074,7 @@ func TestCompressFile_Comprehensive(t *testing.T) {
 		}
 
 		// Trigger rotation which should compress the file
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation failed (may be expected): %v", err)
 		}
 
@@ -6083,7 +6109,7 @@ func TestCompressFile_Comprehensive(t *testing.T) {
 		}
 
 		// This might trigger compression errors due to file conflicts
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation with conflict failed: %v", err)
 		}
 
@@ -6108,7 +6134,7 @@ func TestCompressFile_Comprehensive(t *testing.T) {
 		}
 
 		// Try to trigger compression - may fail due to permissions
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("performRotation permission test: %v", err)
 		}
 
@@ -6138,7 +6164,7 @@ func TestCompressFile_Comprehensive(t *testing.T) {
 		}
 
 		// Trigger compression of larger file
-		if err := logger.performRotation(); err != nil {
+		if err := logger.performRotation(rotateManual); err != nil {
 			t.Logf("Large file compression failed: %v", err)
 		}
 
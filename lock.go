@@ -0,0 +1,67 @@
+// lock.go: Cross-process rotation lock (MultiProcess)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+)
+
+// lockSuffix is appended to Filename for the rotation lock file.
+const lockSuffix = ".lock"
+
+// lockPath returns the path of the advisory rotation lock file.
+func (l *Logger) lockPath() string {
+	return l.Filename + lockSuffix
+}
+
+// lockRotation takes the cross-process rotation lock, blocking until no
+// other process holds it. The returned function releases it.
+//
+// WHY a separate lock file: the active file is renamed during rotation, so
+// a lock held on it would move with the backup. The lock file is never
+// removed, because unlinking it could let two processes lock different inodes.
+func (l *Logger) lockRotation() (func(), error) {
+	_, _, fileMode := l.getRetryConfig()
+	file, err := os.OpenFile(l.lockPath(), os.O_CREATE|os.O_RDWR, fileMode) // #nosec G304 -- derived from l.Filename, controlled by application
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rotation lock %s: %v", l.lockPath(), err)
+	}
+	if err := lockFile(file); err != nil {
+		_ = file.Close() // Ignore close error during cleanup
+		return nil, fmt.Errorf("failed to lock %s: %v", l.lockPath(), err)
+	}
+	return func() {
+		_ = unlockFile(file) // Closing the file releases the lock anyway
+		_ = file.Close()
+	}, nil
+}
+
+// reopenAfterPeerRotation switches to the file another process created by
+// rotating Filename while we waited for the lock. Renaming again would
+// rotate the peer's fresh file, so only the handle is replaced.
+func (l *Logger) reopenAfterPeerRotation(oldFile *os.File) error {
+	_, _, fileMode := l.getRetryConfig()
+	newFile, err := openAppend(l.Filename, fileMode)
+	if err != nil {
+		return &RotationError{Op: "reopen", Path: l.Filename, Err: fmt.Errorf("file rotated by another process: %w", err)}
+	}
+
+	var size uint64
+	if info, err := newFile.Stat(); err == nil && info.Size() > 0 {
+		size = uint64(info.Size()) // #nosec G115 -- checked positive above
+	}
+
+	l.currentFile.Store(newFile)
+	_ = oldFile.Close() // Old inode is now a peer's backup
+
+	l.bytesWritten.Store(size)
+	l.lineCount.Store(0)
+	l.fileCreated.Store(l.now().Unix())
+	l.updateSymlink()
+	return nil
+}
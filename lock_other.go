@@ -0,0 +1,17 @@
+// lock_other.go: Rotation lock fallback for platforms without file locking
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !unix && !windows
+
+package lethe
+
+import "os"
+
+// lockFile is a no-op: MultiProcess gives no cross-process guarantee here.
+func lockFile(file *os.File) error { return nil }
+
+// unlockFile is a no-op counterpart of lockFile.
+func unlockFile(file *os.File) error { return nil }
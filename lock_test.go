@@ -0,0 +1,105 @@
+// lock_test.go: Tests for MultiProcess rotation locking
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newMultiProcessLogger(t *testing.T, logFile string) *Logger {
+	t.Helper()
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, MultiProcess: true, MaxBackups: 5})
+	return logger
+}
+
+func TestMultiProcess_PeerRotationIsFollowedNotRepeated(t *testing.T) {
+	// Two loggers on one file stand in for two processes
+	logFile := filepath.Join(t.TempDir(), "shared.log")
+	a := newMultiProcessLogger(t, logFile)
+	b := newMultiProcessLogger(t, logFile)
+
+	if _, err := a.Write([]byte("from a\n")); err != nil {
+		t.Fatalf("a.Write: %v", err)
+	}
+	if _, err := b.Write([]byte("from b\n")); err != nil {
+		t.Fatalf("b.Write: %v", err)
+	}
+	if err := a.RotateSync(); err != nil {
+		t.Fatalf("a.RotateSync: %v", err)
+	}
+
+	// b still holds the rotated inode; its rotation must not rename a's new file
+	if err := b.RotateSync(); err != nil {
+		t.Fatalf("b.RotateSync: %v", err)
+	}
+	if backups, _ := filepath.Glob(logFile + ".*-*"); len(backups) != 1 {
+		t.Fatalf("backups = %v, want exactly one", backups)
+	}
+
+	// Both now append to the same active file
+	if _, err := b.Write([]byte("b after\n")); err != nil {
+		t.Fatalf("b.Write: %v", err)
+	}
+	if _, err := a.Write([]byte("a after\n")); err != nil {
+		t.Fatalf("a.Write: %v", err)
+	}
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got := string(data); !strings.Contains(got, "b after") || !strings.Contains(got, "a after") {
+		t.Errorf("active file = %q, want writes from both loggers", got)
+	}
+}
+
+func TestMultiProcess_RotationWaitsForLock(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "windows" {
+		t.Skip("file locking not exercised on this platform")
+	}
+
+	logFile := filepath.Join(t.TempDir(), "locked.log")
+	logger := newMultiProcessLogger(t, logFile)
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Hold the lock as a peer process would
+	unlock, err := logger.lockRotation()
+	if err != nil {
+		t.Fatalf("lockRotation: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- logger.RotateSync() }()
+
+	select {
+	case err := <-done:
+		unlock()
+		t.Fatalf("rotation finished while the lock was held (err=%v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("rotation did not proceed after the lock was released")
+	}
+
+	// The lock file survives rotation and retention cleanup
+	if _, err := os.Stat(logFile + lockSuffix); err != nil {
+		t.Errorf("lock file missing: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+// lumberjack.go: Migration shim for gopkg.in/natefinch/lumberjack.v2
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LumberjackLogger lets code that names *lumberjack.Logger in signatures
+// and struct fields compile against Lethe. Struct literals are another
+// matter: Logger.MaxAge is a rotation age in nanoseconds, while
+// lumberjack's MaxAge is a backup retention age in days, so a literal
+// like &lumberjack.Logger{MaxAge: 28} would rotate every 28ns. Build
+// such loggers with NewLumberjack instead.
+type LumberjackLogger = Logger
+
+// LumberjackConfig has the fields of lumberjack.Logger, with their
+// lumberjack meaning, for NewLumberjack.
+type LumberjackConfig struct {
+	// Filename is the file to write to. Empty uses
+	// <processname>-lumberjack.log in os.TempDir(), as lumberjack does.
+	Filename string `json:"filename"`
+
+	// MaxSize is the size in megabytes before the file is rotated
+	// (default: 100).
+	MaxSize int `json:"maxsize"`
+
+	// MaxAge is the number of days to retain old log files, by the time
+	// encoded in their name. 0 keeps them regardless of age.
+	MaxAge int `json:"maxage"`
+
+	// MaxBackups is the number of old log files to retain. 0 keeps all.
+	MaxBackups int `json:"maxbackups"`
+
+	// LocalTime formats backup timestamps in local time instead of UTC.
+	LocalTime bool `json:"localtime"`
+
+	// Compress gzips rotated files.
+	Compress bool `json:"compress"`
+}
+
+// lumberjackDefaultMaxSize is lumberjack's MaxSize when unset, in MB.
+const lumberjackDefaultMaxSize = 100
+
+// NewLumberjack creates a Logger configured like the lumberjack.Logger
+// with the same fields, so a migration is a mechanical rewrite:
+//
+//	// Before
+//	logger := &lumberjack.Logger{Filename: "app.log", MaxSize: 100, MaxAge: 28}
+//
+//	// After
+//	logger, err := lethe.NewLumberjack(lethe.LumberjackConfig{Filename: "app.log", MaxSize: 100, MaxAge: 28})
+//
+// MaxAge becomes MaxFileAge (backup retention), not Lethe's MaxAge, so,
+// as with lumberjack, the file rotates on size only; opt into age or
+// calendar rotation with SetMaxAge or the Lethe fields afterwards.
+//
+// The one difference to plan for is backup naming: Lethe names backups
+// "app.log.2006-01-02-15-04-05" where lumberjack used
+// "app-2006-01-02T15-04-05.000.log", so log shippers matching backups by
+// pattern need updating, and backups left by lumberjack are not pruned
+// by MaxBackups or MaxAge.
+func NewLumberjack(config LumberjackConfig) (*Logger, error) {
+	filename := config.Filename
+	if filename == "" {
+		filename = filepath.Join(os.TempDir(), filepath.Base(os.Args[0])+"-lumberjack.log")
+	}
+	maxSize := config.MaxSize
+	if maxSize <= 0 {
+		maxSize = lumberjackDefaultMaxSize
+	}
+	var maxFileAge time.Duration
+	if config.MaxAge > 0 {
+		maxFileAge = time.Duration(config.MaxAge) * 24 * time.Hour
+	}
+
+	return NewWithConfig(&LoggerConfig{
+		Filename:   filename,
+		MaxSize:    int64(maxSize),
+		MaxBackups: config.MaxBackups,
+		MaxFileAge: maxFileAge,
+		LocalTime:  config.LocalTime,
+		Compress:   config.Compress,
+	})
+}
@@ -0,0 +1,77 @@
+// lumberjack_test.go: Tests for the lumberjack migration shim
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewLumberjack_TypicalConfig mirrors the configuration shown in
+// lumberjack's README.
+func TestNewLumberjack_TypicalConfig(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "foo.log")
+	logger, err := NewLumberjack(LumberjackConfig{
+		Filename:   logFile,
+		MaxSize:    1, // megabytes
+		MaxBackups: 3,
+		MaxAge:     28, // days
+		Compress:   true,
+	})
+	if err != nil {
+		t.Fatalf("NewLumberjack: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	// Code typed against *lumberjack.Logger keeps compiling
+	var lj *LumberjackLogger = logger
+	var _ io.WriteCloser = lj
+
+	if logger.MaxFileAge != 28*24*time.Hour {
+		t.Errorf("MaxFileAge = %v, want 28 days of backup retention", logger.MaxFileAge)
+	}
+	if logger.MaxAge != 0 || logger.MaxAgeStr != "" {
+		t.Errorf("MaxAge = %v, MaxAgeStr = %q; want no age-based rotation", logger.MaxAge, logger.MaxAgeStr)
+	}
+
+	line := strings.Repeat("x", 1023) + "\n"
+	for i := 0; i < 1100; i++ { // Just over 1MB
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	stats := logger.Stats()
+	if stats.SizeRotations != 1 || stats.RotationCount != 1 {
+		t.Errorf("SizeRotations = %d, RotationCount = %d; want one size rotation",
+			stats.SizeRotations, stats.RotationCount)
+	}
+}
+
+func TestNewLumberjack_Defaults(t *testing.T) {
+	logger, err := NewLumberjack(LumberjackConfig{})
+	if err != nil {
+		t.Fatalf("NewLumberjack: %v", err)
+	}
+	defer func() {
+		_ = logger.Close()
+		_ = os.Remove(logger.Filename)
+	}()
+
+	if !strings.HasSuffix(logger.Filename, "-lumberjack.log") {
+		t.Errorf("Filename = %q, want <processname>-lumberjack.log", logger.Filename)
+	}
+	if logger.MaxSize != 100 {
+		t.Errorf("MaxSize = %d, want lumberjack's default of 100MB", logger.MaxSize)
+	}
+	if logger.MaxFileAge != 0 || logger.MaxBackups != 0 {
+		t.Errorf("MaxFileAge = %v, MaxBackups = %d; want every backup kept", logger.MaxFileAge, logger.MaxBackups)
+	}
+}
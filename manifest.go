@@ -0,0 +1,192 @@
+// manifest.go: JSON-lines inventory of rotated backups (Manifest)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// manifestSuffix is appended to Filename for the backup manifest.
+const manifestSuffix = ".manifest"
+
+// BackupInfo is one manifest entry: a backup in its final form, after
+// compression and encryption.
+type BackupInfo struct {
+	Path       string    `json:"path"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"modtime"`
+	SHA256     string    `json:"sha256"`
+	Compressed bool      `json:"compressed"`
+	Encrypted  bool      `json:"encrypted,omitempty"`
+}
+
+// manifestPath returns the path of the backup manifest.
+func (l *Logger) manifestPath() string {
+	return l.Filename + manifestSuffix
+}
+
+// recordBackup appends the final form of the backup rotated to base to the
+// manifest. Runs on a background worker once compression and encryption
+// for that backup are done, so the recorded hash is of the file at rest.
+func (l *Logger) recordBackup(base string) error {
+	path, info, ok := finalBackupForm(base)
+	if !ok {
+		// Already removed by retention cleanup: nothing left to inventory
+		return nil
+	}
+
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return l.taskFailed("manifest_hash", fmt.Errorf("failed to hash %s: %v", path, err))
+	}
+	_, compressed := compressorForPath(strings.TrimSuffix(path, encryptedSuffix))
+	line, err := json.Marshal(BackupInfo{
+		Path:       path,
+		Size:       info.Size(),
+		ModTime:    info.ModTime().UTC(),
+		SHA256:     sum,
+		Compressed: compressed,
+		Encrypted:  strings.HasSuffix(path, encryptedSuffix),
+	})
+	if err != nil {
+		return l.taskFailed("manifest_write", err)
+	}
+	line = append(line, '\n')
+
+	l.manifestMu.Lock()
+	defer l.manifestMu.Unlock()
+
+	// WHY one Write on an O_APPEND descriptor: the entry lands whole at the
+	// end of the file, so a crash never leaves half a line mid-manifest.
+	f, err := os.OpenFile(l.manifestPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, l.backupFileMode()) // #nosec G304 -- path derived from Filename, not user input
+	if err != nil {
+		return l.taskFailed("manifest_write", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close() // Ignore close error; the write error is what matters
+		return l.taskFailed("manifest_write", err)
+	}
+	if err := f.Close(); err != nil {
+		return l.taskFailed("manifest_write", err)
+	}
+	return nil
+}
+
+// pruneManifest drops the entries for removed backups. The manifest is
+// rewritten to a temp file and renamed over the old one, so a crash leaves
+// either the old or the new inventory.
+func (l *Logger) pruneManifest(removed []string) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	gone := make(map[string]struct{}, len(removed))
+	for _, path := range removed {
+		gone[path] = struct{}{}
+	}
+
+	l.manifestMu.Lock()
+	defer l.manifestMu.Unlock()
+
+	path := l.manifestPath()
+	data, err := os.ReadFile(path) // #nosec G304 -- path derived from Filename, not user input
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return l.taskFailed("manifest_prune", err)
+	}
+
+	var kept bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		var entry BackupInfo
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		// Unparseable lines are kept: pruning must never destroy evidence
+		if json.Unmarshal(line, &entry) == nil {
+			if _, ok := gone[entry.Path]; ok {
+				continue
+			}
+		}
+		kept.Write(line)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, kept.Bytes(), l.backupFileMode()); err != nil {
+		return l.taskFailed("manifest_prune", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath) // Ignore remove error during cleanup
+		return l.taskFailed("manifest_prune", err)
+	}
+	return nil
+}
+
+// ReadManifest parses the backup manifest (Filename + ".manifest"),
+// oldest entry first. Returns no entries and a nil error if the manifest
+// does not exist yet, and an error naming the line if an entry is corrupt.
+func (l *Logger) ReadManifest() ([]BackupInfo, error) {
+	f, err := os.Open(l.manifestPath()) // #nosec G304 -- path derived from Filename, not user input
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []BackupInfo
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry BackupInfo
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return entries, fmt.Errorf("manifest %s line %d: %w", l.manifestPath(), lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// finalBackupForm returns the most processed form of base that exists
+// (e.g., "name.gz.enc" before "name.gz" before "name").
+func finalBackupForm(base string) (string, os.FileInfo, bool) {
+	forms := backupForms(base)
+	for i := len(forms) - 1; i >= 0; i-- {
+		if info, err := os.Stat(forms[i]); err == nil {
+			return forms[i], info, true
+		}
+	}
+	return "", nil, false
+}
+
+// fileSHA256 returns the hex SHA-256 of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path) // #nosec G304 -- internal backup path
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
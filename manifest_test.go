@@ -0,0 +1,109 @@
+// manifest_test.go: Tests for the backup manifest
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func rotateManifestLogger(t *testing.T, config *LoggerConfig, rotations int) *Logger {
+	t.Helper()
+	config.Manifest = true
+	logger := newTestLogger(t, config)
+
+	for i := 0; i < rotations; i++ {
+		if _, err := logger.Write([]byte(strings.Repeat("x", 100*(i+1)) + "\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("RotateSync: %v", err)
+		}
+	}
+	return logger
+}
+
+func TestManifest_RecordsEachBackup(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := rotateManifestLogger(t, &LoggerConfig{Filename: logFile}, 2)
+
+	entries, err := logger.ReadManifest()
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		info, err := os.Stat(entry.Path)
+		if err != nil {
+			t.Fatalf("manifest lists missing file: %v", err)
+		}
+		if entry.Size != info.Size() || entry.Compressed {
+			t.Errorf("entry %+v does not describe a plain %d-byte backup", entry, info.Size())
+		}
+		if sum, _ := fileSHA256(entry.Path); entry.SHA256 != sum {
+			t.Errorf("sha256 = %s, want %s", entry.SHA256, sum)
+		}
+	}
+}
+
+func TestManifest_RecordsCompressedForm(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := rotateManifestLogger(t, &LoggerConfig{Filename: logFile, Compress: true, CompressMinSize: -1}, 1)
+
+	entries, err := logger.ReadManifest()
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if len(entries) != 1 || !entries[0].Compressed || !strings.HasSuffix(entries[0].Path, ".gz") {
+		t.Fatalf("entries = %+v, want one compressed .gz backup", entries)
+	}
+	if sum, _ := fileSHA256(entries[0].Path); entries[0].SHA256 != sum {
+		t.Error("sha256 is not the hash of the compressed file at rest")
+	}
+}
+
+func TestManifest_PrunedByCleanup(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := rotateManifestLogger(t, &LoggerConfig{Filename: logFile, MaxBackups: 1}, 3)
+
+	entries, err := logger.ReadManifest()
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %+v, want only the retained backup", entries)
+	}
+	if _, err := os.Stat(entries[0].Path); err != nil {
+		t.Errorf("remaining entry points at a deleted file: %v", err)
+	}
+}
+
+func TestReadManifest_MissingAndCorrupt(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := &Logger{Filename: logFile}
+
+	entries, err := logger.ReadManifest()
+	if err != nil || entries != nil {
+		t.Fatalf("ReadManifest without manifest = (%v, %v), want (nil, nil)", entries, err)
+	}
+
+	content := `{"path":"app.log.2025-01-02-15-04-05","size":1}` + "\nnot json\n"
+	if err := os.WriteFile(logFile+manifestSuffix, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	entries, err = logger.ReadManifest()
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadManifest error = %v, want one naming line 2", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("entries before the corrupt line = %d, want 1", len(entries))
+	}
+}
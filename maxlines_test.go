@@ -0,0 +1,120 @@
+// maxlines_test.go: Tests for line-count-based rotation (MaxLines)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// TestMaxLines_RotatesAtLineCount verifies rotation fires once MaxLines newlines
+// have been written and resets the counter for the new file.
+func TestMaxLines_RotatesAtLineCount(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "lines.log")
+
+	var rotations atomic.Int32
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		MaxLines: 5,
+		OnRotate: func(RotationEvent) { rotations.Add(1) },
+	})
+
+	for i := 0; i < 4; i++ {
+		if _, err := logger.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if rotations.Load() != 0 {
+		t.Fatalf("rotated after 4 lines, want no rotation before MaxLines")
+	}
+
+	if _, err := logger.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rotations.Load() != 1 {
+		t.Fatalf("rotations = %d after 5 lines, want 1", rotations.Load())
+	}
+	if got := logger.lineCount.Load(); got != 0 {
+		t.Errorf("lineCount after rotation = %d, want 0", got)
+	}
+
+	// A single chunk carrying several lines counts each newline
+	if _, err := logger.Write([]byte("a\nb\nc\nd\ne\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rotations.Load() != 2 {
+		t.Errorf("rotations = %d after multi-line chunk, want 2", rotations.Load())
+	}
+}
+
+// TestMaxLines_PartialLineCountedOnNewline verifies a write without a trailing
+// newline is not counted until its newline arrives.
+func TestMaxLines_PartialLineCountedOnNewline(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "partial.log")
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		MaxLines: 2,
+	})
+
+	if _, err := logger.Write([]byte("first\nsecond-part")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.lineCount.Load(); got != 1 {
+		t.Fatalf("lineCount = %d, want 1 (partial line not yet counted)", got)
+	}
+	if _, err := logger.Write([]byte("-end\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// The second newline completes MaxLines and rotates
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(content) != 0 {
+		t.Errorf("active file = %q, want empty after rotation", content)
+	}
+	matches, _ := filepath.Glob(logFile + ".*")
+	if len(matches) != 1 {
+		t.Fatalf("backups = %v, want exactly one", matches)
+	}
+	backup, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("ReadFile backup: %v", err)
+	}
+	if !bytes.Equal(backup, []byte("first\nsecond-part-end\n")) {
+		t.Errorf("backup = %q", backup)
+	}
+}
+
+// TestMaxLines_ComposesWithSize verifies size still triggers rotation when
+// MaxLines is set but not reached.
+func TestMaxLines_ComposesWithSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "compose.log")
+
+	var rotations atomic.Int32
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		MaxSizeStr: "1KB",
+		MaxLines:   1000,
+		OnRotate:   func(RotationEvent) { rotations.Add(1) },
+	})
+
+	if _, err := logger.Write(bytes.Repeat([]byte("x"), 2048)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rotations.Load() != 1 {
+		t.Errorf("rotations = %d, want size-triggered rotation", rotations.Load())
+	}
+}
@@ -0,0 +1,324 @@
+// memfs.go: Map-backed in-memory filesystem for deterministic tests
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemFileSystem is an in-memory filesystem backed by maps, for tests that
+// must not touch the disk or need to force error paths deterministically.
+//
+// It mirrors the FileSystem method set, but Create, Open and OpenFile hand
+// out *MemFile rather than *os.File, so it does not satisfy FileSystem.
+// Directories are implicit: a file's directory exists as long as it holds
+// the file. Set the ReturnErrorOn fields before the filesystem is shared
+// between goroutines; everything else is safe for concurrent use.
+type MemFileSystem struct {
+	ReturnErrorOnCreate error // Returned by Create and OpenFile with O_CREATE when set
+	ReturnErrorOnOpen   error // Returned by Open and OpenFile without O_CREATE when set
+	ReturnErrorOnRename error // Returned by Rename when set, e.g. syscall.EXDEV
+	ReturnErrorOnRemove error // Returned by Remove when set
+	ReturnErrorOnStat   error // Returned by Stat when set
+	ReturnErrorOnWrite  error // Returned by MemFile.Write when set, nothing is written
+
+	// ShortWriteLimit, when positive, caps every MemFile.Write at that
+	// many bytes; longer writes are truncated and return io.ErrShortWrite.
+	ShortWriteLimit int
+
+	mu    sync.Mutex
+	files map[string]*memFileData
+}
+
+// memFileData is the content of a file, shared by every MemFile opened on
+// it. It survives Remove for handles that are still open, as on Unix.
+type memFileData struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// NewMemFileSystem returns an empty in-memory filesystem.
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{files: make(map[string]*memFileData)}
+}
+
+// Create creates or truncates the named file, like os.Create.
+func (fs *MemFileSystem) Create(name string) (*MemFile, error) {
+	return fs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+// Open opens the named file for reading, like os.Open.
+func (fs *MemFileSystem) Open(name string) (*MemFile, error) {
+	return fs.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// OpenFile opens the named file honouring O_RDONLY, O_WRONLY, O_RDWR,
+// O_CREATE, O_EXCL, O_TRUNC and O_APPEND, like os.OpenFile.
+func (fs *MemFileSystem) OpenFile(name string, flag int, perm os.FileMode) (*MemFile, error) {
+	name = filepath.Clean(name)
+	injected := fs.ReturnErrorOnOpen
+	if flag&os.O_CREATE != 0 {
+		injected = fs.ReturnErrorOnCreate
+	}
+	if injected != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: injected}
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	file, exists := fs.lookup(name)
+	switch {
+	case exists && flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+	case !exists && flag&os.O_CREATE == 0:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	case !exists:
+		file = &memFileData{mode: perm.Perm(), modTime: time.Now()}
+		fs.files[name] = file
+	}
+
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	if writable && flag&os.O_TRUNC != 0 {
+		file.data = nil
+		file.modTime = time.Now()
+	}
+	return &MemFile{
+		fs:       fs,
+		file:     file,
+		name:     name,
+		readable: flag&os.O_WRONLY == 0,
+		writable: writable,
+		append:   flag&os.O_APPEND != 0,
+	}, nil
+}
+
+// Rename moves oldname to newname, replacing newname if it exists.
+func (fs *MemFileSystem) Rename(oldname, newname string) error {
+	oldname, newname = filepath.Clean(oldname), filepath.Clean(newname)
+	if fs.ReturnErrorOnRename != nil {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: fs.ReturnErrorOnRename}
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	file, exists := fs.lookup(oldname)
+	if !exists {
+		return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: os.ErrNotExist}
+	}
+	delete(fs.files, oldname)
+	fs.files[newname] = file
+	return nil
+}
+
+// Remove deletes the named file. Handles already open on it keep working.
+func (fs *MemFileSystem) Remove(name string) error {
+	name = filepath.Clean(name)
+	if fs.ReturnErrorOnRemove != nil {
+		return &os.PathError{Op: "remove", Path: name, Err: fs.ReturnErrorOnRemove}
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if _, exists := fs.lookup(name); !exists {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	delete(fs.files, name)
+	return nil
+}
+
+// Stat describes the named file.
+func (fs *MemFileSystem) Stat(name string) (os.FileInfo, error) {
+	name = filepath.Clean(name)
+	if fs.ReturnErrorOnStat != nil {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: fs.ReturnErrorOnStat}
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	file, exists := fs.lookup(name)
+	if !exists {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return file.info(name), nil
+}
+
+// ReadFile returns a copy of the named file's content.
+func (fs *MemFileSystem) ReadFile(name string) ([]byte, error) {
+	name = filepath.Clean(name)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	file, exists := fs.lookup(name)
+	if !exists {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return append([]byte(nil), file.data...), nil
+}
+
+// List returns the sorted base names of the files directly in dir.
+func (fs *MemFileSystem) List(dir string) []string {
+	dir = filepath.Clean(dir)
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var names []string
+	for name := range fs.files {
+		if filepath.Dir(name) == dir {
+			names = append(names, filepath.Base(name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup finds a file; the zero MemFileSystem is usable. Caller holds fs.mu.
+func (fs *MemFileSystem) lookup(name string) (*memFileData, bool) {
+	if fs.files == nil {
+		fs.files = make(map[string]*memFileData)
+	}
+	file, exists := fs.files[name]
+	return file, exists
+}
+
+func (f *memFileData) info(name string) os.FileInfo {
+	return memFileInfo{name: filepath.Base(name), size: int64(len(f.data)), mode: f.mode, modTime: f.modTime}
+}
+
+// MemFile is an open file of a MemFileSystem. Like *os.File, a single
+// MemFile must not be read or written from several goroutines at once.
+type MemFile struct {
+	fs       *MemFileSystem
+	file     *memFileData
+	name     string
+	offset   int64
+	readable bool
+	writable bool
+	append   bool
+	closed   bool
+}
+
+// Name returns the name the file was opened with.
+func (f *MemFile) Name() string {
+	return f.name
+}
+
+// Read reads from the current offset, returning io.EOF at the end.
+func (f *MemFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &os.PathError{Op: "read", Path: f.name, Err: os.ErrClosed}
+	}
+	if !f.readable {
+		return 0, &os.PathError{Op: "read", Path: f.name, Err: os.ErrPermission}
+	}
+
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.offset >= int64(len(f.file.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.file.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+// Write writes at the current offset, or at the end with O_APPEND, subject
+// to ReturnErrorOnWrite and ShortWriteLimit.
+func (f *MemFile) Write(p []byte) (int, error) {
+	if f.closed {
+		return 0, &os.PathError{Op: "write", Path: f.name, Err: os.ErrClosed}
+	}
+	if !f.writable {
+		return 0, &os.PathError{Op: "write", Path: f.name, Err: os.ErrPermission}
+	}
+	if f.fs.ReturnErrorOnWrite != nil {
+		return 0, &os.PathError{Op: "write", Path: f.name, Err: f.fs.ReturnErrorOnWrite}
+	}
+	var err error
+	if limit := f.fs.ShortWriteLimit; limit > 0 && len(p) > limit {
+		p, err = p[:limit], io.ErrShortWrite
+	}
+
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.append {
+		f.offset = int64(len(f.file.data))
+	}
+	if end := f.offset + int64(len(p)); end > int64(len(f.file.data)) {
+		f.file.data = append(f.file.data, make([]byte, end-int64(len(f.file.data)))...)
+	}
+	copy(f.file.data[f.offset:], p)
+	f.offset += int64(len(p))
+	f.file.modTime = time.Now()
+	return len(p), err
+}
+
+// Seek sets the offset for the next Read or Write, like os.File.Seek.
+func (f *MemFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &os.PathError{Op: "seek", Path: f.name, Err: os.ErrClosed}
+	}
+
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += int64(len(f.file.data))
+	}
+	if offset < 0 {
+		return 0, &os.PathError{Op: "seek", Path: f.name, Err: os.ErrInvalid}
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+// Stat describes the file, even after it has been removed or renamed.
+func (f *MemFile) Stat() (os.FileInfo, error) {
+	if f.closed {
+		return nil, &os.PathError{Op: "stat", Path: f.name, Err: os.ErrClosed}
+	}
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	return f.file.info(f.name), nil
+}
+
+// Sync is a no-op: the content is already in memory.
+func (f *MemFile) Sync() error {
+	if f.closed {
+		return &os.PathError{Op: "sync", Path: f.name, Err: os.ErrClosed}
+	}
+	return nil
+}
+
+// Close closes the file; later calls fail with os.ErrClosed.
+func (f *MemFile) Close() error {
+	if f.closed {
+		return &os.PathError{Op: "close", Path: f.name, Err: os.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
+
+// memFileInfo implements os.FileInfo for MemFileSystem.
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi memFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() interface{}   { return nil }
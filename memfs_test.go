@@ -0,0 +1,222 @@
+// memfs_test.go: Tests for the in-memory MemFileSystem
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+// memWrite creates name in fs with the given content.
+func memWrite(t *testing.T, fs *MemFileSystem, name, content string) {
+	t.Helper()
+	f, err := fs.Create(name)
+	if err != nil {
+		t.Fatalf("Create(%s): %v", name, err)
+	}
+	if _, err := f.Write([]byte(content)); err != nil {
+		t.Fatalf("Write(%s): %v", name, err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close(%s): %v", name, err)
+	}
+}
+
+// memRead returns the content of name in fs, read through Open.
+func memRead(t *testing.T, fs *MemFileSystem, name string) string {
+	t.Helper()
+	f, err := fs.Open(name)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", name, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("ReadAll(%s): %v", name, err)
+	}
+	return string(data)
+}
+
+func TestMemFileSystem_CreateWriteRead(t *testing.T) {
+	fs := NewMemFileSystem()
+	memWrite(t, fs, "/logs/app.log", "hello\n")
+	if got := memRead(t, fs, "/logs/app.log"); got != "hello\n" {
+		t.Errorf("content = %q, want %q", got, "hello\n")
+	}
+
+	info, err := fs.Stat("/logs/app.log")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Name() != "app.log" || info.Size() != 6 || info.IsDir() {
+		t.Errorf("Stat = %s size %d dir %v, want app.log size 6 file", info.Name(), info.Size(), info.IsDir())
+	}
+
+	// Create truncates an existing file
+	memWrite(t, fs, "/logs/app.log", "new")
+	if got := memRead(t, fs, "/logs/app.log"); got != "new" {
+		t.Errorf("content after re-Create = %q, want %q", got, "new")
+	}
+}
+
+func TestMemFileSystem_OpenFileFlags(t *testing.T) {
+	fs := NewMemFileSystem()
+	memWrite(t, fs, "/app.log", "one\n")
+
+	f, err := fs.OpenFile("/app.log", os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("OpenFile(O_APPEND): %v", err)
+	}
+	if _, err := f.Write([]byte("two\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := f.Read(make([]byte, 1)); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("Read on write-only file = %v, want ErrPermission", err)
+	}
+	_ = f.Close()
+	if got := memRead(t, fs, "/app.log"); got != "one\ntwo\n" {
+		t.Errorf("content after append = %q, want %q", got, "one\ntwo\n")
+	}
+
+	if _, err := fs.OpenFile("/app.log", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644); !errors.Is(err, os.ErrExist) {
+		t.Errorf("OpenFile(O_EXCL) on existing file = %v, want ErrExist", err)
+	}
+	if _, err := fs.OpenFile("/missing.log", os.O_WRONLY, 0644); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OpenFile without O_CREATE = %v, want ErrNotExist", err)
+	}
+
+	f, err = fs.OpenFile("/new.log", os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatalf("OpenFile(O_CREATE): %v", err)
+	}
+	_ = f.Close()
+	if info, err := fs.Stat("/new.log"); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Stat(new.log) = %v, %v; want mode 0600", info, err)
+	}
+	if _, err := f.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestMemFileSystem_RenameRemoveList(t *testing.T) {
+	fs := NewMemFileSystem()
+	memWrite(t, fs, "/logs/app.log", "current")
+	memWrite(t, fs, "/logs/app.log.1", "old")
+	memWrite(t, fs, "/other/app.log", "elsewhere")
+
+	if err := fs.Rename("/logs/app.log", "/logs/app.log.1"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if got, want := fs.List("/logs"), []string{"app.log.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List after Rename = %v, want %v", got, want)
+	}
+	if got := memRead(t, fs, "/logs/app.log.1"); got != "current" {
+		t.Errorf("renamed content = %q, want %q", got, "current")
+	}
+	if err := fs.Rename("/logs/app.log", "/logs/app.log.2"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Rename of missing file = %v, want ErrNotExist", err)
+	}
+
+	// An open handle outlives Remove, as on Unix
+	f, err := fs.Open("/logs/app.log.1")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := fs.Remove("/logs/app.log.1"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if data, err := io.ReadAll(f); err != nil || string(data) != "current" {
+		t.Errorf("read after Remove = %q, %v; want %q", data, err, "current")
+	}
+	if len(fs.List("/logs")) != 0 {
+		t.Errorf("List after Remove = %v, want empty", fs.List("/logs"))
+	}
+	if _, err := fs.Stat("/logs/app.log.1"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Stat after Remove = %v, want ErrNotExist", err)
+	}
+	if err := fs.Remove("/logs/app.log.1"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("second Remove = %v, want ErrNotExist", err)
+	}
+}
+
+func TestMemFileSystem_ErrorInjection(t *testing.T) {
+	fs := NewMemFileSystem()
+	memWrite(t, fs, "/app.log", "data")
+
+	fs.ReturnErrorOnRename = syscall.EXDEV
+	err := fs.Rename("/app.log", "/app.log.1")
+	if !errors.Is(err, syscall.EXDEV) {
+		t.Fatalf("Rename = %v, want EXDEV", err)
+	}
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) || linkErr.Old != "/app.log" {
+		t.Errorf("Rename error = %#v, want *os.LinkError for /app.log", err)
+	}
+	if got, want := fs.List("/"), []string{"app.log"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List after failed Rename = %v, want %v", got, want)
+	}
+	fs.ReturnErrorOnRename = nil
+
+	injected := errors.New("injected")
+	fs.ReturnErrorOnCreate = injected
+	if _, err := fs.Create("/new.log"); !errors.Is(err, injected) {
+		t.Errorf("Create = %v, want injected error", err)
+	}
+	fs.ReturnErrorOnCreate = nil
+
+	fs.ReturnErrorOnOpen = injected
+	if _, err := fs.Open("/app.log"); !errors.Is(err, injected) {
+		t.Errorf("Open = %v, want injected error", err)
+	}
+	fs.ReturnErrorOnOpen = nil
+
+	fs.ReturnErrorOnStat = injected
+	if _, err := fs.Stat("/app.log"); !errors.Is(err, injected) {
+		t.Errorf("Stat = %v, want injected error", err)
+	}
+	fs.ReturnErrorOnStat = nil
+
+	fs.ReturnErrorOnRemove = injected
+	if err := fs.Remove("/app.log"); !errors.Is(err, injected) {
+		t.Errorf("Remove = %v, want injected error", err)
+	}
+	fs.ReturnErrorOnRemove = nil
+
+	f, err := fs.Create("/write.log")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	fs.ReturnErrorOnWrite = syscall.ENOSPC
+	if n, err := f.Write([]byte("lost")); n != 0 || !errors.Is(err, syscall.ENOSPC) {
+		t.Errorf("Write = %d, %v; want 0, ENOSPC", n, err)
+	}
+	fs.ReturnErrorOnWrite = nil
+
+	fs.ShortWriteLimit = 3
+	if n, err := f.Write([]byte("abcdef")); n != 3 || !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("Write = %d, %v; want 3, ErrShortWrite", n, err)
+	}
+	if n, err := f.Write([]byte("xy")); n != 2 || err != nil {
+		t.Errorf("Write under the limit = %d, %v; want 2, nil", n, err)
+	}
+	_ = f.Close()
+	if got, err := fs.ReadFile("/write.log"); err != nil || string(got) != "abcxy" {
+		t.Errorf("ReadFile = %q, %v; want %q", got, err, "abcxy")
+	}
+}
+
+func TestMemFileSystem_ZeroValue(t *testing.T) {
+	var fs MemFileSystem
+	memWrite(t, &fs, "app.log", "ok")
+	if got, want := fs.List("."), []string{"app.log"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+}
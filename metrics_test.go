@@ -254,3 +254,157 @@ func TestStats_LatencyTracking(t *testing.T) {
 	t.Logf("Stats: AvgLatencyNs=%d, LastLatencyNs=%d",
 		stats.AvgLatencyNs, stats.LastLatencyNs)
 }
+
+// TestStats_TotalBytesExactAcrossRotations verifies TotalBytes counts every
+// byte written, independent of rotations and file sizes.
+func TestStats_TotalBytesExactAcrossRotations(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		name := "sync"
+		if async {
+			name = "async"
+		}
+		t.Run(name, func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "test.log")
+			logger, err := NewWithConfig(&LoggerConfig{
+				Filename:         logFile,
+				MaxSizeStr:       "1KB",
+				Async:            async,
+				DisableAutoScale: !async, // Keep the sync case on the sync path
+			})
+			if err != nil {
+				t.Fatalf("Failed to create logger: %v", err)
+			}
+			defer func() { _ = logger.Close() }()
+
+			// Odd-sized entries so rotated files are never exactly MaxSize
+			entry := []byte("entry for byte-accurate accounting, 47 bytes.\n")
+			const writes = 200
+			for i := 0; i < writes; i++ {
+				if _, err := logger.Write(entry); err != nil {
+					t.Fatalf("Write failed on iteration %d: %v", i, err)
+				}
+				// Drain periodically so async batches span several files
+				if i%20 == 19 {
+					if err := logger.Sync(); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			// A manual rotation of a small file must not skew the total
+			if err := logger.RotateSync(); err != nil {
+				t.Fatalf("RotateSync failed: %v", err)
+			}
+			if _, err := logger.Write(entry); err != nil {
+				t.Fatal(err)
+			}
+			if err := logger.Sync(); err != nil {
+				t.Fatal(err)
+			}
+
+			stats := logger.Stats()
+			if stats.RotationCount < 5 {
+				t.Fatalf("Expected several rotations, got %d", stats.RotationCount)
+			}
+			if want := uint64((writes + 1) * len(entry)); stats.TotalBytes != want {
+				t.Errorf("TotalBytes = %d, want exactly %d", stats.TotalBytes, want)
+			}
+			if stats.CurrentFileSize != uint64(len(entry)) {
+				t.Errorf("CurrentFileSize = %d, want %d (active file only)", stats.CurrentFileSize, len(entry))
+			}
+		})
+	}
+}
+
+// TestStats_RotationBreakdown verifies that size- and time-triggered
+// rotations are counted separately, and that RotationCount covers all.
+func TestStats_RotationBreakdown(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "test.log")
+	logger, err := NewWithConfig(&LoggerConfig{
+		Filename:         logFile,
+		MaxSizeStr:       "1KB",
+		MaxAgeStr:        "1h",
+		DisableAutoScale: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	// Two size rotations
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write(make([]byte, 1100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// One age rotation: a small file older than MaxAge
+	if _, err := logger.Write([]byte("small\n")); err != nil {
+		t.Fatal(err)
+	}
+	logger.fileCreated.Store(time.Now().Add(-2 * time.Hour).Unix())
+	if _, err := logger.Write([]byte("small\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	// One manual rotation
+	if err := logger.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := logger.Stats()
+	if stats.SizeRotations != 2 {
+		t.Errorf("SizeRotations = %d, want 2", stats.SizeRotations)
+	}
+	if stats.TimeRotations != 1 {
+		t.Errorf("TimeRotations = %d, want 1", stats.TimeRotations)
+	}
+	if stats.ManualRotations != 1 {
+		t.Errorf("ManualRotations = %d, want 1", stats.ManualRotations)
+	}
+	if stats.RotationCount != 4 {
+		t.Errorf("RotationCount = %d, want 4 (size, time and manual)", stats.RotationCount)
+	}
+	byReason := stats.SizeRotations + stats.AgeRotations + stats.CalendarRotations +
+		stats.LineRotations + stats.ManualRotations + stats.CustomRotations
+	if byReason != stats.RotationCount {
+		t.Errorf("per-reason counts sum to %d, want RotationCount %d", byReason, stats.RotationCount)
+	}
+}
+
+// TestStats_AgeRotationCountsOnlyAge verifies an age-triggered rotation is
+// attributed to MaxAge alone and reported as such to OnRotate.
+func TestStats_AgeRotationCountsOnlyAge(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "test.log")
+	var reasons []string
+	logger, err := NewWithConfig(&LoggerConfig{
+		Filename:         logFile,
+		MaxSizeStr:       "1MB",
+		MaxAgeStr:        "1h",
+		MaxLines:         1000,
+		DisableAutoScale: true,
+		OnRotate:         func(event RotationEvent) { reasons = append(reasons, event.Reason) },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte("small\n")); err != nil {
+		t.Fatal(err)
+	}
+	logger.fileCreated.Store(time.Now().Add(-2 * time.Hour).Unix())
+	if _, err := logger.Write([]byte("small\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := logger.Stats()
+	if stats.AgeRotations != 1 || stats.TimeRotations != 1 {
+		t.Errorf("AgeRotations = %d, TimeRotations = %d, want 1 and 1", stats.AgeRotations, stats.TimeRotations)
+	}
+	if stats.SizeRotations != 0 || stats.CalendarRotations != 0 || stats.LineRotations != 0 || stats.ManualRotations != 0 || stats.CustomRotations != 0 {
+		t.Errorf("age rotation counted under another reason: %+v", stats)
+	}
+	if len(reasons) != 1 || reasons[0] != "age" {
+		t.Errorf("OnRotate reasons = %v, want [age]", reasons)
+	}
+}
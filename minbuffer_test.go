@@ -0,0 +1,104 @@
+// minbuffer_test.go: Tests for BufferSize rounding and MinBufferSize
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMinBufferSize_AllowsTinyRing verifies a two-slot ring applies
+// backpressure on the third pending write.
+func TestMinBufferSize_AllowsTinyRing(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           filepath.Join(t.TempDir(), "tiny.log"),
+		Async:              true,
+		BufferSize:         2,
+		MinBufferSize:      1,
+		BackpressurePolicy: "drop",
+		// Parks the consumer on its first batch
+		RotateWhen: func(uint64, time.Duration) bool {
+			once.Do(func() {
+				close(entered)
+				<-release
+			})
+			return false
+		},
+	})
+	defer close(release)
+
+	if _, err := logger.Write([]byte("parks the consumer\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-entered
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("pending\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	stats := logger.Stats()
+	if stats.BufferSize != 2 {
+		t.Errorf("Stats.BufferSize = %d, want 2", stats.BufferSize)
+	}
+	if stats.DroppedOnFull != 1 {
+		t.Errorf("DroppedOnFull = %d, want 1 (the third pending write)", stats.DroppedOnFull)
+	}
+}
+
+func TestBufferSize_RoundingIsReported(t *testing.T) {
+	cases := []struct {
+		name      string
+		size, min int
+		wantSlots uint64
+		reports   int
+	}{
+		{"default floor", 2, 0, 64, 1},
+		{"power of 2", 100, 0, 128, 1},
+		{"exact", 1024, 0, 1024, 0},
+		{"default size", 0, 0, 1024, 0},
+		{"lowered floor", 3, 1, 4, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var reports int
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:      filepath.Join(t.TempDir(), "round.log"),
+				Async:         true,
+				BufferSize:    tc.size,
+				MinBufferSize: tc.min,
+				ErrorCallback: func(op string, err error) {
+					if op == "buffer_size_adjusted" {
+						mu.Lock()
+						reports++
+						mu.Unlock()
+					}
+				},
+			})
+			for i := 0; i < 3; i++ {
+				if _, err := logger.Write([]byte("entry\n")); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+
+			if got := logger.Stats().BufferSize; got != tc.wantSlots {
+				t.Errorf("Stats.BufferSize = %d, want %d", got, tc.wantSlots)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if reports != tc.reports {
+				t.Errorf("buffer_size_adjusted reported %d times, want %d", reports, tc.reports)
+			}
+		})
+	}
+}
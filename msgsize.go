@@ -0,0 +1,59 @@
+// msgsize.go: Per-message size limit (MaxMessageSize)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMessageTooLarge is wrapped by the error returned for writes rejected
+// by MaxMessageSize.
+var ErrMessageTooLarge = errors.New("lethe: message exceeds MaxMessageSize")
+
+// OversizePolicy values select what happens to writes over MaxMessageSize.
+const (
+	OversizePolicyReject   = "reject"   // Fail the write with ErrMessageTooLarge (default)
+	OversizePolicyTruncate = "truncate" // Keep the first MaxMessageSize bytes
+)
+
+// oversized reports whether data exceeds MaxMessageSize.
+func (l *Logger) oversized(data []byte) bool {
+	return l.MaxMessageSize > 0 && int64(len(data)) > l.MaxMessageSize
+}
+
+// writeOversized applies OversizePolicy to a message over MaxMessageSize
+// and hands what is left to dispatch. Both outcomes are reported as
+// "message_too_large".
+func (l *Logger) writeOversized(data []byte, dispatch func([]byte) (int, error)) (int, error) {
+	err := fmt.Errorf("%w: %d bytes, limit %d", ErrMessageTooLarge, len(data), l.MaxMessageSize)
+	if l.OversizePolicy != OversizePolicyTruncate {
+		l.reportError("message_too_large", err)
+		return 0, err
+	}
+	l.reportError("message_too_large", fmt.Errorf("truncated: %w", err))
+
+	// WHY copy: data may be the caller's buffer, which must not be modified,
+	// and a copy lets the truncated line keep its terminating newline
+	limit := l.MaxMessageSize
+	truncated := make([]byte, limit)
+	copy(truncated, data[:limit])
+	if data[len(data)-1] == '\n' {
+		truncated[limit-1] = '\n'
+	}
+	l.recordRecent(truncated)
+
+	if l.Dedup {
+		_, err = l.writeDeduped(truncated, dispatch)
+	} else {
+		_, err = dispatch(truncated)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil // The caller's message was consumed in full
+}
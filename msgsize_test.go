@@ -0,0 +1,101 @@
+// msgsize_test.go: Tests for MaxMessageSize and OversizePolicy
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func newSizeLimitedLogger(t *testing.T, policy string, reported *atomic.Int32) (*Logger, string) {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "limited.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:       logFile,
+		MaxMessageSize: 16,
+		OversizePolicy: policy,
+		ErrorCallback: func(op string, err error) {
+			if op == "message_too_large" && errors.Is(err, ErrMessageTooLarge) {
+				reported.Add(1)
+			}
+		},
+	})
+	return logger, logFile
+}
+
+func TestMaxMessageSize_RejectsOversized(t *testing.T) {
+	var reported atomic.Int32
+	logger, logFile := newSizeLimitedLogger(t, "", &reported)
+
+	if _, err := logger.Write([]byte("fits in 16\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	big := bytes.Repeat([]byte("x"), 1024)
+	if n, err := logger.Write(big); !errors.Is(err, ErrMessageTooLarge) || n != 0 {
+		t.Errorf("Write oversized = (%d, %v), want (0, ErrMessageTooLarge)", n, err)
+	}
+	if n, err := logger.WriteOwned(bytes.Clone(big)); !errors.Is(err, ErrMessageTooLarge) || n != 0 {
+		t.Errorf("WriteOwned oversized = (%d, %v), want (0, ErrMessageTooLarge)", n, err)
+	}
+	// A batch is limited as one message, even when each chunk fits
+	if _, err := logger.WriteBatch([][]byte{[]byte("0123456789\n"), []byte("0123456789\n")}); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("WriteBatch oversized error = %v, want ErrMessageTooLarge", err)
+	}
+	if got := reported.Load(); got != 3 {
+		t.Errorf("message_too_large reported %d times, want 3", got)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "fits in 16\n" {
+		t.Errorf("log = %q, want only the message within the limit", data)
+	}
+}
+
+func TestMaxMessageSize_TruncatesOversized(t *testing.T) {
+	var reported atomic.Int32
+	logger, logFile := newSizeLimitedLogger(t, OversizePolicyTruncate, &reported)
+
+	line := []byte("this line is far too long for the limit\n")
+	original := bytes.Clone(line)
+	if n, err := logger.Write(line); err != nil || n != len(line) {
+		t.Fatalf("Write = (%d, %v), want (%d, nil)", n, err, len(line))
+	}
+	if !bytes.Equal(line, original) {
+		t.Errorf("caller's buffer modified: %q", line)
+	}
+	if _, err := logger.Write([]byte("no newline at all, also too long")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if reported.Load() != 2 {
+		t.Errorf("message_too_large reported %d times, want 2", reported.Load())
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if want := "this line is fa\nno newline at al"; string(data) != want {
+		t.Errorf("log = %q, want %q", data, want)
+	}
+}
+
+func TestMaxMessageSize_Validation(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.log")
+	if err := ValidateConfig(&LoggerConfig{Filename: file, MaxMessageSize: -1}); err == nil {
+		t.Error("negative MaxMessageSize accepted")
+	}
+	if err := ValidateConfig(&LoggerConfig{Filename: file, OversizePolicy: "split"}); err == nil {
+		t.Error("unknown OversizePolicy accepted")
+	}
+}
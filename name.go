@@ -0,0 +1,49 @@
+// name.go: Logical Logger names for multi-logger processes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "path/filepath"
+
+// ErrorEvent is an error report tagged with the Logger that produced it,
+// passed to OnError.
+type ErrorEvent struct {
+	Logger    string // Name() of the reporting Logger
+	Operation string // Same operation string ErrorCallback receives
+	Err       error
+}
+
+// Name returns the Logger's logical name: LoggerConfig.Name when set,
+// otherwise the base name of Filename (e.g. "app.log"). It labels
+// ErrorEvent and Stats.Name so a process with several Loggers can tell
+// their reports apart. Safe to call concurrently with writes.
+func (l *Logger) Name() string {
+	if l.name != "" {
+		return l.name
+	}
+	// Logger built as a struct literal. WHY not Filename once the file
+	// is open: opening rewrites it with the sanitized path.
+	if path := l.currentPath.Load(); path != nil {
+		return filepath.Base(*path)
+	}
+	return filepath.Base(l.Filename)
+}
+
+// loggerName returns the name NewWithConfig stores for config, computed
+// once so Name never reads Filename while the file is being opened.
+func loggerName(config *LoggerConfig) string {
+	if config.Name != "" {
+		return config.Name
+	}
+	return filepath.Base(config.Filename)
+}
+
+// invokeOnError calls OnError with panic recovery. A panic is swallowed:
+// reporting it would re-enter OnError.
+func (l *Logger) invokeOnError(operation string, err error) {
+	defer func() { _ = recover() }()
+	l.OnError(ErrorEvent{Logger: l.Name(), Operation: operation, Err: err})
+}
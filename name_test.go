@@ -0,0 +1,89 @@
+// name_test.go: Tests for Logger names and OnError
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestName_DefaultsToBaseFilename(t *testing.T) {
+	dir := t.TempDir()
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "app.log")})
+	if got := logger.Name(); got != "app.log" {
+		t.Errorf("Name() = %q, want app.log", got)
+	}
+
+	named := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(dir, "audit.log"), Name: "audit"})
+	if got := named.Name(); got != "audit" {
+		t.Errorf("Name() = %q, want audit", got)
+	}
+	if got := named.Stats().Name; got != "audit" {
+		t.Errorf("Stats().Name = %q, want audit", got)
+	}
+
+	clone, err := named.Clone(filepath.Join(dir, "tenant.log"))
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	defer func() { _ = clone.Close() }()
+	if got := clone.Name(); got != "tenant.log" {
+		t.Errorf("clone Name() = %q, want tenant.log (not the original's name)", got)
+	}
+}
+
+// TestOnError_TagsEventsWithName routes two Loggers into one handler and
+// checks each report names its source.
+func TestOnError_TagsEventsWithName(t *testing.T) {
+	var mu sync.Mutex
+	var events []ErrorEvent
+	handler := func(event ErrorEvent) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+	var legacy []string
+
+	dir := t.TempDir()
+	var loggers []*Logger
+	for _, name := range []string{"api", "worker"} {
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:      filepath.Join(dir, name+".log"),
+			Name:          name,
+			OnError:       handler,
+			ErrorCallback: func(operation string, err error) { legacy = append(legacy, operation) },
+		})
+		loggers = append(loggers, logger)
+	}
+
+	cause := errors.New("disk on fire")
+	loggers[1].reportError("test_op", cause)
+
+	if len(events) != 1 {
+		t.Fatalf("OnError events = %+v, want 1", events)
+	}
+	if ev := events[0]; ev.Logger != "worker" || ev.Operation != "test_op" || !errors.Is(ev.Err, cause) {
+		t.Errorf("event = %+v, want worker/test_op/%v", ev, cause)
+	}
+	if len(legacy) != 1 || legacy[0] != "test_op" {
+		t.Errorf("ErrorCallback operations = %v, want unchanged [test_op]", legacy)
+	}
+}
+
+func TestOnError_PanicIsRecovered(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: filepath.Join(t.TempDir(), "panic.log"),
+		OnError:  func(ErrorEvent) { panic("boom") },
+	})
+
+	logger.reportError("test_op", errors.New("reported"))
+	if _, err := logger.Write([]byte("still writing\n")); err != nil {
+		t.Errorf("Write after a panicking OnError: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// newfromenv_test.go: Tests for NewFromEnv
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFromEnv_OverridesDefaults(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "env.log")
+	t.Setenv("ENVTEST_FILENAME", logFile)
+	t.Setenv("ENVTEST_MAX_SIZE", "5MB")
+	t.Setenv("ENVTEST_MAX_AGE", "2h")
+	t.Setenv("ENVTEST_MAX_BACKUPS", "4")
+	t.Setenv("ENVTEST_COMPRESS", "false")
+	t.Setenv("ENVTEST_BACKPRESSURE", "drop")
+
+	logger, err := NewFromEnv("ENVTEST")
+	if err != nil {
+		t.Fatalf("NewFromEnv: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	if logger.Filename != logFile {
+		t.Errorf("Filename = %q, want %q", logger.Filename, logFile)
+	}
+	if logger.MaxSizeStr != "5MB" {
+		t.Errorf("MaxSizeStr = %q, want 5MB", logger.MaxSizeStr)
+	}
+	if logger.MaxAge != 2*time.Hour {
+		t.Errorf("MaxAge = %v, want 2h", logger.MaxAge)
+	}
+	if logger.MaxBackups != 4 {
+		t.Errorf("MaxBackups = %d, want 4", logger.MaxBackups)
+	}
+	if logger.Compress {
+		t.Error("Compress = true, want false from env")
+	}
+	if logger.BackpressurePolicy != "drop" {
+		t.Errorf("BackpressurePolicy = %q, want drop", logger.BackpressurePolicy)
+	}
+	// Unset variables keep NewWithDefaults values
+	if !logger.Async || !logger.LocalTime {
+		t.Errorf("Async=%v LocalTime=%v, want defaults (true, true)", logger.Async, logger.LocalTime)
+	}
+}
+
+func TestNewFromEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantVar string
+	}{
+		{"missing filename", map[string]string{}, "ENVERR_FILENAME"},
+		{"bad size", map[string]string{"ENVERR_MAX_SIZE": "lots"}, "ENVERR_MAX_SIZE"},
+		{"bad age", map[string]string{"ENVERR_MAX_AGE": "soon"}, "ENVERR_MAX_AGE"},
+		{"bad backups", map[string]string{"ENVERR_MAX_BACKUPS": "many"}, "ENVERR_MAX_BACKUPS"},
+		{"bad bool", map[string]string{"ENVERR_ASYNC": "maybe"}, "ENVERR_ASYNC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantVar != "ENVERR_FILENAME" {
+				t.Setenv("ENVERR_FILENAME", filepath.Join(t.TempDir(), "env.log"))
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			logger, err := NewFromEnv("ENVERR")
+			if err == nil {
+				_ = logger.Close()
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantVar) {
+				t.Errorf("error %q does not name %s", err, tt.wantVar)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// newline.go: EnsureNewline line termination
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+// unterminated reports whether EnsureNewline must append '\n' to data.
+// Empty writes are left alone rather than turned into blank lines.
+func (l *Logger) unterminated(data []byte) bool {
+	return l.EnsureNewline && len(data) > 0 && data[len(data)-1] != '\n'
+}
+
+// terminate returns a copy of data followed by '\n'.
+//
+// WHY always copy, even for WriteOwned: ownership covers data[:len(data)]
+// only. Appending into spare capacity would scribble over whatever the
+// caller keeps past the end, e.g. the next record in a shared arena.
+func terminate(data []byte) []byte {
+	line := make([]byte, len(data)+1)
+	copy(line, data)
+	line[len(data)] = '\n'
+	return line
+}
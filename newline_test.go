@@ -0,0 +1,64 @@
+// newline_test.go: Tests for EnsureNewline
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEnsureNewline_TerminatesWrites(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		logFile := filepath.Join(t.TempDir(), "newline.log")
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:      logFile,
+			Async:         async,
+			EnsureNewline: true,
+		})
+
+		for _, msg := range []string{"foo", "bar\n", ""} {
+			n, err := logger.Write([]byte(msg))
+			if err != nil {
+				t.Fatalf("Write(%q): %v", msg, err)
+			}
+			if n != len(msg) {
+				t.Errorf("Write(%q) n = %d, want %d (the appended byte is not counted)", msg, n, len(msg))
+			}
+		}
+		if n, err := logger.WriteOwned([]byte("baz")); err != nil || n != 3 {
+			t.Errorf("WriteOwned = %d, %v; want 3, nil", n, err)
+		}
+		if n, err := logger.WriteBatch([][]byte{[]byte("a"), []byte("b\n")}); err != nil || n != 3 {
+			t.Errorf("WriteBatch = %d, %v; want 3, nil", n, err)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		if got, want := readLog(t, logFile), "foo\nbar\nbaz\na\nb\n"; got != want {
+			t.Errorf("async=%v: log = %q, want %q", async, got, want)
+		}
+	}
+}
+
+func TestEnsureNewline_OffByDefault(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "newline.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+	if _, err := logger.Write([]byte("foo")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := logger.Write([]byte("bar\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "foobar\n" {
+		t.Errorf("log = %q, want writes left untouched", got)
+	}
+}
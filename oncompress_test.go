@@ -0,0 +1,160 @@
+// oncompress_test.go: Tests for OnCompress and OnCleanup lifecycle callbacks
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestOnCompress_ReportsPathAndRatio verifies the callback fires after the
+// .gz is in place with a ratio reflecting the achieved compression.
+func TestOnCompress_ReportsPathAndRatio(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "compress.log")
+
+	var mu sync.Mutex
+	var src, gz string
+	var ratio float64
+	var gzExisted bool
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Compress: true,
+		OnCompress: func(srcPath, gzPath string, r float64) {
+			mu.Lock()
+			defer mu.Unlock()
+			src, gz, ratio = srcPath, gzPath, r
+			_, err := os.Stat(gzPath)
+			gzExisted = err == nil
+		},
+	})
+
+	// Highly repetitive data compresses very well
+	if _, err := logger.Write([]byte(strings.Repeat("aaaaaaaaaa", 1000))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	logger.WaitForBackgroundTasks()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gz == "" {
+		t.Fatal("OnCompress was never called")
+	}
+	if gz != src+".gz" {
+		t.Errorf("gzPath = %q, want %q", gz, src+".gz")
+	}
+	if !gzExisted {
+		t.Error("compressed file did not exist at callback time")
+	}
+	if ratio <= 0 || ratio >= 0.5 {
+		t.Errorf("ratio = %v, want a small positive ratio for repetitive data", ratio)
+	}
+}
+
+// TestOnCompress_PanicRecovered verifies a panicking callback does not kill
+// the worker and is reported via ErrorCallback.
+func TestOnCompress_PanicRecovered(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	var mu sync.Mutex
+	var ops []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        filepath.Join(tmpDir, "panic.log"),
+		Compress:        true,
+		CompressMinSize: -1,
+		OnCompress:      func(string, string, float64) { panic("boom") },
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, op)
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate: %v", err)
+		}
+		logger.WaitForBackgroundTasks()
+		time.Sleep(1100 * time.Millisecond) // distinct backup timestamps
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	panics := 0
+	for _, op := range ops {
+		if op == "on_compress_panic" {
+			panics++
+		}
+	}
+	if panics != 2 {
+		t.Errorf("on_compress_panic reported %d times, want 2 (worker must survive)", panics)
+	}
+}
+
+// TestOnCleanup_ReportsRemovedPaths verifies MaxBackups cleanup reports the
+// exact files it deleted.
+func TestOnCleanup_ReportsRemovedPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "cleanup.log")
+
+	// Pre-create old backups so cleanup has something to prune
+	old := []string{
+		logFile + ".2020-01-01-00-00-00",
+		logFile + ".2020-01-02-00-00-00",
+	}
+	for i, name := range old {
+		if err := os.WriteFile(name, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		mtime := time.Now().Add(-time.Duration(10-i) * time.Hour)
+		if err := os.Chtimes(name, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var removed []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		MaxBackups: 1,
+		OnCleanup: func(paths []string) {
+			mu.Lock()
+			defer mu.Unlock()
+			removed = append(removed, paths...)
+		},
+	})
+
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	logger.WaitForBackgroundTasks()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(removed) != 2 {
+		t.Fatalf("removed = %v, want the two old backups", removed)
+	}
+	for _, name := range old {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Errorf("%s still exists", name)
+		}
+	}
+}
@@ -378,3 +378,64 @@ func TestOnRotate_DirectLoggerStruct(t *testing.T) {
 		t.Error("OnRotate not called when set directly on Logger struct")
 	}
 }
+
+// TestOnRotate_PreviousFileIsPreCompression verifies that with Compress enabled
+// the callback receives the uncompressed backup path, which exists at call
+// time, and that the .gz only appears after background tasks complete.
+func TestOnRotate_PreviousFileIsPreCompression(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "upload.log")
+
+	var previous string
+	var existedAtCallback bool
+	config := &LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressMinSize: -1,
+		OnRotate: func(event RotationEvent) {
+			previous = event.PreviousFile
+			_, err := os.Stat(event.PreviousFile)
+			existedAtCallback = err == nil
+		},
+	}
+
+	logger, err := NewWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewWithConfig: %v", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte(strings.Repeat("payload ", 64) + "\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	logger.WaitForBackgroundTasks()
+
+	if previous == "" {
+		t.Fatal("OnRotate not called for manual Rotate()")
+	}
+	if strings.HasSuffix(previous, ".gz") {
+		t.Errorf("PreviousFile = %q, want pre-compression name", previous)
+	}
+	if !existedAtCallback {
+		t.Errorf("PreviousFile %q did not exist when callback ran", previous)
+	}
+	if _, err := os.Stat(previous + ".gz"); err != nil {
+		t.Errorf("compressed backup %q.gz missing after background tasks: %v", previous, err)
+	}
+}
+
+// TestBackgroundWorkers_StopKeepsCountAtZero guards against workers taking
+// zero-value tasks from the closed queue after stop, each of which would
+// decrement activeTasks without a matching submit.
+func TestBackgroundWorkers_StopKeepsCountAtZero(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		bg := newBackgroundWorkers(8)
+		bg.stop()
+		if got := bg.activeTasks.Load(); got != 0 {
+			t.Fatalf("activeTasks after stop = %d, want 0", got)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// open_other.go: Opening of the active log file on non-Windows platforms
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package lethe
+
+import "os"
+
+// openAppend opens path for appending, creating it with perm if needed.
+// Renaming an open file is always allowed here, so no share mode applies.
+func openAppend(path string, perm os.FileMode) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, perm) // #nosec G304 -- callers pass Filename, controlled by the application
+}
@@ -0,0 +1,47 @@
+// open_windows.go: Share-mode aware opening of the active log file on Windows
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package lethe
+
+import (
+	"os"
+	"syscall"
+)
+
+// Access rights os.OpenFile requests for O_WRONLY|O_APPEND, missing from
+// package syscall.
+const (
+	fileWriteEA         = 0x00000010
+	standardRightsWrite = 0x00020000
+)
+
+// openAppend opens path for appending, creating it with perm if needed.
+//
+// WHY not os.OpenFile: it omits FILE_SHARE_DELETE, and Windows refuses to
+// rename a file while any handle lacking it is open. With MultiProcess the
+// peers' handles stay open across a rotation, so every rename failed with a
+// sharing violation until they let go; sharing delete lets it through.
+func openAppend(path string, perm os.FileMode) (*os.File, error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+
+	attrs := uint32(syscall.FILE_ATTRIBUTE_NORMAL)
+	if perm&0200 == 0 {
+		attrs = syscall.FILE_ATTRIBUTE_READONLY
+	}
+	handle, err := syscall.CreateFile(name,
+		syscall.FILE_APPEND_DATA|syscall.FILE_WRITE_ATTRIBUTES|fileWriteEA|standardRightsWrite|syscall.SYNCHRONIZE,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_ALWAYS, attrs, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(handle), path), nil
+}
@@ -0,0 +1,75 @@
+// open_windows_test.go: Tests for share-mode opening on Windows
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestOpenAppend_RapidRotationWithOpenPeer rotates repeatedly while a
+// second Logger keeps the active file open, as a peer process would. Before
+// FILE_SHARE_DELETE every rename hit a sharing violation.
+func TestOpenAppend_RapidRotationWithOpenPeer(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "shared.log")
+
+	var mu sync.Mutex
+	var errs []error
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:     logFile,
+		MultiProcess: true,
+		MaxBackups:   100,
+		RetryCount:   1,
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	})
+	peer := newMultiProcessLogger(t, logFile)
+	if _, err := peer.Write([]byte("peer holds the file\n")); err != nil {
+		t.Fatalf("peer.Write: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.RotateSync(); err != nil {
+			t.Fatalf("rotation %d: %v", i, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, err := range errs {
+		t.Errorf("rotation reported: %v", err)
+	}
+}
+
+// TestOpenAppend_RenameWhileOpen verifies the handle itself does not block
+// renaming the file it refers to.
+func TestOpenAppend_RenameWhileOpen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "open.log")
+	file, err := openAppend(path, 0644)
+	if err != nil {
+		t.Fatalf("openAppend: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.Write([]byte("still writable\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Rename(path, filepath.Join(dir, "moved.log")); err != nil {
+		t.Fatalf("Rename with handle open: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// orphan_test.go: Tests for startup cleanup of orphaned temp files
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStartup_RemovesStaleOrphanTmpFiles(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	stale := time.Now().Add(-2 * orphanTmpGracePeriod)
+
+	files := map[string]bool{ // name -> should survive startup
+		"app.log.2025-01-02-15-04-05.gz.tmp":     false, // Crashed compression
+		"app.log.2025-01-02-15-04-05.1.gz.tmp":   false, // Crashed compression of a same-second backup
+		"app.log.2025-01-02-15-04-05.gz.enc.tmp": false, // Crashed encryption
+		"app.log.state.tmp":                      false, // Crashed state save
+		"app.log.notes.tmp":                      true,  // Not a Lethe temp name
+		"other.log.2025-01-02-15-04-05.gz.tmp":   true,  // Another log series
+		"download.tmp":                           true,  // Unrelated file in a shared dir
+	}
+	for name := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("partial"), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if err := os.Chtimes(path, stale, stale); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+	// A fresh temp file may belong to a rotation still in progress
+	fresh := "app.log.2025-01-02-15-04-06.gz.tmp"
+	files[fresh] = true
+	if err := os.WriteFile(filepath.Join(dir, fresh), []byte("partial"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+	if _, err := logger.Write([]byte("started\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	for name, survives := range files {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != survives {
+			t.Errorf("%s exists = %v, want %v", name, exists, survives)
+		}
+	}
+}
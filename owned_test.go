@@ -0,0 +1,114 @@
+// owned_test.go: Tests for WriteOwned ownership rules
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWriteOwned_SyncFallbackConsumesBeforeReturn stalls the consumer,
+// fills the ring, and then writes pooled buffers that fall back to sync.
+// Those are scribbled over and recycled as soon as WriteOwned returns;
+// the log must still hold every message intact.
+func TestWriteOwned_SyncFallbackConsumesBeforeReturn(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	logFile := filepath.Join(t.TempDir(), "owned.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           logFile,
+		Async:              true,
+		BufferSize:         8,
+		BackpressurePolicy: "fallback",
+		DisableAutoScale:   true,
+		// Parks the consumer on its first batch; other callers pass
+		RotateWhen: func(uint64, time.Duration) bool {
+			first := false
+			once.Do(func() { first = true })
+			if first {
+				close(entered)
+				<-release
+			}
+			return false
+		},
+	})
+
+	var want []string
+	write := func(i int, buf []byte) []byte {
+		t.Helper()
+		buf = fmt.Appendf(buf[:0], "owned message %03d\n", i)
+		want = append(want, strings.TrimSuffix(string(buf), "\n"))
+		if _, err := logger.WriteOwned(buf); err != nil {
+			t.Fatalf("WriteOwned: %v", err)
+		}
+		return buf
+	}
+
+	write(0, nil)
+	<-entered // The consumer is stuck with message 0
+
+	// Fill the ring: the Logger owns these until the consumer writes them
+	ringSize := int(logger.Stats().BufferSize)
+	for i := 1; i <= ringSize; i++ {
+		write(i, make([]byte, 0, 64))
+	}
+	if fill := logger.Stats().BufferFill; fill != uint64(ringSize) {
+		t.Fatalf("BufferFill = %d, want a full ring (%d)", fill, ringSize)
+	}
+
+	// Every further write falls back to sync. Reuse one pooled buffer for
+	// all of them, clobbering it right after each return.
+	pool := sync.Pool{New: func() any { b := make([]byte, 0, 64); return &b }}
+	for i := ringSize + 1; i <= ringSize+50; i++ {
+		pooled := pool.Get().(*[]byte)
+		*pooled = write(i, *pooled)
+		copy(*pooled, bytes.Repeat([]byte{'X'}, len(*pooled)))
+		pool.Put(pooled)
+	}
+
+	close(release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := strings.Split(strings.TrimSuffix(readLog(t, logFile), "\n"), "\n")
+	sort.Strings(got)
+	sort.Strings(want)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("log lines differ from what was written:\ngot:\n%s\nwant:\n%s",
+			strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestWriteOwned_SpareCapacityUntouched verifies EnsureNewline does not
+// append into capacity the caller still owns.
+func TestWriteOwned_SpareCapacityUntouched(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "owned.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, EnsureNewline: true})
+
+	arena := []byte("firstSECOND")
+	if _, err := logger.WriteOwned(arena[:5]); err != nil {
+		t.Fatalf("WriteOwned: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if string(arena) != "firstSECOND" {
+		t.Errorf("arena = %q, want the bytes past len(data) untouched", arena)
+	}
+	if got := readLog(t, logFile); got != "first\n" {
+		t.Errorf("log = %q, want %q", got, "first\n")
+	}
+}
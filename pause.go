@@ -0,0 +1,158 @@
+// pause.go: Pause/Resume for maintenance windows
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPaused is returned by writes rejected while the Logger is paused
+// (PausePolicy "error", or the pause buffer is full) and by Sync,
+// RotateSync and RotateNamed during a pause.
+var ErrPaused = errors.New("lethe: logger is paused")
+
+// Pause policies
+const (
+	PausePolicyBuffer = "buffer" // Hold writes in memory until Resume (default)
+	PausePolicyError  = "error"  // Reject writes with ErrPaused
+)
+
+// defaultPauseBufferBytes caps held writes when MaxBufferBytes is unset.
+const defaultPauseBufferBytes = 64 << 20
+
+// Pause stops Lethe from touching the filesystem, e.g. while the log
+// volume is migrated. When Pause returns, in-flight writes and flushes
+// have completed, the async consumer has stopped draining, and queued
+// background tasks (compression, cleanup) have finished. Until Resume:
+//
+//   - Writes are held in memory (PausePolicy "buffer", up to
+//     MaxBufferBytes or 64 MiB when unset) or rejected with ErrPaused
+//     (PausePolicy "error"). A held write that does not fit fails with
+//     ErrPaused. Messages already in the async buffer stay there.
+//   - Size, age and calendar rotation are deferred, Rotate is a no-op,
+//     and Sync, RotateSync and RotateNamed return ErrPaused.
+//   - Periodic fsync (SyncInterval) is skipped. BufferedSync data was
+//     written out before Pause returned.
+//
+// Pause is idempotent. Close resumes implicitly and then drains as usual.
+func (l *Logger) Pause() {
+	l.pauseMu.Lock()
+	defer l.pauseMu.Unlock()
+	if l.paused.Load() {
+		return
+	}
+
+	// Publish the wake-up channel before the flag, so a consumer that
+	// sees paused=true always waits on this pause's channel
+	resume := make(chan struct{})
+	l.resumeCh.Store(&resume)
+	l.holdWrites.Store(true)
+	l.paused.Store(true)
+
+	// Either an operation sees the flags (and backs off), or we see its
+	// fsInFlight increment and wait for it: same protocol as scaleDown
+	for l.fsInFlight.Load() != 0 {
+		time.Sleep(100 * time.Microsecond)
+	}
+	if err := l.flushSyncBuffer(); err != nil {
+		l.reportWriteError("buffered_flush", err)
+	}
+	l.WaitForBackgroundTasks()
+}
+
+// Resume undoes Pause: the async consumer drains again and writes held
+// during the pause are written, in order, before any write issued after
+// Resume. Resume is a no-op when the Logger is not paused.
+func (l *Logger) Resume() {
+	l.pauseMu.Lock()
+	defer l.pauseMu.Unlock()
+	if !l.paused.Load() {
+		return
+	}
+
+	// Let the consumer drain older buffered messages first; new writers
+	// stay held (blocked on pauseMu) until the replay below is done
+	l.paused.Store(false)
+	if resume := l.resumeCh.Load(); resume != nil {
+		close(*resume)
+	}
+
+	held := l.held
+	l.held, l.heldBytes = nil, 0
+	for _, msg := range held {
+		if _, err := l.routeOwned(msg); err != nil {
+			l.reportError("resume_write", err)
+		}
+	}
+	l.holdWrites.Store(false)
+}
+
+// Paused reports whether the Logger is paused.
+func (l *Logger) Paused() bool {
+	return l.holdWrites.Load()
+}
+
+// enterFS marks the start of an operation that may touch the filesystem.
+// It returns false, without entering, while writes are held by Pause.
+// Callers that get true must call exitFS when done.
+func (l *Logger) enterFS() bool {
+	l.fsInFlight.Add(1)
+	if l.holdWrites.Load() {
+		l.fsInFlight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// exitFS ends an operation started with enterFS.
+func (l *Logger) exitFS() {
+	l.fsInFlight.Add(-1)
+}
+
+// writePaused handles a write that arrived while paused. owned reports
+// whether data may be kept without copying. route writes it normally if
+// Resume completed in the meantime.
+func (l *Logger) writePaused(data []byte, owned bool, route func([]byte) (int, error)) (int, error) {
+	if l.PausePolicy == PausePolicyError {
+		return 0, ErrPaused
+	}
+
+	l.pauseMu.Lock()
+	if !l.holdWrites.Load() {
+		l.pauseMu.Unlock()
+		return route(data) // Resumed while we waited for the lock
+	}
+	defer l.pauseMu.Unlock()
+
+	limit := l.MaxBufferBytes
+	if limit <= 0 {
+		limit = defaultPauseBufferBytes
+	}
+	if l.heldBytes+int64(len(data)) > limit {
+		return 0, ErrPaused
+	}
+
+	if !owned {
+		data = append([]byte(nil), data...)
+	}
+	l.held = append(l.held, data)
+	l.heldBytes += int64(len(data))
+	return len(data), nil
+}
+
+// waitForResume blocks the consumer while paused, or until shutdown.
+func (c *MPSCConsumer) waitForResume() {
+	resume := c.logger.resumeCh.Load()
+	if resume == nil {
+		return
+	}
+	select {
+	case <-*resume:
+	case <-c.ctx.Done():
+	}
+}
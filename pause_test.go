@@ -0,0 +1,138 @@
+// pause_test.go: Tests for Pause and Resume
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func mustWrite(t *testing.T, logger *Logger, s string) {
+	t.Helper()
+	if _, err := logger.Write([]byte(s)); err != nil {
+		t.Fatalf("Write(%q): %v", s, err)
+	}
+}
+
+func TestPause_HoldsWritesUntilResume(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "app.log")
+			logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Async: async})
+
+			mustWrite(t, logger, "before\n")
+			if err := logger.Sync(); err != nil {
+				t.Fatalf("Sync: %v", err)
+			}
+			logger.Pause()
+			if !logger.Paused() {
+				t.Fatal("Paused() = false after Pause")
+			}
+			mustWrite(t, logger, "during 1\n")
+			mustWrite(t, logger, "during 2\n")
+
+			time.Sleep(20 * time.Millisecond) // Give a consumer time to misbehave
+			if got := readLog(t, logFile); got != "before\n" {
+				t.Fatalf("file changed while paused: %q", got)
+			}
+
+			logger.Resume()
+			mustWrite(t, logger, "after\n")
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			want := "before\nduring 1\nduring 2\nafter\n"
+			if got := readLog(t, logFile); got != want {
+				t.Errorf("log = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPause_ErrorPolicy(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{PausePolicy: PausePolicyError})
+	defer func() { _ = logger.Close() }()
+	mustWrite(t, logger, "before\n")
+
+	logger.Pause()
+	if _, err := logger.Write([]byte("rejected\n")); !errors.Is(err, ErrPaused) {
+		t.Errorf("Write error = %v, want ErrPaused", err)
+	}
+	if err := logger.Sync(); !errors.Is(err, ErrPaused) {
+		t.Errorf("Sync error = %v, want ErrPaused", err)
+	}
+	if err := logger.RotateSync(); !errors.Is(err, ErrPaused) {
+		t.Errorf("RotateSync error = %v, want ErrPaused", err)
+	}
+
+	logger.Resume()
+	mustWrite(t, logger, "accepted\n")
+}
+
+func TestPause_BufferLimit(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{MaxBufferBytes: 16})
+	defer func() { _ = logger.Close() }()
+
+	logger.Pause()
+	mustWrite(t, logger, "0123456789\n")
+	if _, err := logger.Write([]byte("0123456789\n")); !errors.Is(err, ErrPaused) {
+		t.Errorf("Write past the limit error = %v, want ErrPaused", err)
+	}
+}
+
+func TestPause_CloseDrainsHeldWrites(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Async: true})
+
+	logger.Pause()
+	mustWrite(t, logger, "held\n")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := readLog(t, logFile); got != "held\n" {
+		t.Errorf("log = %q, want the held write", got)
+	}
+}
+
+func TestPause_ConcurrentWritersLoseNothing(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	const writers, perWriter = 8, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := fmt.Fprintf(logger, "w%d-%d\n", w, i); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+	for i := 0; i < 20; i++ {
+		logger.Pause()
+		time.Sleep(time.Millisecond)
+		logger.Resume()
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if lines := strings.Count(readLog(t, logFile), "\n"); lines != writers*perWriter {
+		t.Errorf("lines = %d, want %d", lines, writers*perWriter)
+	}
+}
@@ -0,0 +1,73 @@
+// prealloc.go: Disk space preallocation for the active log file
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// errPreallocUnsupported marks a platform or filesystem without
+// preallocation; Preallocate is then silently a no-op.
+var errPreallocUnsupported = errors.New("preallocation not supported")
+
+// preallocate reserves MaxSize bytes of disk blocks for a freshly opened
+// active file, so appends do not allocate (and fragment) block by block.
+// The file size, and so bytesWritten and size-based rotation, is unchanged.
+func (l *Logger) preallocate(file *os.File) {
+	l.preallocated.Store(0)
+	if !l.Preallocate {
+		return
+	}
+	l.initSizeConfig()
+	size := l.maxSizeBytes.Load()
+	if size <= 0 {
+		return // No size limit to reserve up to
+	}
+
+	err := reserveBlocks(file, size)
+	if errors.Is(err, errPreallocUnsupported) {
+		return
+	}
+	if err != nil {
+		l.reportError("preallocate", fmt.Errorf("failed to preallocate %d bytes for %s: %w", size, file.Name(), err))
+		return
+	}
+	l.preallocated.Store(size)
+}
+
+// releasePreallocation frees the reserved blocks a sealed backup did not
+// use, so backups occupy only their real size on disk. Called after the
+// rename, once the active file is closed and writes go to its successor.
+func (l *Logger) releasePreallocation(backupName string) {
+	reserved := l.preallocated.Swap(0)
+	if reserved <= 0 {
+		return
+	}
+
+	file, err := os.OpenFile(backupName, os.O_WRONLY, 0) // #nosec G304 -- backupName is generated internally, not user input
+	if err != nil {
+		l.reportError("preallocate_release", err)
+		return
+	}
+	defer func() { _ = file.Close() }() // Nothing was written; close error is not actionable
+
+	info, err := file.Stat()
+	if err != nil {
+		l.reportError("preallocate_release", err)
+		return
+	}
+	// WHY truncate rather than FALLOC_FL_PUNCH_HOLE: ext4 ignores holes
+	// punched past the end of file, while truncating to the current size
+	// frees every block beyond it on all filesystems
+	if reserved > info.Size() {
+		if err := file.Truncate(info.Size()); err != nil {
+			l.reportError("preallocate_release", fmt.Errorf("failed to release preallocated space of %s: %w", backupName, err))
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// prealloc_linux.go: fallocate-based preallocation for Linux
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// fallocKeepSize is FALLOC_FL_KEEP_SIZE (linux/falloc.h): reserve blocks
+// without changing the file size. Not exported by package syscall.
+const fallocKeepSize = 0x01
+
+// reserveBlocks allocates blocks for [0, size) without changing the file
+// size, so O_APPEND writes still land at the real end of data.
+func reserveBlocks(file *os.File, size int64) error {
+	return fallocate(file, fallocKeepSize, 0, size)
+}
+
+func fallocate(file *os.File, mode uint32, offset, length int64) error {
+	for {
+		err := syscall.Fallocate(int(file.Fd()), mode, offset, length) // #nosec G115 -- file descriptors fit in int
+		if err == syscall.EINTR {
+			continue
+		}
+		// Filesystems without fallocate (or this mode) simply skip it
+		if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.ENOSYS) {
+			return errPreallocUnsupported
+		}
+		return err
+	}
+}
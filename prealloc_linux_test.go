@@ -0,0 +1,95 @@
+// prealloc_linux_test.go: Tests for fallocate-based preallocation
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// allocatedBytes returns the disk space allocated to path.
+func allocatedBytes(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat %s: %v", path, err)
+	}
+	return info.Sys().(*syscall.Stat_t).Blocks * 512
+}
+
+func TestPreallocate_ReservesWithoutChangingSize(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "prealloc.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		MaxSizeStr:       "1MB",
+		Preallocate:      true,
+		DisableAutoScale: true,
+	})
+
+	line := []byte("preallocated line\n")
+	if _, err := logger.Write(line); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if logger.preallocated.Load() == 0 {
+		t.Skip("filesystem does not support fallocate")
+	}
+
+	info, err := os.Stat(logFile)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Size() != int64(len(line)) {
+		t.Errorf("file size = %d, want %d (reservation must not change the size)", info.Size(), len(line))
+	}
+	if got := logger.Stats().CurrentFileSize; got != uint64(len(line)) {
+		t.Errorf("CurrentFileSize = %d, want %d", got, len(line))
+	}
+	if got := allocatedBytes(t, logFile); got < 1024*1024 {
+		t.Errorf("allocated %d bytes, want at least 1MB", got)
+	}
+
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+	backups, _ := filepath.Glob(logFile + ".*")
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %v", backups)
+	}
+	if got := allocatedBytes(t, backups[0]); got >= 1024*1024 {
+		t.Errorf("backup still holds %d bytes after rotation, want the reservation released", got)
+	}
+	if got := allocatedBytes(t, logFile); got < 1024*1024 {
+		t.Errorf("new active file allocated %d bytes, want at least 1MB", got)
+	}
+}
+
+func TestPreallocate_SizeRotationStillTriggers(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "prealloc.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         logFile,
+		MaxSizeStr:       "1KB",
+		Preallocate:      true,
+		DisableAutoScale: true,
+	})
+
+	line := make([]byte, 100)
+	line[len(line)-1] = '\n'
+	for i := 0; i < 30; i++ {
+		if _, err := logger.Write(line); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	logger.WaitForBackgroundTasks()
+
+	if got := logger.Stats().SizeRotations; got == 0 {
+		t.Error("expected size rotations with a preallocated file, got none")
+	}
+}
@@ -0,0 +1,14 @@
+// prealloc_other.go: Preallocation fallback for platforms without fallocate
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package lethe
+
+import "os"
+
+// reserveBlocks is unsupported: Preallocate is a no-op here.
+func reserveBlocks(file *os.File, size int64) error { return errPreallocUnsupported }
@@ -0,0 +1,90 @@
+// recent.go: In-memory ring of the most recent writes (RecentBufferSize)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "sync/atomic"
+
+// recentRing keeps copies of the last len(slots) writes, independent of
+// the MPSC ring: entries stay readable after the consumer has drained
+// them, and messages still queued (or lost in a crash) are included.
+//
+// WHY lock-free: RecentLogs is meant for panic handlers, which must not
+// block on a lock a panicking writer may hold. Each writer claims a
+// sequence number and publishes an immutable entry into its slot, so a
+// reader only has to skip slots that are not yet published or already
+// overwritten by a newer sequence.
+type recentRing struct {
+	next  atomic.Uint64
+	slots []atomic.Pointer[recentEntry]
+}
+
+// recentEntry is one recorded write; never modified once published.
+type recentEntry struct {
+	seq  uint64
+	data []byte
+}
+
+// recordRecent copies data into the recent ring, if RecentBufferSize is set.
+func (l *Logger) recordRecent(data []byte) {
+	if l.RecentBufferSize <= 0 {
+		return
+	}
+	r := l.recent.Load()
+	if r == nil {
+		// Lazily created so Loggers built as struct literals work too
+		l.recent.CompareAndSwap(nil, &recentRing{slots: make([]atomic.Pointer[recentEntry], l.RecentBufferSize)})
+		r = l.recent.Load()
+	}
+
+	seq := r.next.Add(1) - 1
+	entry := &recentEntry{seq: seq, data: append([]byte(nil), data...)}
+	r.slots[seq%uint64(len(r.slots))].Store(entry)
+}
+
+// RecentLogs returns copies of the most recent writes, oldest first, up to
+// RecentBufferSize of them. Each entry is one write as submitted (after
+// Transform, PreWriteHook and EnsureNewline); a WriteBatch counts as one.
+// Entries are recorded before they are queued, so the result includes
+// messages not yet flushed to disk. Returns nil when RecentBufferSize is
+// not set or nothing was written.
+//
+// Safe to call concurrently with writes and after Close, which makes it
+// suitable for a panic handler:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			for _, line := range logger.RecentLogs() {
+//				os.Stderr.Write(line)
+//			}
+//			panic(r)
+//		}
+//	}()
+func (l *Logger) RecentLogs() [][]byte {
+	r := l.recent.Load()
+	if r == nil {
+		return nil
+	}
+
+	end := r.next.Load()
+	size := uint64(len(r.slots))
+	start := uint64(0)
+	if end > size {
+		start = end - size
+	}
+
+	logs := make([][]byte, 0, end-start)
+	for seq := start; seq < end; seq++ {
+		// A writer may have claimed seq but not published it yet, or a
+		// newer write may have replaced it since end was read
+		entry := r.slots[seq%size].Load()
+		if entry == nil || entry.seq != seq {
+			continue
+		}
+		logs = append(logs, append([]byte(nil), entry.data...))
+	}
+	return logs
+}
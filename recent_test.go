@@ -0,0 +1,95 @@
+// recent_test.go: Tests for RecentBufferSize and RecentLogs
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// TestRecentLogs_KeepsLastN verifies only the newest RecentBufferSize
+// writes are returned, oldest first, including ones still queued.
+func TestRecentLogs_KeepsLastN(t *testing.T) {
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "recent.log"),
+		Async:            true,
+		Tee:              tee,
+		RecentBufferSize: 3,
+		EnsureNewline:    true,
+	})
+	defer close(tee.release)
+
+	for i := 0; i < 5; i++ {
+		if _, err := logger.Write([]byte(fmt.Sprintf("entry %d", i))); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	<-tee.entered // The consumer is stuck: later entries are not on disk
+
+	got := logger.RecentLogs()
+	want := []string{"entry 2\n", "entry 3\n", "entry 4\n"}
+	if len(got) != len(want) {
+		t.Fatalf("RecentLogs returned %d entries, want %d: %q", len(got), len(want), got)
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	// The result is a copy
+	got[0][0] = 'X'
+	if string(logger.RecentLogs()[0]) != want[0] {
+		t.Error("modifying the result changed the ring")
+	}
+}
+
+func TestRecentLogs_Disabled(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "recent.log")})
+
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.RecentLogs(); got != nil {
+		t.Errorf("RecentLogs without RecentBufferSize = %q, want nil", got)
+	}
+}
+
+// TestRecentLogs_Concurrent reads the ring while writers wrap it; run
+// with -race.
+func TestRecentLogs_Concurrent(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "recent.log"),
+		RecentBufferSize: 8,
+	})
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				_, _ = logger.Write([]byte(fmt.Sprintf("w%d-%03d\n", w, i)))
+			}
+		}(w)
+	}
+	for i := 0; i < 100; i++ {
+		for _, entry := range logger.RecentLogs() {
+			if len(entry) != len("w0-000\n") {
+				t.Fatalf("torn entry %q", entry)
+			}
+		}
+	}
+	wg.Wait()
+
+	if got := len(logger.RecentLogs()); got != 8 {
+		t.Errorf("RecentLogs returned %d entries after 800 writes, want 8", got)
+	}
+}
@@ -0,0 +1,105 @@
+// reconcile_test.go: Tests for retention enforcement at startup
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// seedBackups creates n backups of logFile, the i-th one i hours old.
+func seedBackups(t *testing.T, logFile string, n int) []string {
+	t.Helper()
+	var paths []string
+	for i := 0; i < n; i++ {
+		path := fmt.Sprintf("%s.2025-01-%02d-00-00-00", logFile, i+1)
+		if err := os.WriteFile(path, []byte("old run\n"), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		mtime := time.Now().Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// TestReconcile_PrunesBackupsOnOpen verifies backups left by an earlier
+// run are pruned when the log file is opened, not at the next rotation.
+func TestReconcile_PrunesBackupsOnOpen(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	backups := seedBackups(t, logFile, 6)
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		MaxSizeStr: "100MB",
+		MaxBackups: 3,
+		MaxFileAge: 150 * time.Minute, // Also expires backups[3:] by age
+	})
+
+	if _, err := logger.Write([]byte("first entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().RotationCount; got != 0 {
+		t.Fatalf("RotationCount = %d, want 0", got)
+	}
+
+	for i, path := range backups {
+		_, err := os.Stat(path)
+		if i < 3 && err != nil {
+			t.Errorf("recent backup %s removed: %v", filepath.Base(path), err)
+		}
+		if i >= 3 && !os.IsNotExist(err) {
+			t.Errorf("backup %s survived startup (err=%v)", filepath.Base(path), err)
+		}
+	}
+}
+
+// TestReconcile_AfterReconfigure verifies ReconcileRetention enforces a
+// tightened policy without waiting for a rotation.
+func TestReconcile_AfterReconfigure(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, MaxSizeStr: "100MB"})
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	backups := seedBackups(t, logFile, 4)
+	if err := logger.ReconfigureRetention(RetentionPolicy{MaxBackups: 1}); err != nil {
+		t.Fatalf("ReconfigureRetention: %v", err)
+	}
+	if err := logger.ReconcileRetention(); err != nil {
+		t.Fatalf("ReconcileRetention: %v", err)
+	}
+
+	if _, err := os.Stat(backups[0]); err != nil {
+		t.Errorf("newest backup removed: %v", err)
+	}
+	for _, path := range backups[1:] {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("backup %s kept past MaxBackups=1 (err=%v)", filepath.Base(path), err)
+		}
+	}
+	if _, err := os.Stat(logFile); err != nil {
+		t.Errorf("active file touched: %v", err)
+	}
+}
+
+func TestReconcile_Paused(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "app.log")})
+
+	logger.Pause()
+	defer logger.Resume()
+	if err := logger.ReconcileRetention(); err != ErrPaused {
+		t.Errorf("ReconcileRetention while paused = %v, want ErrPaused", err)
+	}
+}
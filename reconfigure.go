@@ -8,6 +8,7 @@ package lethe
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -26,7 +27,7 @@ type RetentionPolicy struct {
 	// Zero retains all backups.
 	MaxBackups int
 
-	// Compress enables gzip compression of rotated files.
+	// Compress enables compression of rotated files (see Logger.Compression).
 	Compress bool
 
 	// Checksum enables SHA-256 checksum calculation for file integrity.
@@ -36,7 +37,8 @@ type RetentionPolicy struct {
 
 // ReconfigureRetention atomically replaces the active retention policy.
 // Safe to call from any goroutine while the Logger is running.
-// Changes take effect on the next rotation cycle.
+// Changes take effect on the next rotation cycle, or at once with
+// ReconcileRetention.
 //
 // Returns an error if the policy is invalid (negative MaxBackups or MaxFileAge).
 func (l *Logger) ReconfigureRetention(policy RetentionPolicy) error {
@@ -51,6 +53,22 @@ func (l *Logger) ReconfigureRetention(policy RetentionPolicy) error {
 	return nil
 }
 
+// ReconcileRetention applies MaxBackups and MaxFileAge to the backups on
+// disk now instead of at the next rotation. The Logger does this once on
+// its own when it opens Filename, so backups left from before a restart
+// are pruned even when the service rarely rotates; call it again after
+// ReconfigureRetention to enforce a tighter policy at once.
+//
+// Returns ErrPaused while paused, else the first removal failure; every
+// failure is also reported via ErrorCallback.
+func (l *Logger) ReconcileRetention() error {
+	if !l.enterFS() {
+		return ErrPaused
+	}
+	defer l.exitFS()
+	return l.cleanupOldFiles()
+}
+
 // effectiveRetention returns the active retention policy.
 // Prefers the atomically stored policy if set; falls back to the
 // construction-time fields for zero-allocation backward compatibility.
@@ -65,3 +83,70 @@ func (l *Logger) effectiveRetention() RetentionPolicy {
 		Checksum:   l.Checksum,
 	}
 }
+
+// rotationLimitDisabled marks a size or age limit turned off at runtime,
+// distinct from 0 ("not set yet, use the construction-time field").
+const rotationLimitDisabled = -1
+
+// SetMaxSize changes the size threshold for rotation at runtime, without
+// reopening the file (e.g., to rotate sooner under disk pressure). It
+// accepts ParseSize formats ("50MB", "512K"); "0" disables size-based
+// rotation. Overrides MaxSize and MaxSizeStr.
+//
+// Safe to call from any goroutine. The new limit applies from the next
+// write; if the active file is already at or above it, that write
+// triggers rotation.
+func (l *Logger) SetMaxSize(s string) error {
+	size, err := ParseSize(s)
+	if err != nil {
+		return fmt.Errorf("lethe: SetMaxSize: %w", err)
+	}
+	if size < 0 {
+		return errors.New("lethe: SetMaxSize: size must be >= 0")
+	}
+	if size == 0 {
+		size = rotationLimitDisabled
+	}
+	l.maxSizeBytes.Store(size)
+	return nil
+}
+
+// SetMaxAge changes the age threshold for rotation at runtime. It accepts
+// ParseDuration formats ("1h", "7d"); "0" disables time-based rotation.
+// Overrides MaxAge and MaxAgeStr.
+//
+// Safe to call from any goroutine. The new limit applies from the next
+// write; if the active file is already older, that write triggers
+// rotation.
+func (l *Logger) SetMaxAge(s string) error {
+	age, err := ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("lethe: SetMaxAge: %w", err)
+	}
+	if age < 0 {
+		return errors.New("lethe: SetMaxAge: age must be >= 0")
+	}
+	if age == 0 {
+		age = rotationLimitDisabled
+	}
+	l.maxAgeOverride.Store(int64(age))
+	return nil
+}
+
+// SetErrorCallback replaces ErrorCallback on a running Logger; nil stops
+// error reports (OnError is unaffected). Safe to call from any goroutine,
+// including while background tasks report errors: each report uses either
+// the old or the new callback. Use it instead of assigning the
+// ErrorCallback field after the first write, which is a data race.
+func (l *Logger) SetErrorCallback(fn func(operation string, err error)) {
+	l.errorCallback.Store(&fn)
+}
+
+// errorCallbackFunc returns the callback set by SetErrorCallback, or else
+// the ErrorCallback field.
+func (l *Logger) errorCallbackFunc() func(operation string, err error) {
+	if fn := l.errorCallback.Load(); fn != nil {
+		return *fn
+	}
+	return l.ErrorCallback
+}
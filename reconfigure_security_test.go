@@ -170,7 +170,7 @@ func TestReconfigureRetention_Security_NoDeadlockDuringRotation(t *testing.T) {
 	dir := t.TempDir()
 	l, err := NewWithConfig(&LoggerConfig{
 		Filename:   filepath.Join(dir, "t.log"),
-		MaxSizeStr: "1B",
+		MaxSizeStr: "1",
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -413,3 +413,147 @@ func TestReconfigureRetention_ChecksumReflectedInEffectivePolicy(t *testing.T) {
 		t.Errorf("MaxBackups: got %d, want 5", ret.MaxBackups)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// SetMaxSize / SetMaxAge — runtime rotation limits
+// ---------------------------------------------------------------------------
+
+func TestSetMaxSize_ShrinkRotatesOnNextWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(dir, "test.log"),
+		MaxSizeStr: "10MB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	if _, err := l.Write(make([]byte, 2048)); err != nil {
+		t.Fatal(err)
+	}
+	if l.Stats().RotationCount != 0 {
+		t.Fatal("rotated before the limit was lowered")
+	}
+
+	if err := l.SetMaxSize("1KB"); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.Stats().MaxSizeBytes; got != 1024 {
+		t.Errorf("MaxSizeBytes: got %d, want 1024", got)
+	}
+	if _, err := l.Write([]byte("x\n")); err != nil {
+		t.Fatal(err)
+	}
+	if l.Stats().RotationCount != 1 {
+		t.Errorf("RotationCount: got %d, want 1 after shrinking below the current size", l.Stats().RotationCount)
+	}
+}
+
+func TestSetMaxSize_ZeroDisables(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(dir, "test.log"),
+		MaxSizeStr: "1KB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	if err := l.SetMaxSize("0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Write(make([]byte, 4096)); err != nil {
+		t.Fatal(err)
+	}
+	if l.Stats().RotationCount != 0 || l.Stats().MaxSizeBytes != 0 {
+		t.Errorf("size rotation still active: %+v", l.Stats())
+	}
+}
+
+func TestSetMaxAge_AppliesToActiveFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:  filepath.Join(dir, "test.log"),
+		MaxAgeStr: "24h",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	if _, err := l.Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	l.fileCreated.Store(time.Now().Add(-2 * time.Hour).Unix())
+
+	if err := l.SetMaxAge("1h"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Write([]byte("second\n")); err != nil {
+		t.Fatal(err)
+	}
+	if l.Stats().RotationCount != 1 {
+		t.Errorf("RotationCount: got %d, want 1 for a file older than the new MaxAge", l.Stats().RotationCount)
+	}
+}
+
+func TestSetMaxSizeAndAge_RejectInvalid(t *testing.T) {
+	t.Parallel()
+
+	l := &Logger{Filename: filepath.Join(t.TempDir(), "test.log"), MaxSizeStr: "1MB"}
+	for _, s := range []string{"", "lots", "-5"} {
+		if err := l.SetMaxSize(s); err == nil {
+			t.Errorf("SetMaxSize(%q) succeeded, want error", s)
+		}
+	}
+	for _, s := range []string{"", "soon", "-1h"} {
+		if err := l.SetMaxAge(s); err == nil {
+			t.Errorf("SetMaxAge(%q) succeeded, want error", s)
+		}
+	}
+	if l.maxSizeBytes.Load() != 0 || l.maxAgeOverride.Load() != 0 {
+		t.Error("limits changed despite validation errors")
+	}
+}
+
+func TestSetMaxSize_ConcurrentWithWrites(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(dir, "test.log"),
+		MaxSizeStr: "64KB",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				_, _ = l.Write([]byte("concurrent write\n"))
+			}
+		}()
+	}
+	for _, s := range []string{"8KB", "16KB", "4KB", "0", "32KB"} {
+		if err := l.SetMaxSize(s); err != nil {
+			t.Error(err)
+		}
+		if err := l.SetMaxAge("1h"); err != nil {
+			t.Error(err)
+		}
+	}
+	wg.Wait()
+}
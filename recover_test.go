@@ -0,0 +1,130 @@
+// recover_test.go: Tests for panic recovery in background goroutines
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// panickingEncryptor panics on Encrypt, standing in for a buggy task.
+type panickingEncryptor struct{}
+
+func (panickingEncryptor) Encrypt(dst io.Writer, src io.Reader) error { panic("encryptor bug") }
+
+func (panickingEncryptor) Decrypt(src io.Reader) (io.Reader, error) { panic("encryptor bug") }
+
+func TestBackgroundWorker_RecoversFromTaskPanic(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "worker-panic.log")
+	var mu sync.Mutex
+	var panics []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:          logFile,
+		BackgroundWorkers: 1, // The only worker must survive
+		ErrorCallback: func(operation string, err error) {
+			if operation == "worker_panic" {
+				mu.Lock()
+				panics = append(panics, err.Error())
+				mu.Unlock()
+			}
+		},
+	})
+	logger.Encryptor = panickingEncryptor{}
+
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Fatalf("RotateSync error = %v, want task panic", err)
+	}
+	mu.Lock()
+	if len(panics) != 1 || !strings.Contains(panics[0], "encryptor bug") {
+		t.Errorf("worker_panic reports = %v, want one naming the panic", panics)
+	}
+	mu.Unlock()
+
+	// The pool keeps working after the panic
+	logger.Encryptor = nil
+	logger.Checksum = true
+	if _, err := logger.Write([]byte("second\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync after panic: %v", err)
+	}
+	if sums, _ := filepath.Glob(logFile + ".*.sha256"); len(sums) != 1 {
+		t.Errorf("checksum sidecars = %v, want 1 from the surviving worker", sums)
+	}
+}
+
+func TestMPSCConsumer_RecoversFromPanic(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "consumer-panic.log")
+	var panicked, recovered atomic.Bool
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:          logFile,
+		Async:             true,
+		RecreateIfMissing: true,
+		ErrorCallback: func(operation string, err error) {
+			switch operation {
+			case "file_vanished":
+				if panicked.CompareAndSwap(false, true) {
+					panic("callback bug")
+				}
+			case "consumer_panic":
+				recovered.Store(true)
+			}
+		},
+	})
+
+	if _, err := logger.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	waitForEmptyBuffer(t, logger)
+
+	// Make the consumer's next presence check report file_vanished, whose
+	// callback panics on the consumer goroutine
+	if err := os.Remove(logFile); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	logger.lastFileCheck.Store(0)
+	if _, err := logger.Write([]byte("trigger\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !recovered.Load() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !recovered.Load() {
+		t.Fatal("consumer_panic was not reported")
+	}
+
+	// The consumer is still draining the ring
+	for i := 0; i < 10; i++ {
+		if _, err := logger.Write([]byte("after\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	waitForEmptyBuffer(t, logger)
+}
+
+// waitForEmptyBuffer waits for the MPSC consumer to drain the ring.
+func waitForEmptyBuffer(t *testing.T, logger *Logger) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.Stats().BufferFill > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("buffer not drained: %+v", logger.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
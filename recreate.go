@@ -0,0 +1,136 @@
+// recreate.go: Reopen the active log file after external deletion or replacement
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileCheckInterval bounds how often the active path is stat'ed.
+// WHY not on every write: a stat per write would double syscalls on the
+// hot path; a vanished file is an operator error, so detecting it within
+// a second is enough.
+const fileCheckInterval = time.Second
+
+// ensureFilePresent reopens Filename when it no longer refers to the open
+// fd (deleted or replaced externally). Without this, writes go to an
+// unlinked inode on Unix and are silently lost.
+//
+// RecreateIfMissing handles both cases; InodeCheckInterval handles only a
+// replaced file, which it reports as "inode_changed" rather than
+// "file_vanished".
+//
+// Called from both write paths; the CAS on lastFileCheck elects a single
+// caller per interval, and the rotation flag keeps it exclusive with rotation.
+func (l *Logger) ensureFilePresent() {
+	interval := l.fileCheckInterval()
+	if interval <= 0 {
+		return
+	}
+
+	last := l.lastFileCheck.Load()
+	now := time.Now().UnixNano()
+	if now-last < int64(interval) {
+		return
+	}
+	if !l.lastFileCheck.CompareAndSwap(last, now) {
+		return // Another goroutine is checking this interval
+	}
+
+	file := l.currentFile.Load()
+	if file == nil {
+		return
+	}
+	state := l.pathState(file)
+	if state == pathLinked || (state == pathMissing && !l.RecreateIfMissing) {
+		return
+	}
+
+	// Claim the rotation flag so a concurrent rotation cannot swap the file
+	// underneath us; if rotation is running it recreates the file anyway.
+	if !l.rotationFlag.CompareAndSwap(false, true) {
+		return
+	}
+	defer l.rotationFlag.Store(false)
+
+	if l.currentFile.Load() != file {
+		return // Rotated while we were checking
+	}
+
+	if state == pathReplaced && l.InodeCheckInterval > 0 {
+		l.reportError("inode_changed", fmt.Errorf("log file %q now names a different file; reopening", l.Filename))
+	} else {
+		l.reportError("file_vanished", fmt.Errorf("log file %q was removed or replaced externally; recreating", l.Filename))
+	}
+
+	_, _, fileMode := l.getRetryConfig()
+	newFile, err := openAppend(l.Filename, fileMode)
+	if err != nil {
+		l.reportError("file_open", &FileOpenError{Op: "open", Path: l.Filename, Err: err})
+		return
+	}
+	l.preallocate(newFile) // The old reservation went with the unlinked inode
+
+	release := l.holdSyncBuffer()
+	l.currentFile.Store(newFile)
+	_ = file.Close() // Ignore close error: the old inode is already unlinked
+	release()
+
+	// A replacement may already hold data; size limits count it
+	var size int64
+	if info, err := newFile.Stat(); err == nil {
+		size = info.Size()
+	}
+	l.bytesWritten.Store(uint64(size)) // #nosec G115 -- file sizes are non-negative
+	l.lineCount.Store(0)
+	l.fileCreated.Store(l.now().Unix())
+	l.updateSymlink()
+}
+
+// fileCheckInterval returns how often ensureFilePresent stats the path,
+// or 0 when neither RecreateIfMissing nor InodeCheckInterval is set.
+func (l *Logger) fileCheckInterval() time.Duration {
+	if l.InodeCheckInterval > 0 {
+		return l.InodeCheckInterval
+	}
+	if l.RecreateIfMissing {
+		return fileCheckInterval
+	}
+	return 0
+}
+
+// Results of pathState.
+const (
+	pathLinked   = iota // Filename names the open file
+	pathMissing         // Filename does not exist
+	pathReplaced        // Filename names a different file (inode or device changed)
+)
+
+// fileStillLinked reports whether Filename still names the open file.
+func (l *Logger) fileStillLinked(file *os.File) bool {
+	return l.pathState(file) == pathLinked
+}
+
+// pathState compares Filename with the open file by device and inode.
+// Stat errors other than not-exist are treated as linked to avoid
+// reopening on transient failures.
+func (l *Logger) pathState(file *os.File) int {
+	pathInfo, err := os.Stat(l.Filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return pathMissing
+		}
+		return pathLinked
+	}
+	fdInfo, err := file.Stat()
+	if err != nil || os.SameFile(pathInfo, fdInfo) {
+		return pathLinked
+	}
+	return pathReplaced
+}
@@ -0,0 +1,95 @@
+// recreate_test.go: Tests for RecreateIfMissing
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRecreateIfMissing_DeletedFileRecreated verifies that writes after an
+// external rm land in a freshly created file and the event is reported.
+func TestRecreateIfMissing_DeletedFileRecreated(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be deleted on Windows")
+	}
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "vanish.log")
+
+	var mu sync.Mutex
+	var ops []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:          logFile,
+		RecreateIfMissing: true,
+		ErrorCallback: func(op string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, op)
+		},
+	})
+
+	if _, err := logger.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Remove(logFile); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(fileCheckInterval + 100*time.Millisecond)
+	if _, err := logger.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("log file was not recreated: %v", err)
+	}
+	if string(content) != "after\n" {
+		t.Errorf("recreated file content = %q, want %q", content, "after\n")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	found := false
+	for _, op := range ops {
+		if op == "file_vanished" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("file_vanished not reported, got %v", ops)
+	}
+}
+
+// TestRecreateIfMissing_DisabledKeepsWritingToUnlinkedFile documents the
+// default: without the option the path is not recreated.
+func TestRecreateIfMissing_DisabledKeepsWritingToUnlinkedFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be deleted on Windows")
+	}
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "vanish.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	if _, err := logger.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Remove(logFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := logger.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := os.Stat(logFile); !os.IsNotExist(err) {
+		t.Errorf("log file recreated without RecreateIfMissing (err=%v)", err)
+	}
+}
@@ -0,0 +1,82 @@
+// rename.go: Rotation renames with a cross-device copy fallback
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// renameFile moves oldname to newname. When the two paths live on different
+// mounts (EXDEV), rename(2) cannot work, so the file is copied and the source
+// removed instead, preserving permission bits and modification time.
+func (l *Logger) renameFile(oldname, newname string) error {
+	fs := l.fs
+	if fs == nil {
+		fs = DefaultFileSystem{}
+	}
+	err := fs.Rename(oldname, newname)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	return copyAcrossDevices(oldname, newname)
+}
+
+// copyAcrossDevices copies oldname to newname and removes oldname.
+// The copy is written to a temp file in the destination directory and
+// renamed into place, so a crash never leaves a truncated backup under the
+// final name; the source is removed only after the copy is complete.
+func copyAcrossDevices(oldname, newname string) error {
+	info, err := os.Stat(oldname)
+	if err != nil {
+		return err
+	}
+
+	source, err := os.Open(oldname) // #nosec G304 -- oldname is the application's log file, not user input
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }() // Read-only; close error is not actionable
+
+	tempName := newname + ".tmp"
+	target, err := os.OpenFile(tempName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm()) // #nosec G304 -- tempName is internally generated, not user input
+	if err != nil {
+		return fmt.Errorf("cross-device copy: %w", err)
+	}
+
+	_, copyErr := io.Copy(target, source)
+	if copyErr == nil {
+		copyErr = target.Sync()
+	}
+	closeErr := target.Close()
+	if copyErr != nil || closeErr != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		if copyErr == nil {
+			copyErr = closeErr
+		}
+		return fmt.Errorf("cross-device copy: %w", copyErr)
+	}
+
+	// OpenFile's mode is filtered by the umask; restore the exact bits
+	if err := os.Chmod(tempName, info.Mode().Perm()); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return fmt.Errorf("cross-device copy: %w", err)
+	}
+	if err := os.Chtimes(tempName, info.ModTime(), info.ModTime()); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return fmt.Errorf("cross-device copy: %w", err)
+	}
+	if err := os.Rename(tempName, newname); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return fmt.Errorf("cross-device copy: %w", err)
+	}
+
+	return os.Remove(oldname)
+}
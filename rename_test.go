@@ -0,0 +1,91 @@
+// rename_test.go: Tests for the cross-device rename fallback
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// crossDeviceFS fails every Rename with EXDEV, like rename(2) across mounts.
+type crossDeviceFS struct {
+	DefaultFileSystem
+	renames int
+}
+
+func (fs *crossDeviceFS) Rename(oldname, newname string) error {
+	fs.renames++
+	return &os.LinkError{Op: "rename", Old: oldname, New: newname, Err: syscall.EXDEV}
+}
+
+func TestRotate_CrossDeviceFallback(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "xdev.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, FileMode: 0640})
+	fs := &crossDeviceFS{}
+	logger.fs = fs
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(logFile, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if fs.renames == 0 {
+		t.Fatal("rotation did not go through the injected FileSystem")
+	}
+
+	backups, _ := filepath.Glob(logFile + ".*")
+	if len(backups) != 1 {
+		t.Fatalf("backups = %v, want exactly one", backups)
+	}
+	content, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "before rotation\n" {
+		t.Errorf("backup content = %q", content)
+	}
+
+	info, err := os.Stat(backups[0])
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("backup mode = %v, want 0640", info.Mode().Perm())
+	}
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("backup mtime = %v, want %v", info.ModTime(), mtime)
+	}
+
+	// The active file is a fresh one, and writes keep working
+	if _, err := logger.Write([]byte("after rotation\n")); err != nil {
+		t.Fatalf("Write after rotation: %v", err)
+	}
+	content, _ = os.ReadFile(logFile)
+	if string(content) != "after rotation\n" {
+		t.Errorf("active file content = %q", content)
+	}
+}
+
+func TestRenameFile_OtherErrorsAreReturned(t *testing.T) {
+	logger := &Logger{}
+	err := logger.renameFile(filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "dst"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("renameFile error = %v, want not-exist", err)
+	}
+}
@@ -0,0 +1,292 @@
+// resize_test.go: Tests for the "adaptive" BackpressurePolicy ring resize
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// checkAllLines verifies that the log holds every line in want exactly once.
+func checkAllLines(t *testing.T, logFile string, want []string) {
+	t.Helper()
+	seen := make(map[string]int, len(want))
+	for _, line := range strings.Split(strings.TrimSuffix(readLog(t, logFile), "\n"), "\n") {
+		seen[line]++
+	}
+	for _, line := range want {
+		if seen[line] != 1 {
+			t.Fatalf("line %q written %d times, want once (%d distinct lines in log, %d written)",
+				line, seen[line], len(seen), len(want))
+		}
+	}
+}
+
+func TestAdaptiveResize_NoMessageLoss(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		arena int
+	}{
+		{"pointer", 0},
+		{"arena", 64},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+			logFile := filepath.Join(t.TempDir(), "app.log")
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:           logFile,
+				Async:              true,
+				BufferSize:         8,
+				MinBufferSize:      8,
+				ArenaSlotSize:      tc.arena,
+				BackpressurePolicy: "adaptive",
+				Tee:                tee,
+			})
+
+			want := []string{"first"}
+			if _, err := logger.Write([]byte("first\n")); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			<-tee.entered // The consumer is now stuck, so the ring fills up
+
+			// 8+16+32+64+128 slots hold all of them: no write falls back
+			// to sync, which would block on the tee as well
+			for i := 0; i < 200; i++ {
+				line := fmt.Sprintf("message %03d", i)
+				want = append(want, line)
+				if _, err := logger.Write([]byte(line + "\n")); err != nil {
+					t.Fatalf("Write: %v", err)
+				}
+			}
+			if got := logger.Stats().BufferSize; got != 128 {
+				t.Errorf("BufferSize after resizes = %d, want 128", got)
+			}
+
+			close(tee.release)
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+			checkAllLines(t, logFile, want)
+			if stats := logger.Stats(); stats.DroppedOnFull != 0 || stats.ShutdownLost != 0 {
+				t.Errorf("DroppedOnFull = %d, ShutdownLost = %d; want 0", stats.DroppedOnFull, stats.ShutdownLost)
+			}
+		})
+	}
+}
+
+// TestAdaptiveResize_ConcurrentWriters races resizes against each other,
+// against writers still pushing into the replaced ring, and the consumer.
+func TestAdaptiveResize_ConcurrentWriters(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           logFile,
+		Async:              true,
+		BufferSize:         8,
+		MinBufferSize:      8,
+		BackpressurePolicy: "adaptive",
+	})
+
+	const writers, perWriter = 8, 500
+	var wg sync.WaitGroup
+	var want []string
+	for w := 0; w < writers; w++ {
+		lines := make([]string, perWriter)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("writer %d message %03d", w, i)
+		}
+		want = append(want, lines...)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, line := range lines {
+				if _, err := logger.Write([]byte(line + "\n")); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	checkAllLines(t, logFile, want)
+	if got := logger.Stats().DroppedOnFull; got != 0 {
+		t.Errorf("DroppedOnFull = %d, want 0", got)
+	}
+}
+
+// checkWriterOrder verifies that each writer's lines, "writer W message N",
+// appear in the log with N strictly increasing.
+func checkWriterOrder(t *testing.T, logFile string, writers, perWriter int) {
+	t.Helper()
+	next := make([]int, writers)
+	for _, line := range strings.Split(strings.TrimSuffix(readLog(t, logFile), "\n"), "\n") {
+		var w, n int
+		if _, err := fmt.Sscanf(line, "writer %d message %d", &w, &n); err != nil {
+			continue // "first" and the like
+		}
+		if n != next[w] {
+			t.Fatalf("writer %d: got message %d, want %d", w, n, next[w])
+		}
+		next[w]++
+	}
+	for w, n := range next {
+		if n != perWriter {
+			t.Errorf("writer %d: %d messages in log, want %d", w, n, perWriter)
+		}
+	}
+}
+
+func TestAdaptiveResize_PreservesOrder(t *testing.T) {
+	t.Run("stuck_consumer", func(t *testing.T) {
+		tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:           logFile,
+			Async:              true,
+			BufferSize:         8,
+			MinBufferSize:      8,
+			BackpressurePolicy: "adaptive",
+			Tee:                tee,
+		})
+		if _, err := logger.Write([]byte("first\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		<-tee.entered
+
+		// Every resize happens with older messages queued in the old ring
+		const count = 200
+		for i := 0; i < count; i++ {
+			if _, err := fmt.Fprintf(logger, "writer 0 message %d\n", i); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+		}
+		close(tee.release)
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		checkWriterOrder(t, logFile, 1, count)
+	})
+
+	t.Run("concurrent_writers", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		logger := newTestLogger(t, &LoggerConfig{
+			Filename:           logFile,
+			Async:              true,
+			BufferSize:         8,
+			MinBufferSize:      8,
+			BackpressurePolicy: "adaptive",
+		})
+
+		// Writers losing a resize race, or finding the new ring already
+		// full, must keep queuing rather than write ahead of their own lines
+		const writers, perWriter = 8, 500
+		var wg sync.WaitGroup
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perWriter; i++ {
+					if _, err := fmt.Fprintf(logger, "writer %d message %d\n", w, i); err != nil {
+						t.Errorf("Write: %v", err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		if err := logger.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		checkWriterOrder(t, logFile, writers, perWriter)
+	})
+}
+
+// TestAdaptiveResize_LoserRetriesOnSuccessor covers a writer that lost the
+// resize race while the winner has linked its ring but not yet published
+// it in l.buffer: retrying on l.buffer would fail and fall back to sync.
+func TestAdaptiveResize_LoserRetriesOnSuccessor(t *testing.T) {
+	logger := &Logger{Filename: filepath.Join(t.TempDir(), "app.log")}
+	old := logger.newRing(8)
+	logger.buffer.Store(old)
+
+	winner := logger.newRing(16)
+	old.next.Store(winner) // The winner is between linking and the swap
+	old.retire()
+
+	if got := logger.tryAdaptiveResize(old); got != winner {
+		t.Fatalf("tryAdaptiveResize returned %p, want the winner's ring %p", got, winner)
+	}
+	if logger.buffer.Load() != winner {
+		t.Error("l.buffer left on the retired ring")
+	}
+}
+
+// TestAdaptiveResize_LostSwapKeepsOrder interleaves two resizes: the
+// creator of ring Y stalls before swapping it into l.buffer, and a writer
+// that fills Y resizes it to Z, so its own swap from Y fails. The writer
+// must keep queuing behind its earlier lines instead of writing directly.
+func TestAdaptiveResize_LostSwapKeepsOrder(t *testing.T) {
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           logFile,
+		Async:              true,
+		BufferSize:         8,
+		MinBufferSize:      8,
+		BackpressurePolicy: "adaptive",
+		Tee:                tee,
+	})
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-tee.entered // The consumer is now stuck
+
+	seq := 0
+	write := func(n int) {
+		t.Helper()
+		for end := seq + n; seq < end; seq++ {
+			if _, err := fmt.Fprintf(logger, "writer 0 message %d\n", seq); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+		}
+	}
+	write(8) // Fills X
+	x := logger.buffer.Load()
+
+	// The creator of Y links and retires X, then stalls before the swap
+	y := logger.newRing(16)
+	x.next.Store(y)
+	x.retire()
+	for ; seq < 24; seq++ { // Writers that found Y through the link
+		line := []byte(fmt.Sprintf("writer 0 message %d\n", seq))
+		if !logger.reserveBufferBytes(len(line)) || !y.push(line) {
+			t.Fatalf("push %d into Y failed", seq)
+		}
+	}
+
+	// Y is full: this resize to Z loses its swap, l.buffer still holds X
+	z := logger.tryAdaptiveResize(y)
+	if z == nil || z != y.next.Load() {
+		t.Fatalf("tryAdaptiveResize(Y) = %p, want Z %p", z, y.next.Load())
+	}
+	if logger.buffer.Load() != z {
+		t.Errorf("l.buffer not advanced to the newest ring")
+	}
+
+	write(100)
+	close(tee.release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	checkWriterOrder(t, logFile, 1, seq)
+}
@@ -0,0 +1,158 @@
+// retry_test.go: Tests for retry backoff and jitter
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// recordAttempts returns an operation that fails until attempt succeedAt
+// (never when 0) and records when each attempt ran.
+func recordAttempts(succeedAt int, times *[]time.Time) func() error {
+	return func() error {
+		*times = append(*times, time.Now())
+		if succeedAt > 0 && len(*times) >= succeedAt {
+			return nil
+		}
+		return os.ErrPermission
+	}
+}
+
+// gaps returns the delays between consecutive attempts.
+func gaps(times []time.Time) []time.Duration {
+	out := make([]time.Duration, 0, len(times))
+	for i := 1; i < len(times); i++ {
+		out = append(out, times[i].Sub(times[i-1]))
+	}
+	return out
+}
+
+func TestRetryWithPolicy_ExponentialBackoff(t *testing.T) {
+	var times []time.Time
+	err := RetryWithPolicy(recordAttempts(0, &times), RetryPolicy{
+		Count:    5,
+		Delay:    5 * time.Millisecond,
+		MaxDelay: 20 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("expected error after exhausting attempts")
+	}
+	if len(times) != 5 {
+		t.Fatalf("attempts = %d, want 5", len(times))
+	}
+
+	// 5, 10, 20, then capped at 20
+	want := []time.Duration{5, 10, 20, 20}
+	for i, gap := range gaps(times) {
+		if floor := want[i] * time.Millisecond; gap < floor {
+			t.Errorf("delay %d = %v, want at least %v", i, gap, floor)
+		}
+	}
+}
+
+func TestRetryWithPolicy_FixedDelayByDefault(t *testing.T) {
+	var times []time.Time
+	start := time.Now()
+	_ = RetryWithPolicy(recordAttempts(0, &times), RetryPolicy{Count: 4, Delay: 5 * time.Millisecond})
+
+	// Doubling would take at least 5+10+20 = 35ms
+	if elapsed := time.Since(start); elapsed >= 35*time.Millisecond {
+		t.Errorf("elapsed %v suggests backoff without MaxDelay", elapsed)
+	}
+	if len(times) != 4 {
+		t.Errorf("attempts = %d, want 4", len(times))
+	}
+}
+
+func TestRetryWithPolicy_StopsOnSuccess(t *testing.T) {
+	var times []time.Time
+	err := RetryWithPolicy(recordAttempts(3, &times), RetryPolicy{
+		Count:    10,
+		Delay:    time.Millisecond,
+		MaxDelay: time.Second,
+		Jitter:   true,
+	})
+	if err != nil || len(times) != 3 {
+		t.Errorf("err = %v, attempts = %d; want nil, 3", err, len(times))
+	}
+}
+
+func TestRetryBackoff_JitterRange(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	if got := retryBackoff(delay, false); got != delay {
+		t.Errorf("without jitter: got %v, want %v", got, delay)
+	}
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 200; i++ {
+		got := retryBackoff(delay, true)
+		if got < delay/2 || got > delay {
+			t.Fatalf("jittered delay %v outside [%v, %v]", got, delay/2, delay)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jitter produced a constant delay")
+	}
+	if got := retryBackoff(1, true); got != 1 {
+		t.Errorf("1ns delay: got %v", got)
+	}
+}
+
+func TestLogger_RetryBackoffConfig(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:      logFile,
+		RetryCount:    4,
+		RetryDelay:    2 * time.Millisecond,
+		RetryMaxDelay: 8 * time.Millisecond,
+		RetryJitter:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+
+	var attempts int
+	start := time.Now()
+	err = l.retryFileOperation(func() error {
+		attempts++
+		return errors.New("mount unavailable")
+	}, l.RetryCount, l.RetryDelay)
+	if err == nil || attempts != 4 {
+		t.Fatalf("err = %v, attempts = %d; want an error after 4 attempts", err, attempts)
+	}
+	// Jittered 2+4+8 ms is at least 7ms
+	if elapsed := time.Since(start); elapsed < 7*time.Millisecond {
+		t.Errorf("elapsed %v, want backoff of at least 7ms", elapsed)
+	}
+
+	if err := ValidateConfig(&LoggerConfig{Filename: logFile, RetryMaxDelay: -time.Second}); err == nil {
+		t.Error("negative RetryMaxDelay accepted")
+	}
+}
+
+func TestLoadFromEnv_RetryBackoff(t *testing.T) {
+	t.Setenv("LETHE_RETRY_MAX_DELAY", "2s")
+	t.Setenv("LETHE_RETRY_JITTER", "true")
+	config, err := LoadFromEnv("LETHE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.RetryMaxDelay != 2*time.Second || !config.RetryJitter {
+		t.Errorf("RetryMaxDelay = %v, RetryJitter = %v", config.RetryMaxDelay, config.RetryJitter)
+	}
+
+	t.Setenv("LETHE_RETRY_JITTER", "sometimes")
+	if _, err := LoadFromEnv("LETHE"); err == nil {
+		t.Error("invalid LETHE_RETRY_JITTER accepted")
+	}
+}
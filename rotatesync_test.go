@@ -0,0 +1,124 @@
+// rotatesync_test.go: Tests for RotateSync
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotateSync_WaitsForBackgroundTasks(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "sync-rotate.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		MaxBackups:      5,
+		Compress:        true,
+		CompressMinSize: -1,
+		Checksum:        true,
+	})
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+
+	// No WaitForBackgroundTasks: the compressed backup and its checksum
+	// must already be in place when RotateSync returns
+	gz, _ := filepath.Glob(logFile + ".*.gz")
+	if len(gz) != 1 {
+		t.Fatalf("compressed backups = %v, want 1", gz)
+	}
+	// Checksum and compression run concurrently, so the sidecar may cover
+	// either form of the backup; it just has to exist
+	if sums, _ := filepath.Glob(logFile + ".*.sha256"); len(sums) != 1 {
+		t.Errorf("checksum sidecars = %v, want 1", sums)
+	}
+	if plain := gz[0][:len(gz[0])-len(".gz")]; fileExists(plain) {
+		t.Errorf("uncompressed backup %s still present", plain)
+	}
+}
+
+func TestRotateSync_ReturnsTaskErrors(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "sync-rotate-fail.log")
+	var reported []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		ErrorCallback: func(operation string, err error) {
+			reported = append(reported, operation)
+		},
+	})
+	logger.Encryptor = failingEncryptor{}
+
+	if _, err := logger.Write([]byte("secret\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	err := logger.RotateSync()
+	if err == nil || !strings.Contains(err.Error(), errEncryptFailed.Error()) {
+		t.Fatalf("RotateSync error = %v, want %q", err, errEncryptFailed)
+	}
+	if len(reported) == 0 || reported[0] != "encrypt" {
+		t.Errorf("reported operations = %v, want encrypt", reported)
+	}
+}
+
+func TestRotateSync_NoFile(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "never.log")})
+
+	if err := logger.RotateSync(); err == nil {
+		t.Fatal("RotateSync before any write returned nil, want error")
+	}
+}
+
+var errEncryptFailed = errors.New("encrypt failed")
+
+// failingEncryptor is an Encryptor whose Encrypt always fails.
+type failingEncryptor struct{}
+
+func (failingEncryptor) Encrypt(dst io.Writer, src io.Reader) error { return errEncryptFailed }
+
+func (failingEncryptor) Decrypt(src io.Reader) (io.Reader, error) { return nil, errEncryptFailed }
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestRotateNamed_ReturnsBackupPath(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "named.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+
+	// Nothing opened yet
+	if backup, err := logger.RotateNamed(); backup != "" || err != nil {
+		t.Errorf("RotateNamed before any write = %q, %v; want \"\", nil", backup, err)
+	}
+
+	if _, err := logger.Write([]byte("archived\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	backup, err := logger.RotateNamed()
+	if err != nil {
+		t.Fatalf("RotateNamed: %v", err)
+	}
+	if !strings.HasPrefix(backup, logFile+".") {
+		t.Fatalf("RotateNamed = %q, want a backup of %s", backup, logFile)
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil || string(data) != "archived\n" {
+		t.Errorf("backup content = %q, %v; want the rotated data", data, err)
+	}
+
+	// The fresh active file is empty: nothing to rotate
+	if backup, err := logger.RotateNamed(); backup != "" || err != nil {
+		t.Errorf("RotateNamed on empty file = %q, %v; want \"\", nil", backup, err)
+	}
+}
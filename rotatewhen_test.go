@@ -0,0 +1,90 @@
+// rotatewhen_test.go: Tests for the RotateWhen custom rotation predicate
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRotateWhen_ForcesRotation verifies a true predicate rotates the file
+// and that it sees the active file's size.
+func TestRotateWhen_ForcesRotation(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "custom.log")
+
+	var deployed atomic.Bool
+	var lastSize atomic.Uint64
+	var rotations atomic.Int32
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		RotateWhen: func(currentSize uint64, fileAge time.Duration) bool {
+			lastSize.Store(currentSize)
+			return deployed.CompareAndSwap(true, false) // Rotate once per marker
+		},
+		OnRotate: func(RotationEvent) { rotations.Add(1) },
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if rotations.Load() != 0 {
+		t.Fatalf("rotated with a false predicate")
+	}
+	if got := lastSize.Load(); got != 15 {
+		t.Errorf("predicate saw size %d, want 15", got)
+	}
+
+	deployed.Store(true)
+	if _, err := logger.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rotations.Load() != 1 {
+		t.Fatalf("rotations = %d after predicate returned true, want 1", rotations.Load())
+	}
+
+	// The predicate is false again: no further rotations
+	if _, err := logger.Write([]byte("line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if rotations.Load() != 1 {
+		t.Errorf("rotations = %d, want 1", rotations.Load())
+	}
+	if stats := logger.Stats(); stats.SizeRotations != 0 || stats.TimeRotations != 0 {
+		t.Errorf("custom rotation counted as size/time: %+v", stats)
+	}
+}
+
+// TestRotateWhen_PanicIsRecovered verifies a panicking predicate is
+// reported and does not break writes.
+func TestRotateWhen_PanicIsRecovered(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "panic.log")
+
+	var reported atomic.Value
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:   logFile,
+		RotateWhen: func(uint64, time.Duration) bool { panic("boom") },
+		ErrorCallback: func(op string, err error) {
+			reported.Store(op + ": " + err.Error())
+		},
+	})
+
+	if _, err := logger.Write([]byte("survives\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	msg, _ := reported.Load().(string)
+	if !strings.HasPrefix(msg, "rotate_when_panic") {
+		t.Errorf("reported %q, want rotate_when_panic", msg)
+	}
+	if logger.Stats().RotationCount != 0 {
+		t.Error("panicking predicate must not rotate")
+	}
+}
@@ -7,11 +7,12 @@
 package lethe
 
 import (
-	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"sort"
@@ -39,14 +40,33 @@ func (l *Logger) initFile() error {
 	}
 
 	// Cleanup orphan .tmp files from interrupted rotations (crash recovery)
-	l.cleanupOrphanTmpFiles(filepath.Dir(sanitizedPath))
+	l.cleanupOrphanTmpFiles(sanitizedPath)
+
+	// Enforce retention on backups left by earlier runs now: the next
+	// rotation may be days away. Failures are reported via taskFailed.
+	if ret := l.effectiveRetention(); ret.MaxBackups > 0 || ret.MaxFileAge > 0 {
+		_ = l.cleanupOldFiles()
+	}
 
 	file, err := l.openLogFile(sanitizedPath, fileMode, retryCount, retryDelay)
 	if err != nil {
 		return err
 	}
 
-	return l.initFileState(file, sanitizedPath)
+	if err := l.initFileState(file, sanitizedPath); err != nil {
+		return err
+	}
+	l.preallocate(file)
+
+	l.loadState()
+	l.updateSymlink()
+	l.startRotateScheduler()
+	l.startSyncLoop()
+	l.startBufferedSync()
+	l.startCompressActive()
+	l.startActiveChecksum()
+	l.startDedupLoop()
+	return nil
 }
 
 // initSizeConfig initializes the size configuration with backward compatibility.
@@ -60,13 +80,13 @@ func (l *Logger) initSizeConfig() {
 	if l.MaxSizeStr != "" {
 		// Use new string-based configuration
 		if size, err := ParseSize(l.MaxSizeStr); err == nil {
-			l.maxSizeBytes.Store(size)
+			l.maxSizeBytes.CompareAndSwap(0, size) // SetMaxSize may have won the race
 		} else {
 			l.reportError("size_parse", fmt.Errorf("invalid MaxSizeStr %q: %v", l.MaxSizeStr, err))
 		}
 	} else if l.MaxSize > 0 {
 		// Fallback to legacy MB-based configuration
-		l.maxSizeBytes.Store(l.MaxSize * 1024 * 1024) // MB to bytes
+		l.maxSizeBytes.CompareAndSwap(0, l.MaxSize*1024*1024) // MB to bytes
 	}
 }
 
@@ -90,21 +110,38 @@ func (l *Logger) createLogDirectory(sanitizedPath string, retryCount int, retryD
 		return nil
 	}
 
-	err := RetryFileOperation(func() error {
-		return os.MkdirAll(dir, 0750) // More secure permissions
+	dirMode := l.DirMode
+	if dirMode == 0 {
+		dirMode = defaultDirMode
+	}
+	err := l.retryFileOperation(func() error {
+		return os.MkdirAll(dir, dirMode)
 	}, retryCount, retryDelay)
 
 	if err != nil {
-		l.reportError("directory_creation", fmt.Errorf("failed to create log directory %q: %v (check permissions and disk space)", dir, err))
-		return fmt.Errorf("failed to create log directory: %v", err)
+		err = &FileOpenError{Op: "mkdir", Path: dir, Err: err}
+		l.reportError("directory_creation", err)
+		return err
 	}
 	return nil
 }
 
+// orphanTmpGracePeriod is how old a temp file must be before startup
+// cleanup removes it, so temp files of a concurrently running rotation
+// (e.g., another process sharing Filename) are left alone.
+const orphanTmpGracePeriod = time.Minute
+
 // cleanupOrphanTmpFiles removes orphan .tmp files left from interrupted rotations.
-// This provides crash recovery - if the process died mid-rotation, .tmp files
-// may be left behind. We clean them up on startup to prevent disk space leaks.
-func (l *Logger) cleanupOrphanTmpFiles(dir string) {
+// This provides crash recovery - if the process died mid-compression,
+// encryption, cross-device copy or state save, a .tmp file is left behind.
+// We clean them up on startup to prevent disk space leaks.
+//
+// Only Lethe's own temp names for logPath are touched (a backup name with
+// optional compressor extension/".enc", or the state sidecar, plus ".tmp"); unrelated
+// .tmp files in a shared directory are never removed.
+func (l *Logger) cleanupOrphanTmpFiles(logPath string) {
+	dir := filepath.Dir(logPath)
+	prefix := filepath.Base(logPath) + "."
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		// Not critical - just log and continue
@@ -116,19 +153,23 @@ func (l *Logger) cleanupOrphanTmpFiles(dir string) {
 			continue
 		}
 		name := entry.Name()
-		if strings.HasSuffix(name, ".tmp") {
-			tmpPath := filepath.Join(dir, name)
-			// Only remove if file is old enough (at least 1 minute)
-			// to avoid removing files from concurrent rotations
-			info, err := entry.Info()
-			if err != nil {
-				continue
-			}
-			if time.Since(info.ModTime()) > time.Minute {
-				// WHY: Best-effort cleanup of orphan temp file; error is
-				// intentionally not acted upon to avoid masking the original error.
-				_ = os.Remove(tmpPath)
-			}
+		suffix, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		suffix, ok = strings.CutSuffix(suffix, ".tmp")
+		if !ok || !(isBackupSuffix(suffix) || "."+suffix == stateSuffix || "."+suffix == manifestSuffix || "."+suffix == activeChecksumSuffix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > orphanTmpGracePeriod {
+			// WHY: Best-effort cleanup of orphan temp file; error is
+			// intentionally not acted upon to avoid masking the original error.
+			_ = os.Remove(filepath.Join(dir, name))
 		}
 	}
 }
@@ -136,15 +177,16 @@ func (l *Logger) cleanupOrphanTmpFiles(dir string) {
 // openLogFile opens or creates the log file with retry
 func (l *Logger) openLogFile(sanitizedPath string, fileMode os.FileMode, retryCount int, retryDelay time.Duration) (*os.File, error) {
 	var file *os.File
-	err := RetryFileOperation(func() error {
+	err := l.retryFileOperation(func() error {
 		var err error
-		file, err = os.OpenFile(sanitizedPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileMode) // #nosec G304 -- sanitizedPath validated by SanitizeFilename above
+		file, err = openAppend(sanitizedPath, fileMode)
 		return err
 	}, retryCount, retryDelay)
 
 	if err != nil {
-		l.reportError("file_open", fmt.Errorf("failed to open log file %q: %v (check permissions and disk space)", sanitizedPath, err))
-		return nil, fmt.Errorf("failed to open log file: %v", err)
+		err = &FileOpenError{Op: "open", Path: sanitizedPath, Err: err}
+		l.reportError("file_open", err)
+		return nil, err
 	}
 	return file, nil
 }
@@ -155,12 +197,14 @@ func (l *Logger) initFileState(file *os.File, sanitizedPath string) error {
 	info, err := file.Stat()
 	if err != nil {
 		_ = file.Close() // Ignore close error during cleanup
-		l.reportError("file_stat", fmt.Errorf("failed to stat log file %q: %v", sanitizedPath, err))
-		return fmt.Errorf("failed to stat log file: %v", err)
+		err = &FileOpenError{Op: "stat", Path: sanitizedPath, Err: err}
+		l.reportError("file_stat", err)
+		return err
 	}
 
 	// Update the filename to the sanitized version
 	l.Filename = sanitizedPath
+	l.currentPath.Store(&sanitizedPath)
 
 	// Store file, size and creation time atomically
 	l.currentFile.Store(file)
@@ -172,24 +216,54 @@ func (l *Logger) initFileState(file *os.File, sanitizedPath string) error {
 	}
 	l.bytesWritten.Store(uint64(size)) // #nosec G115 -- size checked for negative values above
 
-	// Use cached time for better performance
-	if l.timeCache != nil {
-		l.fileCreated.Store(l.timeCache.CachedTime().Unix())
-	} else {
-		l.fileCreated.Store(time.Now().Unix())
-	}
+	l.fileCreated.Store(l.now().Unix())
 
 	return nil
 }
 
 // performRotation does the actual file rotation
-func (l *Logger) performRotation() error {
+func (l *Logger) performRotation(reason rotationReason) error {
+	_, err := l.performRotationWith(nil, reason)
+	return err
+}
+
+// performRotationWith rotates and attaches the scheduled background tasks
+// to tasks (nil for fire-and-forget rotations), see RotateSync.
+// Returns the backup name, or "" if a peer process already rotated.
+func (l *Logger) performRotationWith(tasks *rotationTasks, reason rotationReason) (string, error) {
+	return l.rotateActiveFile(tasks, true, reason)
+}
+
+// rotateActiveFile seals the active file as a backup and schedules its
+// background tasks. With reopen false no new active file is created,
+// because the Logger is shutting down (CompressOnClose). reason is
+// counted in Stats and passed to OnRotate.
+func (l *Logger) rotateActiveFile(tasks *rotationTasks, reopen bool, reason rotationReason) (string, error) {
 	currentFile := l.currentFile.Load()
 	if currentFile == nil {
-		return fmt.Errorf("no current file to rotate")
+		return "", fmt.Errorf("no current file to rotate")
+	}
+	if l.failedOver.Load() {
+		return "", errFailedOver
+	}
+
+	if l.MultiProcess {
+		unlock, err := l.lockRotation()
+		if err != nil {
+			return "", err
+		}
+		defer unlock()
+
+		// Another process rotated while we waited: follow it, don't rotate again
+		if !l.fileStillLinked(currentFile) {
+			return "", l.reopenAfterPeerRotation(currentFile)
+		}
 	}
 
 	backupName := l.generateBackupName()
+	if l.CompressActive {
+		backupName += gzipCompressor{}.Extension() // Already a gzip stream
+	}
 	retryCount, retryDelay, fileMode := l.getRetryConfig()
 
 	// WHY capture before closeAndRotateFile: bytesWritten is reset in
@@ -198,11 +272,30 @@ func (l *Logger) performRotation() error {
 	// of the sealed segment for anomaly detection (flood attacks).
 	sealedBytes := l.bytesWritten.Load()
 
-	if err := l.closeAndRotateFile(currentFile, backupName, retryCount, retryDelay, fileMode); err != nil {
-		return err
+	// BufferedSync data belongs to the file being sealed
+	release := l.holdSyncBuffer()
+	var err error
+	if reopen {
+		err = l.closeAndRotateFile(currentFile, backupName, retryCount, retryDelay, fileMode)
+	} else {
+		err = l.sealFile(currentFile, backupName, retryCount, retryDelay)
+	}
+	release()
+	if err != nil {
+		return "", err
+	}
+
+	newFile := ""
+	if reopen {
+		newFile = l.Filename
 	}
 
 	l.updateRotationState()
+	l.countRotation(reason)
+	l.saveState()
+	if reopen {
+		l.updateSymlink()
+	}
 
 	// Invoke OnRotate callback before scheduling background tasks.
 	// WHY before: the callback must fire while the rotation is still
@@ -212,15 +305,16 @@ func (l *Logger) performRotation() error {
 		l.safeInvokeOnRotate(RotationEvent{
 			Timestamp:    timecache.CachedTime(),
 			PreviousFile: backupName,
-			NewFile:      l.Filename,
+			NewFile:      newFile,
 			Sequence:     l.rotationSeq.Load(),
 			BytesWritten: sealedBytes,
+			Reason:       reason.String(),
 		})
 	}
 
-	l.scheduleBackgroundTasks(backupName)
+	l.scheduleBackgroundTasks(backupName, tasks)
 
-	return nil
+	return backupName, nil
 }
 
 // safeInvokeOnRotate calls the OnRotate callback with panic recovery.
@@ -236,6 +330,31 @@ func (l *Logger) safeInvokeOnRotate(event RotationEvent) {
 	l.OnRotate(event)
 }
 
+// safeInvokeOnCompress calls the OnCompress callback with panic recovery.
+// WHY: runs on a shared background worker; a panic would kill the worker
+// and stall compression for every later rotation.
+func (l *Logger) safeInvokeOnCompress(srcPath, gzPath string, ratio float64) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("on_compress_panic", fmt.Errorf("OnCompress callback panicked: %v", r))
+		}
+	}()
+	l.OnCompress(srcPath, gzPath, ratio)
+}
+
+// safeInvokeOnCleanup calls the OnCleanup callback with panic recovery.
+func (l *Logger) safeInvokeOnCleanup(removed []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("on_cleanup_panic", fmt.Errorf("OnCleanup callback panicked: %v", r))
+		}
+	}()
+	l.OnCleanup(removed)
+}
+
+// backupTimeFormat is the timestamp layout embedded in backup filenames.
+const backupTimeFormat = "2006-01-02-15-04-05"
+
 // generateBackupName creates a timestamped backup filename
 func (l *Logger) generateBackupName() string {
 	// WHY: Both writeSync and generateBackupName go through timeCacheOnce.Do
@@ -245,11 +364,80 @@ func (l *Logger) generateBackupName() string {
 	l.timeCacheOnce.Do(func() {
 		l.timeCache = timecache.NewWithResolution(time.Millisecond)
 	})
-	now := l.timeCache.CachedTime()
-	if !l.LocalTime {
-		now = now.UTC()
+	now := l.now().In(l.location())
+	base := fmt.Sprintf("%s.%s", l.Filename, now.Format(backupTimeFormat))
+
+	// Two rotations within one second format to the same name; renaming onto
+	// it would clobber the first backup, so append .1, .2, ... instead.
+	// Only called under rotationFlag, so no other rotation races the check.
+	name := base
+	for n := 1; backupNameTaken(name); n++ {
+		name = fmt.Sprintf("%s.%d", base, n)
+	}
+	return name
+}
+
+// backupNameTaken reports whether name, or any compressed or encrypted form
+// background workers may have produced from it, already exists.
+func backupNameTaken(name string) bool {
+	for _, candidate := range backupForms(name) {
+		if _, err := os.Lstat(candidate); err == nil {
+			return true
+		}
 	}
-	return fmt.Sprintf("%s.%s", l.Filename, now.Format("2006-01-02-15-04-05"))
+	return false
+}
+
+// isBackupSuffix reports whether suffix (the part after "Filename.") names a
+// rotated backup: a timestamp with an optional collision counter, optionally
+// followed by a compressor extension (e.g., ".gz") and/or ".enc".
+func isBackupSuffix(suffix string) bool {
+	suffix = strings.TrimSuffix(suffix, encryptedSuffix)
+	suffix = trimCompressedSuffix(suffix)
+	if _, err := time.Parse(backupTimeFormat, suffix); err == nil {
+		return true
+	}
+	stamp, counter, ok := strings.Cut(suffix, ".")
+	if !ok || counter == "" || strings.Trim(counter, "0123456789") != "" {
+		return false
+	}
+	_, err := time.Parse(backupTimeFormat, stamp)
+	return err == nil
+}
+
+// ageJitter returns this Logger's RotationJitter offset, drawn uniformly
+// from [0, RotationJitter] on first use and fixed for its lifetime so the
+// rotation period stays regular, just shifted per instance.
+func (l *Logger) ageJitter() time.Duration {
+	l.ageJitterOnce.Do(func() {
+		if l.RotationJitter > 0 {
+			l.ageJitterValue = rand.N(l.RotationJitter + 1) // #nosec G404 -- spreading load needs no cryptographic randomness
+		}
+	})
+	return l.ageJitterValue
+}
+
+// location returns the zone used for backup names and RotateAt boundary math.
+// TimeZone takes precedence over LocalTime; an unloadable TimeZone (only
+// possible with struct-literal construction) is reported once and ignored.
+func (l *Logger) location() *time.Location {
+	if l.TimeZone != "" {
+		l.timeZoneOnce.Do(func() {
+			loc, err := time.LoadLocation(l.TimeZone)
+			if err != nil {
+				l.reportError("timezone", fmt.Errorf("invalid TimeZone %q: %v", l.TimeZone, err))
+				return
+			}
+			l.timeZone = loc
+		})
+		if l.timeZone != nil {
+			return l.timeZone
+		}
+	}
+	if l.LocalTime {
+		return time.Local
+	}
+	return time.UTC
 }
 
 // getRetryConfig returns retry configuration with defaults
@@ -272,59 +460,114 @@ func (l *Logger) getRetryConfig() (int, time.Duration, os.FileMode) {
 	return retryCount, retryDelay, fileMode
 }
 
+// retryFileOperation is RetryFileOperation with the Logger's backoff
+// settings (RetryMaxDelay, RetryJitter) applied.
+func (l *Logger) retryFileOperation(operation func() error, retryCount int, retryDelay time.Duration) error {
+	return RetryWithPolicy(operation, RetryPolicy{
+		Count:    retryCount,
+		Delay:    retryDelay,
+		MaxDelay: l.RetryMaxDelay,
+		Jitter:   l.RetryJitter,
+	})
+}
+
+// backupFileMode returns the permissions for backup artifacts:
+// BackupFileMode if set, otherwise the active file's mode.
+func (l *Logger) backupFileMode() os.FileMode {
+	if l.BackupFileMode != 0 {
+		return l.BackupFileMode
+	}
+	_, _, fileMode := l.getRetryConfig()
+	return fileMode
+}
+
 // closeAndRotateFile handles the file rotation operation
 func (l *Logger) closeAndRotateFile(currentFile *os.File, backupName string, retryCount int, retryDelay time.Duration, fileMode os.FileMode) error {
+	if err := l.sealFile(currentFile, backupName, retryCount, retryDelay); err != nil {
+		return err
+	}
+
+	// Create new file with retry
+	var newFile *os.File
+	err := l.retryFileOperation(func() error {
+		var err error
+		newFile, err = openAppend(l.Filename, fileMode)
+		return err
+	}, retryCount, retryDelay)
+	if err != nil {
+		return &RotationError{Op: "reopen", Path: l.Filename, Backup: backupName, Err: err}
+	}
+	l.preallocate(newFile)
+
+	// Update atomic pointer to new file
+	l.currentFile.Store(newFile)
+	return nil
+}
+
+// sealFile closes the active file and renames it to backupName.
+func (l *Logger) sealFile(currentFile *os.File, backupName string, retryCount int, retryDelay time.Duration) error {
 	// Close current file with retry
-	err := RetryFileOperation(func() error {
+	err := l.retryFileOperation(func() error {
 		return currentFile.Close()
 	}, retryCount, retryDelay)
 	if err != nil {
-		return fmt.Errorf("failed to close current file: %v", err)
+		return &RotationError{Op: "close", Path: l.Filename, Backup: backupName, Err: err}
 	}
 
 	// Rename current file to backup with retry
-	err = RetryFileOperation(func() error {
-		return os.Rename(l.Filename, backupName)
+	err = l.retryFileOperation(func() error {
+		return l.renameFile(l.Filename, backupName)
 	}, retryCount, retryDelay)
 	if err != nil {
-		return fmt.Errorf("failed to rename log file: %v", err)
+		return &RotationError{Op: "rename", Path: l.Filename, Backup: backupName, Err: err}
 	}
+	l.releasePreallocation(backupName)
 
-	// Small delay to ensure file handles are released (Windows)
-	time.Sleep(retryDelay)
+	// The backup inherits the active file's mode; tighten it if configured
+	if l.BackupFileMode != 0 {
+		if err := os.Chmod(backupName, l.BackupFileMode); err != nil {
+			l.reportError("backup_chmod", fmt.Errorf("failed to set mode of %s: %v", backupName, err))
+		}
+	}
+	return nil
+}
 
-	// Create new file with retry
-	var newFile *os.File
-	err = RetryFileOperation(func() error {
-		var err error
-		newFile, err = os.OpenFile(l.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileMode) // #nosec G304 -- l.Filename is controlled by application, not user input
-		return err
-	}, retryCount, retryDelay)
-	if err != nil {
-		return fmt.Errorf("failed to create new log file: %v", err)
+// archiveOnClose seals the final active file for CompressOnClose and waits
+// for its post-rotation tasks (compression, checksum, cleanup). Called from
+// stopBackground after the consumer has flushed and before the worker pool
+// stops, so the tasks still have workers to run on.
+func (l *Logger) archiveOnClose() {
+	l.claimRotation()
+	if l.currentFile.Load() == nil || l.bytesWritten.Load() == 0 {
+		l.rotationFlag.Store(false)
+		return // Nothing written: leave no empty backup behind
 	}
 
-	// Update atomic pointer to new file
-	l.currentFile.Store(newFile)
-	return nil
+	tasks := &rotationTasks{}
+	_, err := l.rotateActiveFile(tasks, false, rotateClose)
+	l.rotationFlag.Store(false)
+	if err != nil {
+		l.reportError("compress_on_close", err)
+		return
+	}
+	_ = tasks.wait() // Task failures were already reported individually
 }
 
 // updateRotationState updates internal rotation state
 func (l *Logger) updateRotationState() {
 	l.bytesWritten.Store(0)
-	if l.timeCache != nil {
-		l.fileCreated.Store(l.timeCache.CachedTime().Unix())
-	} else {
-		l.fileCreated.Store(time.Now().Unix())
-	}
+	l.lineCount.Store(0)
+	l.fileCreated.Store(l.now().Unix())
+	l.deferredRotation.Store(uint32(rotateNone))
 	l.rotationSeq.Add(1)
 }
 
 // scheduleBackgroundTasks submits background tasks for cleanup, compression, etc.
-func (l *Logger) scheduleBackgroundTasks(backupName string) {
+// When tasks is non-nil each submitted task is tracked in it.
+func (l *Logger) scheduleBackgroundTasks(backupName string, tasks *rotationTasks) {
 	// Initialize background workers if needed
 	if l.bgWorkers.Load() == nil {
-		workers := newBackgroundWorkers(2)
+		workers := newBackgroundWorkers(l.backgroundWorkerCount())
 		l.bgWorkers.Store(workers)
 	}
 
@@ -340,6 +583,16 @@ func (l *Logger) scheduleBackgroundTasks(backupName string) {
 		l.safeSubmitTask(BackgroundTask{
 			TaskType: "cleanup",
 			Logger:   l,
+			tasks:    tasks,
+		})
+	}
+
+	// Re-compress aged backups; also catches up after tier changes
+	if len(l.CompressionTiers) > 0 {
+		l.safeSubmitTask(BackgroundTask{
+			TaskType: "tier",
+			Logger:   l,
+			tasks:    tasks,
 		})
 	}
 
@@ -349,15 +602,33 @@ func (l *Logger) scheduleBackgroundTasks(backupName string) {
 			TaskType: "checksum",
 			FilePath: backupName,
 			Logger:   l,
+			tasks:    tasks,
 		})
 	}
 
-	// Submit compression task if enabled
-	if ret.Compress {
+	// Submit compression task if enabled (also encrypts the compressed file when an
+	// Encryptor is set, so the two never race on the same file)
+	if ret.Compress && !l.CompressActive {
 		l.safeSubmitTask(BackgroundTask{
 			TaskType: "compress",
 			FilePath: backupName,
 			Logger:   l,
+			tasks:    tasks,
+		})
+	} else if l.Encryptor != nil {
+		l.safeSubmitTask(BackgroundTask{
+			TaskType: "encrypt",
+			FilePath: backupName,
+			Logger:   l,
+			tasks:    tasks,
+		})
+	} else if l.Manifest {
+		// Nothing transforms the backup: record it as rotated
+		l.safeSubmitTask(BackgroundTask{
+			TaskType: "manifest",
+			FilePath: backupName,
+			Logger:   l,
+			tasks:    tasks,
 		})
 	}
 }
@@ -366,53 +637,127 @@ func (l *Logger) scheduleBackgroundTasks(backupName string) {
 func (l *Logger) safeSubmitTask(task BackgroundTask) {
 	workers := l.bgWorkers.Load()
 	if workers == nil {
+		task.tasks.fail(errTaskNotRun(task, "background workers not running"))
 		return // Workers shut down
 	}
 
 	// Check if context is cancelled first
 	select {
 	case <-workers.ctx.Done():
+		task.tasks.fail(errTaskNotRun(task, "background workers stopped"))
 		return // Workers are shutting down
 	default:
 	}
 
+	// WHY count at submit time: counting only when a worker dequeues lets
+	// WaitForBackgroundTasks return while the task is still queued.
+	workers.activeTasks.Add(1)
+	task.tasks.add()
+
 	// Use non-blocking submit to avoid panics
 	select {
 	case workers.taskQueue <- task:
 		// Task submitted successfully
 	case <-workers.ctx.Done():
 		// Workers shut down while we were trying to submit
+		task.tasks.done(errTaskNotRun(task, "background workers stopped"))
+		workers.taskDone()
 		return
 	default:
-		// Queue is full, skip task
+		// Queue is full: the backup is left unprocessed, so say so
+		err := errTaskNotRun(task, "task queue full")
+		l.droppedTasks.Add(1)
+		l.reportError("task_dropped", err)
+		task.tasks.done(err)
+		workers.taskDone()
 	}
 }
 
+// rotationTasks tracks the background tasks scheduled by a single rotation,
+// so RotateSync can wait for exactly those tasks and collect their errors.
+// All methods are no-ops on a nil receiver (untracked rotations).
+type rotationTasks struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// add registers a task about to be queued.
+func (g *rotationTasks) add() {
+	if g != nil {
+		g.wg.Add(1)
+	}
+}
+
+// done marks a registered task finished, recording err if non-nil.
+func (g *rotationTasks) done(err error) {
+	if g == nil {
+		return
+	}
+	g.fail(err)
+	g.wg.Done()
+}
+
+// fail records err (if non-nil) without touching the task count.
+func (g *rotationTasks) fail(err error) {
+	if g == nil || err == nil {
+		return
+	}
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+}
+
+// wait blocks until every registered task is done and returns their errors joined.
+func (g *rotationTasks) wait() error {
+	g.wg.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// errTaskNotRun describes a background task that was never executed.
+func errTaskNotRun(task BackgroundTask, reason string) error {
+	return fmt.Errorf("%s task for %s not run: %s", task.TaskType, task.FilePath, reason)
+}
+
+// taskFailed reports err via ErrorCallback and returns it, so background
+// tasks feed both the callback and RotateSync's result.
+func (l *Logger) taskFailed(operation string, err error) error {
+	l.reportError(operation, err)
+	return err
+}
+
 // fileInfo holds file information for sorting
 type fileInfo struct {
 	name    string
 	modTime time.Time
 }
 
-// cleanupOldFiles removes old backup files based on MaxBackups and MaxFileAge settings
-func (l *Logger) cleanupOldFiles() {
+// cleanupOldFiles removes old backup files based on MaxBackups and MaxFileAge settings.
+// Every failure is reported; the first one is returned.
+func (l *Logger) cleanupOldFiles() error {
 	// Find all backup files using proper filepath operations
 	pattern := l.Filename + ".*"
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
-		return
+		return nil
 	}
 
 	// Get file info for all backup files
 	var files []fileInfo
-	var now time.Time
-	if l.timeCache != nil {
-		now = l.timeCache.CachedTime()
-	} else {
-		now = time.Now()
-	}
+	var removed []string
+	var firstErr error
+	now := l.now()
 
 	for _, match := range matches {
+		// Only Lethe's own backups: the glob also matches the state sidecar,
+		// the rotation lock, checksum sidecars (removed with their backup)
+		// and unrelated files that merely share the "Filename." prefix
+		if !isBackupSuffix(strings.TrimPrefix(match, l.Filename+".")) {
+			continue
+		}
+
 		info, err := os.Stat(match)
 		if err != nil {
 			continue // Skip files we can't stat
@@ -424,9 +769,14 @@ func (l *Logger) cleanupOldFiles() {
 			fileAge := now.Sub(info.ModTime())
 			if fileAge > ret.MaxFileAge {
 				// File is too old, remove it
-				err := os.Remove(match)
+				err := l.removeBackup(match)
 				if err != nil {
-					l.reportError("age_cleanup", fmt.Errorf("failed to remove old file %s (age: %v): %v", match, fileAge, err))
+					err = l.taskFailed("age_cleanup", fmt.Errorf("failed to remove old file %s (age: %v): %v", match, fileAge, err))
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					removed = append(removed, match)
 				}
 				continue // Don't include in files list since it's removed
 			}
@@ -438,10 +788,20 @@ func (l *Logger) cleanupOldFiles() {
 		})
 	}
 
+	// Report removals once, whichever branch returns
+	defer func() {
+		if len(removed) > 0 && l.Manifest {
+			_ = l.pruneManifest(removed) // Failures are reported via taskFailed
+		}
+		if len(removed) > 0 && l.OnCleanup != nil {
+			l.safeInvokeOnCleanup(removed)
+		}
+	}()
+
 	// Apply count-based cleanup (MaxBackups)
 	ret2 := l.effectiveRetention()
 	if ret2.MaxBackups <= 0 || len(files) <= ret2.MaxBackups {
-		return // Nothing to clean up by count
+		return firstErr // Nothing to clean up by count
 	}
 
 	// Sort by modification time (oldest first)
@@ -452,15 +812,75 @@ func (l *Logger) cleanupOldFiles() {
 	// Remove oldest files beyond MaxBackups
 	filesToRemove := len(files) - ret2.MaxBackups
 	for i := 0; i < filesToRemove; i++ {
-		err := os.Remove(files[i].name)
+		err := l.removeBackup(files[i].name)
 		if err != nil {
-			l.reportError("count_cleanup", fmt.Errorf("failed to remove excess backup file %s: %v", files[i].name, err))
+			err = l.taskFailed("count_cleanup", fmt.Errorf("failed to remove excess backup file %s: %v", files[i].name, err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			removed = append(removed, files[i].name)
 		}
 	}
+	return firstErr
+}
+
+// removeBackup deletes a backup together with its checksum sidecar.
+// The sidecar may name any form of the backup, because checksum and
+// compression tasks run concurrently.
+func (l *Logger) removeBackup(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	base := trimCompressedSuffix(strings.TrimSuffix(path, encryptedSuffix))
+	for _, form := range backupForms(base) {
+		_ = os.Remove(form + ".sha256") // Best effort: most forms have no sidecar
+	}
+	return nil
+}
+
+// defaultCompressMinSize is the CompressMinSize used when it is unset.
+const defaultCompressMinSize = 1024
+
+// compressMinSize returns the effective CompressMinSize (negative = none).
+func (l *Logger) compressMinSize() int64 {
+	if l.CompressMinSize == 0 {
+		return defaultCompressMinSize
+	}
+	return l.CompressMinSize
+}
+
+// skipCompression leaves a below-threshold backup uncompressed, still
+// encrypting it when an Encryptor is set.
+func (l *Logger) skipCompression(filename string) error {
+	if l.Encryptor != nil {
+		return l.encryptFile(filename)
+	}
+	return nil
+}
+
+// compressFailed reports a compression failure as a CompressionError under
+// the operation "compress_" + op.
+func (l *Logger) compressFailed(op, filename string, err error) error {
+	return l.taskFailed("compress_"+op, &CompressionError{Op: op, Path: filename, Err: err})
 }
 
-// compressFile compresses a rotated log file using gzip with crash consistency
-func (l *Logger) compressFile(filename string) {
+// compressFile compresses a rotated log file with the configured codec
+// (see Compression) with crash consistency
+func (l *Logger) compressFile(filename string) error {
+	codec, ok := lookupCompressor(l.Compression)
+	if !ok {
+		return l.compressFailed("codec", filename, fmt.Errorf("unknown compressor %q", l.Compression))
+	}
+
+	// Tiny backups grow when compressed (codec headers); keep them plain
+	if min := l.compressMinSize(); min > 0 {
+		if info, err := os.Stat(filename); err == nil && info.Size() < min {
+			return l.skipCompression(filename)
+		}
+	}
+	defer l.acquireCompressSlot()() // Released once the encrypted copy is written too
+
 	// Open source file with retry (file might be in use during high-frequency rotation)
 	var source *os.File
 	err := RetryFileOperation(func() error {
@@ -470,8 +890,7 @@ func (l *Logger) compressFile(filename string) {
 	}, 3, 10*time.Millisecond)
 
 	if err != nil {
-		l.reportError("compress_open", err)
-		return
+		return l.compressFailed("open", filename, err)
 	}
 	var sourceCloseOnce sync.Once
 	defer func() {
@@ -486,14 +905,10 @@ func (l *Logger) compressFile(filename string) {
 	}()
 
 	// Use temporary file for crash consistency
-	compressedName := filename + ".gz"
-	tempName := compressedName + ".tmp"
-
-	// Create temporary compressed file
-	target, err := os.Create(tempName) // #nosec G304 -- tempName is internally generated, not user input
+	compressedName := filename + codec.Extension()
+	target, tempName, err := l.createCompressTemp(compressedName)
 	if err != nil {
-		l.reportError("compress_create", err)
-		return
+		return l.compressFailed("create", filename, err)
 	}
 	var targetCloseOnce sync.Once
 	defer func() {
@@ -507,40 +922,43 @@ func (l *Logger) compressFile(filename string) {
 		})
 	}()
 
-	// Create gzip writer
-	gzWriter := gzip.NewWriter(target)
-	var gzCloseOnce sync.Once
+	// Create compressing writer
+	cw, err := codec.NewWriter(target)
+	if err != nil {
+		targetCloseOnce.Do(func() { _ = target.Close() })
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.compressFailed("writer", filename, err)
+	}
+	var cwCloseOnce sync.Once
 	defer func() {
-		gzCloseOnce.Do(func() {
-			if closeErr := gzWriter.Close(); closeErr != nil {
+		cwCloseOnce.Do(func() {
+			if closeErr := cw.Close(); closeErr != nil {
 				// Only report if it's not "file already closed"
 				if !isFileAlreadyClosedError(closeErr) {
-					l.reportError("compress_gzip_close", closeErr)
+					l.reportError("compress_writer_close", closeErr)
 				}
 			}
 		})
 	}()
 
 	// Copy data with compression
-	_, err = io.Copy(gzWriter, source)
+	originalSize, err := io.Copy(cw, source)
 	if err != nil {
 		// Clean up failed compression - use sync.Once to avoid duplicate closes
-		gzCloseOnce.Do(func() { _ = gzWriter.Close() })
+		cwCloseOnce.Do(func() { _ = cw.Close() })
 		targetCloseOnce.Do(func() { _ = target.Close() })
 		_ = os.Remove(tempName) // Ignore remove error during cleanup
-		l.reportError("compress_copy", err)
-		return
+		return l.compressFailed("copy", filename, err)
 	}
 
-	// Close gzip writer to finalize compression
+	// Close compressing writer to finalize the stream
 	var finalizeErr error
-	gzCloseOnce.Do(func() {
-		finalizeErr = gzWriter.Close()
+	cwCloseOnce.Do(func() {
+		finalizeErr = cw.Close()
 	})
 	if finalizeErr != nil {
 		_ = os.Remove(tempName) // Ignore remove error during cleanup
-		l.reportError("compress_finalize", finalizeErr)
-		return
+		return l.compressFailed("finalize", filename, finalizeErr)
 	}
 
 	// Close target file
@@ -550,23 +968,69 @@ func (l *Logger) compressFile(filename string) {
 	})
 	if closeErr != nil {
 		_ = os.Remove(tempName) // Ignore remove error during cleanup
-		l.reportError("compress_close", closeErr)
-		return
+		return l.compressFailed("close", filename, closeErr)
 	}
 
 	// Atomically rename temporary file to final name
 	// This ensures crash consistency - either compression is complete or it failed
-	err = os.Rename(tempName, compressedName)
+	if l.TempDir != "" {
+		err = l.renameFile(tempName, compressedName) // May cross devices
+	} else {
+		err = os.Rename(tempName, compressedName)
+	}
 	if err != nil {
 		_ = os.Remove(tempName) // Ignore remove error during cleanup
-		l.reportError("compress_rename", fmt.Errorf("failed to rename %s to %s: %v", tempName, compressedName, err))
-		return
+		return l.compressFailed("rename", filename, err)
 	}
 
 	// Remove original file only after successful compression and rename
+	var cleanupErr error
 	if err := os.Remove(filename); err != nil {
-		l.reportError("compress_cleanup", err)
+		cleanupErr = l.compressFailed("cleanup", filename, err)
+	}
+
+	// Account the savings (Stats.CompressionRatio); backups skipped by
+	// CompressMinSize never get here, so they don't dilute the ratio
+	ratio := 1.0
+	if info, err := os.Stat(compressedName); err == nil && originalSize > 0 {
+		l.uncompressedBytes.Add(uint64(originalSize)) // #nosec G115 -- checked positive above
+		l.compressedBytes.Add(uint64(info.Size()))    // #nosec G115 -- file sizes are never negative
+		ratio = float64(info.Size()) / float64(originalSize)
+	}
+	if l.OnCompress != nil {
+		l.safeInvokeOnCompress(filename, compressedName, ratio)
+	}
+
+	// Encrypt after compression: ciphertext does not compress
+	if l.Encryptor != nil {
+		if err := l.encryptFile(compressedName); err != nil {
+			return err
+		}
+	}
+	return cleanupErr
+}
+
+// createCompressTemp creates the temporary file compression writes to:
+// compressedName + ".tmp", or a uniquely named file in TempDir when set,
+// since several Loggers may share the scratch directory.
+func (l *Logger) createCompressTemp(compressedName string) (*os.File, string, error) {
+	if l.TempDir == "" {
+		tempName := compressedName + ".tmp"
+		file, err := os.OpenFile(tempName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, l.backupFileMode()) // #nosec G304 -- tempName is internally generated, not user input
+		return file, tempName, err
+	}
+
+	file, err := os.CreateTemp(l.TempDir, filepath.Base(compressedName)+".*.tmp")
+	if err != nil {
+		return nil, "", err
+	}
+	// CreateTemp uses 0600; the rename carries the mode to the backup
+	if err := file.Chmod(l.backupFileMode()); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name()) // Ignore remove error during cleanup
+		return nil, "", err
 	}
+	return file, file.Name(), nil
 }
 
 // FileSystem interface for cross-platform abstraction
@@ -603,9 +1067,25 @@ func (fs DefaultFileSystem) Stat(name string) (os.FileInfo, error) {
 
 // BackgroundTask represents a task for the worker pool
 type BackgroundTask struct {
-	TaskType string // "cleanup", "compress", or "checksum"
+	TaskType string // "cleanup", "tier", "compress", "checksum", "encrypt" or "manifest"
 	FilePath string
 	Logger   *Logger
+
+	tasks *rotationTasks // Rotation waiting on this task (RotateSync), or nil
+}
+
+// Background worker pool sizing
+const (
+	defaultBackgroundWorkers = 2   // Workers when BackgroundWorkers is unset
+	backgroundTaskQueueSize  = 100 // Tasks queued before submissions are dropped
+)
+
+// backgroundWorkerCount returns BackgroundWorkers with the default applied.
+func (l *Logger) backgroundWorkerCount() int {
+	if l.BackgroundWorkers <= 0 {
+		return defaultBackgroundWorkers
+	}
+	return l.BackgroundWorkers
 }
 
 // BackgroundWorkers manages a pool of workers for background operations
@@ -615,7 +1095,7 @@ type BackgroundWorkers struct {
 	taskQueue   chan BackgroundTask
 	wg          sync.WaitGroup
 	workers     int
-	activeTasks atomic.Int64 // Track active tasks for synchronization
+	activeTasks atomic.Int64 // Queued plus running tasks, for synchronization
 	stopOnce    sync.Once    // Ensure stop is called only once
 
 	// Condition variable for efficient waitForCompletion
@@ -630,7 +1110,7 @@ func newBackgroundWorkers(numWorkers int) *BackgroundWorkers {
 	bg := &BackgroundWorkers{
 		ctx:       ctx,
 		cancel:    cancel,
-		taskQueue: make(chan BackgroundTask, 100), // Buffered channel
+		taskQueue: make(chan BackgroundTask, backgroundTaskQueueSize),
 		workers:   numWorkers,
 	}
 	bg.taskCond = sync.NewCond(&bg.condMu)
@@ -652,7 +1132,10 @@ func (bg *BackgroundWorkers) worker() {
 		select {
 		case <-bg.ctx.Done():
 			return
-		case task := <-bg.taskQueue:
+		case task, ok := <-bg.taskQueue:
+			if !ok {
+				return // Closed by stop; it releases what is left
+			}
 			bg.processTask(task)
 		}
 	}
@@ -660,30 +1143,70 @@ func (bg *BackgroundWorkers) worker() {
 
 // processTask executes a background task
 func (bg *BackgroundWorkers) processTask(task BackgroundTask) {
-	// Increment active task counter
-	bg.activeTasks.Add(1)
+	// Signal any waiters when the task completes (counted at submit)
+	defer bg.taskDone()
+
+	var err error
+	// WHY recover: tasks run filesystem and Encryptor code on a shared
+	// worker; a panic must not take down the application or the pool.
 	defer func() {
-		bg.activeTasks.Add(-1)
-		// Signal any waiters that a task completed
-		bg.taskCond.Broadcast()
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s task for %s panicked: %v", task.TaskType, task.FilePath, r)
+			if task.Logger != nil {
+				task.Logger.reportError("worker_panic", err)
+			}
+		}
+		task.tasks.done(err)
 	}()
 
 	switch task.TaskType {
 	case "cleanup":
-		task.Logger.cleanupOldFiles()
+		err = task.Logger.cleanupOldFiles()
 	case "compress":
-		task.Logger.compressFile(task.FilePath)
+		err = task.Logger.compressFile(task.FilePath)
 	case "checksum":
-		task.Logger.generateChecksum(task.FilePath)
+		err = task.Logger.generateChecksum(task.FilePath)
+	case "tier":
+		err = task.Logger.retierBackups()
+	case "encrypt":
+		err = task.Logger.encryptFile(task.FilePath)
+	case "manifest":
+		// Nothing to transform; the entry is recorded below
+	}
+
+	// The manifest entry follows the last task that changes the backup,
+	// so it records the file as it stays at rest
+	switch task.TaskType {
+	case "compress", "encrypt", "manifest":
+		if err == nil && task.Logger.Manifest {
+			err = task.Logger.recordBackup(task.FilePath)
+		}
 	}
 }
 
+// taskDone marks a submitted task as finished and wakes waiters.
+// The decrement happens under condMu so a waiter cannot check the
+// counter and then miss the Broadcast (lost wakeup).
+func (bg *BackgroundWorkers) taskDone() {
+	bg.condMu.Lock()
+	bg.activeTasks.Add(-1)
+	bg.condMu.Unlock()
+	bg.taskCond.Broadcast()
+}
+
 // stop gracefully shuts down the worker pool
 func (bg *BackgroundWorkers) stop() {
 	bg.stopOnce.Do(func() {
 		bg.cancel()
 		close(bg.taskQueue)
 		bg.wg.Wait()
+
+		// Release tasks that were queued but never picked up, so
+		// waitForCompletion cannot block after shutdown
+		for task := range bg.taskQueue {
+			task.tasks.done(errTaskNotRun(task, "background workers stopped"))
+			bg.taskDone()
+		}
 	})
 }
 
@@ -700,33 +1223,32 @@ func (bg *BackgroundWorkers) waitForCompletion() {
 
 // generateChecksum creates a SHA-256 checksum sidecar file for the given file
 // Called in background worker pool for rotated files
-func (l *Logger) generateChecksum(filename string) {
+func (l *Logger) generateChecksum(filename string) error {
 	// Check if the file exists
 	_, err := os.Stat(filename)
 	if os.IsNotExist(err) {
-		// File might have been compressed - try .gz version
-		if !strings.HasSuffix(filename, ".gz") {
-			gzFilename := filename + ".gz"
-			if _, err := os.Stat(gzFilename); err == nil {
-				filename = gzFilename
-			} else {
-				l.reportError("checksum_missing", fmt.Errorf("file not found for checksum: %s", filename))
-				return
+		// File might have been compressed and/or encrypted - try those versions
+		found := false
+		if _, compressed := compressorForPath(filename); !compressed {
+			for _, form := range backupForms(filename)[1:] { // [1:] skips filename itself
+				if _, err := os.Stat(form); err == nil {
+					filename = form
+					found = true
+					break
+				}
 			}
-		} else {
-			l.reportError("checksum_missing", fmt.Errorf("file not found for checksum: %s", filename))
-			return
+		}
+		if !found {
+			return l.taskFailed("checksum_missing", fmt.Errorf("file not found for checksum: %s", filename))
 		}
 	} else if err != nil {
-		l.reportError("checksum_stat", fmt.Errorf("failed to stat file for checksum %s: %v", filename, err))
-		return
+		return l.taskFailed("checksum_stat", fmt.Errorf("failed to stat file for checksum %s: %v", filename, err))
 	}
 
 	// Open the file
 	file, err := os.Open(filename) // #nosec G304 -- filename is internal backup file path, not user input
 	if err != nil {
-		l.reportError("checksum_open", fmt.Errorf("failed to open file for checksum %s: %v", filename, err))
-		return
+		return l.taskFailed("checksum_open", fmt.Errorf("failed to open file for checksum %s: %v", filename, err))
 	}
 	defer func() {
 		if closeErr := file.Close(); closeErr != nil {
@@ -740,8 +1262,7 @@ func (l *Logger) generateChecksum(filename string) {
 	// Calculate SHA-256 hash
 	hash := sha256.New()
 	if _, err := io.Copy(hash, file); err != nil {
-		l.reportError("checksum_read", fmt.Errorf("failed to read file for checksum %s: %v", filename, err))
-		return
+		return l.taskFailed("checksum_read", fmt.Errorf("failed to read file for checksum %s: %v", filename, err))
 	}
 
 	// Generate hex string
@@ -751,11 +1272,11 @@ func (l *Logger) generateChecksum(filename string) {
 	checksumFile := filename + ".sha256"
 	content := fmt.Sprintf("%s  %s\n", hashHex, filepath.Base(filename))
 
-	err = os.WriteFile(checksumFile, []byte(content), 0600) // More secure permissions
+	err = os.WriteFile(checksumFile, []byte(content), l.backupFileMode())
 	if err != nil {
-		l.reportError("checksum_write", fmt.Errorf("failed to write checksum file %s: %v", checksumFile, err))
-		return
+		return l.taskFailed("checksum_write", fmt.Errorf("failed to write checksum file %s: %v", checksumFile, err))
 	}
+	return nil
 }
 
 // isFileAlreadyClosedError checks if the error indicates the file is already closed
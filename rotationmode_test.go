@@ -0,0 +1,110 @@
+// rotationmode_test.go: Tests for RotationMode
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSizeAgeLogger returns a Logger limited to 1KB and 1h, on a fake clock.
+func newSizeAgeLogger(t *testing.T, mode string) (*Logger, *fakeClock) {
+	t.Helper()
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "mode.log"),
+		MaxSizeStr:       "1KB",
+		MaxAgeStr:        "1h",
+		RotationMode:     mode,
+		DisableAutoScale: true,
+	})
+	clk := newFakeClock()
+	logger.setClock(clk)
+	return logger, clk
+}
+
+func writeBytes(t *testing.T, logger *Logger, n int) {
+	t.Helper()
+	if _, err := logger.Write([]byte(strings.Repeat("x", n-1) + "\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+}
+
+func TestRotationMode_AnyRotatesOnEitherLimit(t *testing.T) {
+	logger, clk := newSizeAgeLogger(t, RotationModeAny)
+
+	writeBytes(t, logger, 2048)
+	if got := logger.Stats().SizeRotations; got != 1 {
+		t.Fatalf("SizeRotations = %d after 2KB, want 1", got)
+	}
+
+	writeBytes(t, logger, 10)
+	clk.Advance(time.Hour)
+	writeBytes(t, logger, 10)
+	if got := logger.Stats().AgeRotations; got != 1 {
+		t.Errorf("AgeRotations = %d for a small file an hour old, want 1", got)
+	}
+}
+
+func TestRotationMode_AllWaitsForEveryLimit(t *testing.T) {
+	logger, clk := newSizeAgeLogger(t, RotationModeAll)
+
+	writeBytes(t, logger, 2048)
+	if got := logger.Stats().RotationCount; got != 0 {
+		t.Fatalf("RotationCount = %d for a new 2KB file, want 0 until it is an hour old", got)
+	}
+
+	clk.Advance(time.Hour)
+	writeBytes(t, logger, 10)
+	stats := logger.Stats()
+	if stats.RotationCount != 1 || stats.SizeRotations != 1 {
+		t.Fatalf("RotationCount = %d, SizeRotations = %d once both limits are reached; want 1, 1",
+			stats.RotationCount, stats.SizeRotations)
+	}
+
+	// A quiet file ages out without reaching the size: no tiny backup
+	writeBytes(t, logger, 10)
+	clk.Advance(3 * time.Hour)
+	writeBytes(t, logger, 10)
+	if got := logger.Stats().RotationCount; got != 1 {
+		t.Errorf("RotationCount = %d for a small old file, want 1", got)
+	}
+}
+
+func TestRotationMode_AllIgnoresUnsetLimits(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "mode.log"),
+		MaxSizeStr:       "1KB",
+		RotationMode:     RotationModeAll,
+		DisableAutoScale: true,
+	})
+
+	writeBytes(t, logger, 2048)
+	if got := logger.Stats().SizeRotations; got != 1 {
+		t.Errorf("SizeRotations = %d with only MaxSizeStr set, want 1", got)
+	}
+}
+
+func TestRotationMode_ManualRotateAlwaysRotates(t *testing.T) {
+	logger, _ := newSizeAgeLogger(t, RotationModeAll)
+
+	writeBytes(t, logger, 10)
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if got := logger.Stats().ManualRotations; got != 1 {
+		t.Errorf("ManualRotations = %d, want 1 regardless of RotationMode", got)
+	}
+}
+
+func TestRotationMode_Validation(t *testing.T) {
+	err := ValidateConfig(&LoggerConfig{Filename: "app.log", RotationMode: "both"})
+	if err == nil || !strings.Contains(err.Error(), "RotationMode") {
+		t.Errorf("ValidateConfig(RotationMode \"both\") = %v, want a RotationMode error", err)
+	}
+}
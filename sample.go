@@ -0,0 +1,42 @@
+// sample.go: Proactive load shedding (SampleRate, MaxWritesPerSecond)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"math/rand/v2"
+)
+
+// admitWrite reports whether a write of size bytes survives SampleRate
+// and MaxWritesPerSecond, counting it as a drop otherwise.
+// Lock-free and allocation-free: math/rand/v2's top-level functions use a
+// per-thread generator, and the rate window is two atomics.
+func (l *Logger) admitWrite(size int) bool {
+	if l.SampleRate > 0 && l.SampleRate < 1 && rand.Float64() >= l.SampleRate {
+		l.countDrop(dropSampled, size)
+		return false
+	}
+	if l.MaxWritesPerSecond > 0 && !l.withinRateLimit() {
+		l.countDrop(dropRateLimited, size)
+		return false
+	}
+	return true
+}
+
+// withinRateLimit admits up to MaxWritesPerSecond writes per wall-clock
+// second (a fixed window, so a burst may straddle two windows).
+//
+// WHY the reset race is harmless: when several writers observe a new
+// second, only the CAS winner resets the count; losers count into the
+// fresh window. At worst a handful of writes land in the wrong window.
+func (l *Logger) withinRateLimit() bool {
+	now := l.timeCache.CachedTime().Unix()
+	window := l.rateWindow.Load()
+	if now != window && l.rateWindow.CompareAndSwap(window, now) {
+		l.rateCount.Store(0)
+	}
+	return l.rateCount.Add(1) <= int64(l.MaxWritesPerSecond)
+}
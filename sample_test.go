@@ -0,0 +1,88 @@
+// sample_test.go: Tests for SampleRate and MaxWritesPerSecond
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSampleRate_KeepsFraction(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "sampled.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, SampleRate: 0.1, DisableAutoScale: true})
+
+	const writes = 10000
+	for i := 0; i < writes; i++ {
+		if n, err := logger.Write([]byte("x\n")); n != 2 || err != nil {
+			t.Fatalf("Write = %d, %v; sampled writes must still report success", n, err)
+		}
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	kept := bytes.Count(data, []byte("\n"))
+	if kept < 700 || kept > 1300 {
+		t.Errorf("kept %d of %d writes, want about 10%%", kept, writes)
+	}
+	stats := logger.Stats()
+	if stats.SampledOut != uint64(writes-kept) {
+		t.Errorf("SampledOut = %d, want %d", stats.SampledOut, writes-kept)
+	}
+	if stats.DroppedSampled != stats.SampledOut || stats.DroppedRateLimited != 0 {
+		t.Errorf("DroppedSampled = %d, DroppedRateLimited = %d; want all of SampledOut sampled",
+			stats.DroppedSampled, stats.DroppedRateLimited)
+	}
+	if stats.DroppedBytes != 2*stats.SampledOut {
+		t.Errorf("DroppedBytes = %d, want %d", stats.DroppedBytes, 2*stats.SampledOut)
+	}
+}
+
+func TestMaxWritesPerSecond_CapsWrites(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "limited.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, MaxWritesPerSecond: 10, DisableAutoScale: true})
+
+	const writes = 200
+	for i := 0; i < writes; i++ {
+		if _, err := logger.Write([]byte("x\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// The burst may straddle one window boundary
+	kept := bytes.Count(data, []byte("\n"))
+	if kept < 10 || kept > 20 {
+		t.Errorf("kept %d writes, want 10 (or up to 20 across a second boundary)", kept)
+	}
+	stats := logger.Stats()
+	if stats.SampledOut != uint64(writes-kept) {
+		t.Errorf("SampledOut = %d, want %d", stats.SampledOut, writes-kept)
+	}
+	if stats.DroppedRateLimited != stats.SampledOut || stats.DroppedSampled != 0 {
+		t.Errorf("DroppedRateLimited = %d, DroppedSampled = %d; want all of SampledOut rate-limited",
+			stats.DroppedRateLimited, stats.DroppedSampled)
+	}
+}
+
+func TestAdmitWrite_ZeroAllocs(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           filepath.Join(t.TempDir(), "allocs.log"),
+		SampleRate:         0.5,
+		MaxWritesPerSecond: 1000,
+	})
+
+	if allocs := testing.AllocsPerRun(1000, func() { logger.admitWrite(64) }); allocs != 0 {
+		t.Errorf("admitWrite allocates %v times per call, want 0", allocs)
+	}
+}
@@ -0,0 +1,241 @@
+// scale.go: Auto-scaling transitions between sync and MPSC modes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AutoScaleConfig tunes when a non-Async logger switches to MPSC.
+// Zero fields use the defaults listed below; negative values are rejected
+// by NewWithConfig. The same thresholds define the quiet period after
+// which MPSC is scaled back down to sync.
+//
+// Example (network filesystem with naturally slow writes):
+//
+//	AutoScale: &lethe.AutoScaleConfig{
+//		AvgLatencyThreshold:   10 * time.Millisecond,
+//		SpikeLatencyThreshold: 50 * time.Millisecond,
+//	}
+type AutoScaleConfig struct {
+	// MinSamples is the number of writes observed before any decision (default: 100)
+	MinSamples uint64 `json:"min_samples"`
+
+	// AvgLatencyThreshold scales up when average write latency exceeds it (default: 1ms)
+	AvgLatencyThreshold time.Duration `json:"avg_latency_threshold"`
+
+	// SpikeLatencyThreshold scales up when a single write exceeds it (default: 5ms)
+	SpikeLatencyThreshold time.Duration `json:"spike_latency_threshold"`
+
+	// ContentionRatioThreshold scales up when contended writes exceed this
+	// fraction of all writes (default: 0.1, must be <= 1)
+	ContentionRatioThreshold float64 `json:"contention_ratio_threshold"`
+}
+
+// Default auto-scaling thresholds
+const (
+	defaultScaleMinSamples      = 100
+	defaultScaleAvgLatency      = time.Millisecond
+	defaultScaleSpikeLatency    = 5 * time.Millisecond
+	defaultScaleContentionRatio = 0.1
+	defaultScaleCheckInterval   = time.Second // Length of one downscale sample window
+	scaleDownQuietWindows       = 5           // Consecutive quiet windows before downscale
+	scaleContentionSampleFactor = 10          // Any contention counts after MinSamples*10 writes
+)
+
+// validate rejects negative or out-of-range thresholds.
+func (c *AutoScaleConfig) validate() error {
+	if c.AvgLatencyThreshold < 0 || c.SpikeLatencyThreshold < 0 {
+		return fmt.Errorf("invalid AutoScale: latency thresholds must be positive")
+	}
+	if c.ContentionRatioThreshold < 0 || c.ContentionRatioThreshold > 1 {
+		return fmt.Errorf("invalid AutoScale: ContentionRatioThreshold %v must be in (0, 1]", c.ContentionRatioThreshold)
+	}
+	return nil
+}
+
+// autoScaleThresholds returns AutoScale with defaults applied.
+func (l *Logger) autoScaleThresholds() AutoScaleConfig {
+	var c AutoScaleConfig
+	if l.AutoScale != nil {
+		c = *l.AutoScale
+	}
+	if c.MinSamples == 0 {
+		c.MinSamples = defaultScaleMinSamples
+	}
+	if c.AvgLatencyThreshold <= 0 {
+		c.AvgLatencyThreshold = defaultScaleAvgLatency
+	}
+	if c.SpikeLatencyThreshold <= 0 {
+		c.SpikeLatencyThreshold = defaultScaleSpikeLatency
+	}
+	if c.ContentionRatioThreshold <= 0 {
+		c.ContentionRatioThreshold = defaultScaleContentionRatio
+	}
+	return c
+}
+
+// scaleDrain is one downscale in progress. drained is closed once no
+// routed writer is left in flight, resumed once writers may route again.
+type scaleDrain struct {
+	drained     chan struct{}
+	drainedOnce sync.Once
+	resumed     chan struct{}
+}
+
+// routeAutoScaled reports whether a non-Async write should take the MPSC path.
+// A true result leaves asyncInFlight incremented; the caller must release
+// it with doneInFlight once the write has been pushed (or fallen back).
+//
+// WHY increment before checking scaleDrain: scaleDown publishes the drain and
+// then waits for asyncInFlight to reach zero. Either the writer sees the drain
+// (and backs off), or scaleDown sees the writer (and waits for its push), so
+// no message can be pushed into a buffer whose consumer is already stopped.
+func (l *Logger) routeAutoScaled() bool {
+	if l.BufferedSync {
+		return false // MPSC writes would overtake buffered ones
+	}
+	for {
+		l.asyncInFlight.Add(1)
+		drain := l.scaleDrain.Load()
+		if drain == nil {
+			if l.autoScaled.Load() {
+				return true
+			}
+			if l.shouldScaleToMPSC() {
+				l.scaleUp()
+				return true
+			}
+			l.doneInFlight()
+			return false
+		}
+		// Downscale drain in progress: wait so writes stay ordered
+		l.doneInFlight()
+		<-drain.resumed
+	}
+}
+
+// doneInFlight releases a writer counted in asyncInFlight. The last one out
+// during a drain wakes scaleDown.
+func (l *Logger) doneInFlight() {
+	if l.asyncInFlight.Add(-1) != 0 {
+		return
+	}
+	if drain := l.scaleDrain.Load(); drain != nil {
+		drain.drainedOnce.Do(func() { close(drain.drained) })
+	}
+}
+
+// scaleUp records the sync -> MPSC transition and starts the monitor that
+// later scales back down. Only the goroutine winning the CAS does the work.
+func (l *Logger) scaleUp() {
+	if !l.autoScaled.CompareAndSwap(false, true) {
+		return // Someone else scaled up
+	}
+	l.scaleUps.Add(1)
+
+	m := &backgroundLoop{stopCh: make(chan struct{})}
+	l.scaleMonitor.Store(m)
+	m.wg.Add(1)
+	go l.runScaleMonitor(m)
+}
+
+// runScaleMonitor samples write metrics once per window and tears MPSC down
+// after a sustained quiet period. It is the only caller of scaleDown, so
+// transitions never overlap.
+func (l *Logger) runScaleMonitor(m *backgroundLoop) {
+	defer m.wg.Done()
+
+	interval := l.scaleCheckInterval
+	if interval <= 0 {
+		interval = defaultScaleCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	thresholds := l.autoScaleThresholds()
+	maxLatency := uint64(thresholds.AvgLatencyThreshold.Nanoseconds()) // #nosec G115 -- thresholds are positive after defaults
+
+	prevWrites := l.writeCount.Load()
+	prevContention := l.contentionCount.Load()
+	prevLatency := l.totalLatency.Load()
+	quiet := 0
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		writes := l.writeCount.Load()
+		contention := l.contentionCount.Load()
+		latency := l.totalLatency.Load()
+
+		dWrites := writes - prevWrites
+		var avgLatency uint64
+		if dWrites > 0 {
+			avgLatency = (latency - prevLatency) / dWrites
+		}
+		// Quiet: fewer writes than a scale-up sample, no contention, fast writes
+		if dWrites < thresholds.MinSamples && contention == prevContention && avgLatency < maxLatency {
+			quiet++
+		} else {
+			quiet = 0
+		}
+		prevWrites, prevContention, prevLatency = writes, contention, latency
+
+		// The final flush in scaleDown writes, so it waits out a Pause
+		if quiet >= scaleDownQuietWindows && l.enterFS() {
+			done := l.scaleDown()
+			l.exitFS()
+			if done {
+				return
+			}
+		}
+	}
+}
+
+// scaleDown drains and stops the auto-scaled MPSC consumer and returns
+// writes to the synchronous path. Returns false if MPSC is mid-initialization
+// and the teardown must be retried on a later window.
+func (l *Logger) scaleDown() bool {
+	if l.buffer.Load() != nil && l.consumer.Load() == nil {
+		return false // initMPSC has not published the consumer yet
+	}
+
+	// Block new routing decisions, then wait for writers already routed.
+	// A writer reaching zero before the drain was published has nobody to
+	// signal, so the count is checked after publishing it.
+	drain := &scaleDrain{drained: make(chan struct{}), resumed: make(chan struct{})}
+	l.scaleDrain.Store(drain)
+	if l.asyncInFlight.Load() != 0 {
+		<-drain.drained
+	}
+
+	// Stopping the consumer performs a final flush of everything buffered
+	if consumer := l.consumer.Load(); consumer != nil {
+		consumer.stop()
+	}
+	l.consumer.Store(nil)
+	l.buffer.Store(nil)
+
+	// Scale-up decisions restart from the metrics at this point, otherwise
+	// cumulative contention would re-trigger MPSC on the next write
+	l.scaleBaseWrites.Store(l.writeCount.Load())
+	l.scaleBaseContention.Store(l.contentionCount.Load())
+	l.scaleBaseLatency.Store(l.totalLatency.Load())
+
+	l.scaleMonitor.Store(nil)
+	l.autoScaled.Store(false)
+	l.scaleDowns.Add(1)
+	l.scaleDrain.Store(nil)
+	close(drain.resumed)
+	return true
+}
@@ -0,0 +1,277 @@
+// scale_test.go: Tests for auto-scaling transitions between sync and MPSC
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newScaleTestLogger returns a sync logger whose metrics already demand
+// MPSC, with a short downscale sample window.
+func newScaleTestLogger(t *testing.T, logFile string) *Logger {
+	t.Helper()
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+	logger.scaleCheckInterval = 10 * time.Millisecond
+	// Simulated contention history: shouldScaleToMPSC returns true
+	logger.writeCount.Store(2000)
+	logger.contentionCount.Store(500)
+	return logger
+}
+
+func waitForScaleDown(t *testing.T, logger *Logger, want uint64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.Stats().ScaleDownCount < want && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := logger.Stats().ScaleDownCount; got < want {
+		t.Fatalf("ScaleDownCount = %d, want >= %d", got, want)
+	}
+}
+
+// waitForScaleDrain waits until a scaleDown has published its drain.
+func waitForScaleDrain(t *testing.T, logger *Logger) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for logger.scaleDrain.Load() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("scaleDown never started draining")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAutoScale_DownscalesWhenIdle(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "scale.log")
+	logger := newScaleTestLogger(t, logFile)
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte("spike\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	stats := logger.Stats()
+	if stats.EffectiveMode != "mpsc" || stats.ScaleUpCount != 1 {
+		t.Fatalf("after spike: mode=%q ups=%d, want mpsc/1", stats.EffectiveMode, stats.ScaleUpCount)
+	}
+
+	waitForScaleDown(t, logger, 1)
+	stats = logger.Stats()
+	if stats.EffectiveMode != "sync" {
+		t.Errorf("EffectiveMode = %q after quiet period, want sync", stats.EffectiveMode)
+	}
+	if logger.consumer.Load() != nil || logger.buffer.Load() != nil {
+		t.Error("consumer or buffer still present after downscale")
+	}
+
+	// Scale-up history is reset: subsequent quiet writes stay synchronous
+	if _, err := logger.Write([]byte("calm\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().ScaleUpCount; got != 1 {
+		t.Errorf("ScaleUpCount = %d, want 1 (no re-scale on stale metrics)", got)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "spike\ncalm\n" {
+		t.Errorf("content = %q, want spike then calm", content)
+	}
+}
+
+// TestAutoScale_DownscaleUnderConcurrentWriters verifies no message is lost
+// or duplicated when MPSC is torn down while writers are active.
+func TestAutoScale_DownscaleUnderConcurrentWriters(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "scale-race.log")
+	logger := newScaleTestLogger(t, logFile)
+
+	const writers, perWriter = 4, 150
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := fmt.Fprintf(logger, "w%d-%d\n", w, i); err != nil {
+					t.Errorf("Write: %v", err)
+					return
+				}
+				time.Sleep(500 * time.Microsecond) // Quiet enough to downscale mid-run
+			}
+		}(w)
+	}
+	wg.Wait()
+	waitForScaleDown(t, logger, 1)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != writers*perWriter {
+		t.Fatalf("got %d lines, want %d", len(lines), writers*perWriter)
+	}
+	seen := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if seen[line] {
+			t.Fatalf("duplicate line %q", line)
+		}
+		seen[line] = true
+	}
+}
+
+// TestAutoScale_DrainWaitsForInFlightWriter checks that scaleDown blocks
+// until a routed writer is done, and that writers arriving during the drain
+// wait for it and then write synchronously.
+func TestAutoScale_DrainWaitsForInFlightWriter(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "scale-drain.log")
+	logger := newScaleTestLogger(t, logFile)
+	defer func() { _ = logger.Close() }()
+
+	if _, err := logger.Write([]byte("spike\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	logger.scaleMonitor.Load().stop() // This test is the only scaleDown caller
+
+	if !logger.routeAutoScaled() {
+		t.Fatal("routeAutoScaled = false after scale-up")
+	}
+	scaledDown := make(chan struct{})
+	go func() {
+		defer close(scaledDown)
+		logger.scaleDown()
+	}()
+	waitForScaleDrain(t, logger)
+
+	wrote := make(chan struct{})
+	go func() {
+		defer close(wrote)
+		if _, err := logger.Write([]byte("late\n")); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+	}()
+
+	select {
+	case <-scaledDown:
+		t.Fatal("scaleDown finished with a writer still in flight")
+	case <-wrote:
+		t.Fatal("Write finished during the drain")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	logger.doneInFlight()
+	<-scaledDown
+	<-wrote
+	if got := logger.Stats().EffectiveMode; got != "sync" {
+		t.Errorf("EffectiveMode = %q, want sync", got)
+	}
+	if got := readLog(t, logFile); got != "spike\nlate\n" {
+		t.Errorf("log = %q, want spike then late", got)
+	}
+}
+
+func TestDisableAutoScale_StaysSynchronous(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "strict.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, DisableAutoScale: true})
+
+	// Metrics that would otherwise trigger MPSC
+	logger.writeCount.Store(2000)
+	logger.contentionCount.Store(500)
+
+	if _, err := logger.Write([]byte("write\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := logger.WriteOwned([]byte("owned\n")); err != nil {
+		t.Fatalf("WriteOwned: %v", err)
+	}
+
+	stats := logger.Stats()
+	if stats.EffectiveMode != "sync" || stats.ScaleUpCount != 0 {
+		t.Errorf("mode=%q ups=%d, want sync/0 with DisableAutoScale", stats.EffectiveMode, stats.ScaleUpCount)
+	}
+	// Synchronous: data is on disk as soon as Write returns
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(content) != "write\nowned\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestAutoScaleConfig_Thresholds(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "thresholds.log")
+
+	// Same simulated history as newScaleTestLogger: 25% contention
+	build := func(cfg *AutoScaleConfig) *Logger {
+		logger := newTestLogger(t, &LoggerConfig{Filename: logFile, AutoScale: cfg})
+		logger.writeCount.Store(2000)
+		logger.contentionCount.Store(500)
+		return logger
+	}
+
+	defaults := build(nil)
+	defer func() { _ = defaults.Close() }()
+	if !defaults.shouldScaleToMPSC() {
+		t.Error("default thresholds should scale up at 25% contention")
+	}
+
+	relaxed := build(&AutoScaleConfig{MinSamples: 5000, ContentionRatioThreshold: 0.5})
+	defer func() { _ = relaxed.Close() }()
+	if relaxed.shouldScaleToMPSC() {
+		t.Error("MinSamples 5000 should suppress scaling with 2000 writes")
+	}
+
+	tolerant := build(&AutoScaleConfig{MinSamples: 1000, ContentionRatioThreshold: 0.5})
+	defer func() { _ = tolerant.Close() }()
+	// 2000 writes is below MinSamples*10, and 25% is below the 50% ratio
+	if tolerant.shouldScaleToMPSC() {
+		t.Error("ContentionRatioThreshold 0.5 should tolerate 25% contention")
+	}
+
+	slow := build(&AutoScaleConfig{AvgLatencyThreshold: time.Nanosecond, ContentionRatioThreshold: 1})
+	defer func() { _ = slow.Close() }()
+	slow.contentionCount.Store(0)
+	slow.totalLatency.Store(2000 * 10)
+	if !slow.shouldScaleToMPSC() {
+		t.Error("AvgLatencyThreshold 1ns should scale up at 10ns average latency")
+	}
+}
+
+func TestAutoScaleConfig_Validation(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "invalid.log")
+	invalid := []AutoScaleConfig{
+		{AvgLatencyThreshold: -time.Millisecond},
+		{SpikeLatencyThreshold: -time.Millisecond},
+		{ContentionRatioThreshold: -0.1},
+		{ContentionRatioThreshold: 1.5},
+	}
+	for _, cfg := range invalid {
+		cfg := cfg
+		if _, err := NewWithConfig(&LoggerConfig{Filename: logFile, AutoScale: &cfg}); err == nil {
+			t.Errorf("NewWithConfig accepted AutoScale %+v", cfg)
+		}
+	}
+
+	// The logger keeps its own copy of the thresholds
+	cfg := &AutoScaleConfig{MinSamples: 42}
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, AutoScale: cfg})
+	cfg.MinSamples = 1
+	if got := logger.autoScaleThresholds().MinSamples; got != 42 {
+		t.Errorf("MinSamples = %d, want 42", got)
+	}
+}
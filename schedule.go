@@ -0,0 +1,128 @@
+// schedule.go: Calendar-aligned rotation (RotateAt)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// timeOfDay is a wall-clock boundary parsed from RotateAt.
+type timeOfDay struct {
+	hour, minute, second int
+}
+
+// parseRotateAt parses a time-of-day spec in "HH:MM" or "HH:MM:SS" form.
+func parseRotateAt(spec string) (timeOfDay, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, spec); err == nil {
+			return timeOfDay{hour: t.Hour(), minute: t.Minute(), second: t.Second()}, nil
+		}
+	}
+	return timeOfDay{}, fmt.Errorf("invalid RotateAt %q (expected HH:MM or HH:MM:SS)", spec)
+}
+
+// next returns the first occurrence of the boundary strictly after now,
+// evaluated in loc.
+//
+// WHY time.Date instead of midnight.Add(offset): on DST transition days a
+// day is not 24h long, and adding a fixed offset would drift the boundary
+// by an hour. time.Date normalizes wall-clock fields in the target zone.
+func (t timeOfDay) next(now time.Time, loc *time.Location) time.Time {
+	now = now.In(loc)
+	y, m, d := now.Date()
+	boundary := time.Date(y, m, d, t.hour, t.minute, t.second, 0, loc)
+	if !boundary.After(now) {
+		boundary = time.Date(y, m, d+1, t.hour, t.minute, t.second, 0, loc)
+	}
+	return boundary
+}
+
+// backgroundLoop owns a Logger helper goroutine (RotateAt scheduler,
+// periodic fsync) and stops it exactly once on Close.
+type backgroundLoop struct {
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// stop terminates the goroutine and waits for it to exit.
+func (s *backgroundLoop) stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		s.wg.Wait()
+	})
+}
+
+// startRotateScheduler launches the RotateAt goroutine once per Logger.
+// Called from initFile so that both NewWithConfig and struct-literal
+// construction get calendar-aligned rotation.
+func (l *Logger) startRotateScheduler() {
+	if l.RotateAt == "" || l.scheduler.Load() != nil {
+		return
+	}
+
+	tod, err := parseRotateAt(l.RotateAt)
+	if err != nil {
+		l.reportError("rotate_at_parse", err)
+		return
+	}
+
+	s := &backgroundLoop{stopCh: make(chan struct{})}
+	if !l.scheduler.CompareAndSwap(nil, s) {
+		return // Someone else started it
+	}
+
+	s.wg.Add(1)
+	go l.runRotateScheduler(s, tod)
+}
+
+// calendarRetryInterval is how long the scheduler waits before retrying a
+// boundary rotation that was skipped (paused, or another rotation running).
+var calendarRetryInterval = time.Second
+
+// runRotateScheduler sleeps until each boundary and triggers rotation.
+// Empty files are not rotated, so quiet services don't accumulate
+// empty backups at every boundary. A skipped rotation is retried every
+// calendarRetryInterval until it runs or the file is empty again.
+func (l *Logger) runRotateScheduler(s *backgroundLoop, tod timeOfDay) {
+	defer s.wg.Done()
+
+	var last time.Time
+	pending := false
+	for {
+		// WHY from last: the timer may fire a hair before the wall-clock
+		// boundary; computing from the current time alone could yield the
+		// same boundary again and rotate twice.
+		now := l.now()
+		if now.Before(last) {
+			now = last
+		}
+		next := tod.next(now, l.location())
+		wait := next.Sub(now)
+		retry := pending && wait > calendarRetryInterval
+		if retry {
+			wait = calendarRetryInterval
+		}
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+			if !retry {
+				last = next
+			}
+			// WHY keep it pending: triggerRotation skips silently while
+			// paused or while another rotation holds rotationFlag, and
+			// moving on would lose that day's boundary altogether
+			pending = l.bytesWritten.Load() > 0 && !l.triggerRotation(rotateCalendar)
+		}
+	}
+}
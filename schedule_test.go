@@ -0,0 +1,179 @@
+// schedule_test.go: Tests for calendar-aligned rotation (RotateAt)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseRotateAt(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    timeOfDay
+		wantErr bool
+	}{
+		{spec: "00:00", want: timeOfDay{0, 0, 0}},
+		{spec: "23:30", want: timeOfDay{23, 30, 0}},
+		{spec: "06:15:45", want: timeOfDay{6, 15, 45}},
+		{spec: "24:00", wantErr: true},
+		{spec: "midnight", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseRotateAt(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseRotateAt(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseRotateAt(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestTimeOfDayNext(t *testing.T) {
+	midnight := timeOfDay{}
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	if got, want := midnight.next(now, time.UTC), time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("next midnight = %v, want %v", got, want)
+	}
+
+	// A boundary later the same day is selected over tomorrow's
+	evening := timeOfDay{hour: 18}
+	if got, want := evening.next(now, time.UTC), time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("next 18:00 = %v, want %v", got, want)
+	}
+
+	// Exactly at the boundary moves to the next day (strictly after)
+	atBoundary := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+	if got, want := evening.next(atBoundary, time.UTC), time.Date(2025, 3, 11, 18, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("next 18:00 at boundary = %v, want %v", got, want)
+	}
+}
+
+func TestNewWithConfig_RejectsInvalidRotateAt(t *testing.T) {
+	_, err := NewWithConfig(&LoggerConfig{
+		Filename: filepath.Join(t.TempDir(), "bad.log"),
+		RotateAt: "25:99",
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid RotateAt")
+	}
+}
+
+// TestRotateAt_RotatesAtBoundary sets the boundary a second in the future and
+// asserts the scheduler rotates without any write crossing a size limit.
+func TestRotateAt_RotatesAtBoundary(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "calendar.log")
+
+	boundary := time.Now().UTC().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	rotated := make(chan RotationEvent, 1)
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		RotateAt: boundary.Format("15:04:05"),
+		OnRotate: func(ev RotationEvent) {
+			select {
+			case rotated <- ev:
+			default:
+			}
+		},
+	})
+
+	if _, err := logger.Write([]byte("before boundary\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	select {
+	case ev := <-rotated:
+		if ev.Timestamp.Before(boundary.Add(-time.Second)) {
+			t.Errorf("rotation at %v, before boundary %v", ev.Timestamp, boundary)
+		}
+		if stats := logger.Stats(); stats.CalendarRotations != 1 || stats.AgeRotations != 0 || stats.TimeRotations != 1 {
+			t.Errorf("CalendarRotations = %d, AgeRotations = %d, TimeRotations = %d, want 1, 0 and 1",
+				stats.CalendarRotations, stats.AgeRotations, stats.TimeRotations)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RotateAt boundary passed without rotation")
+	}
+}
+
+// TestRotateAt_SkipsEmptyFile verifies no empty backups are produced when
+// the boundary passes with nothing written.
+func TestRotateAt_SkipsEmptyFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "quiet.log")
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		RotateAt: time.Now().UTC().Add(time.Second).Format("15:04:05"),
+	})
+
+	// Zero-length write opens the file and starts the scheduler
+	if _, err := logger.Write(nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	time.Sleep(2 * time.Second)
+	if n := logger.rotationSeq.Load(); n != 0 {
+		t.Errorf("rotationSeq = %d, want 0 for empty file", n)
+	}
+}
+
+// TestRotateAt_RetriesBoundarySkippedWhilePaused drives the scheduler with a
+// fake clock: a boundary that passes while the Logger is paused must still
+// rotate once it resumes, not wait for the next day.
+func TestRotateAt_RetriesBoundarySkippedWhilePaused(t *testing.T) {
+	saved := calendarRetryInterval
+	calendarRetryInterval = 10 * time.Millisecond
+	t.Cleanup(func() { calendarRetryInterval = saved })
+
+	clk := newFakeClock()
+	boundary := clk.Now().Add(50 * time.Millisecond).Truncate(time.Second).Add(time.Second)
+	clk.Advance(boundary.Sub(clk.Now()) - 50*time.Millisecond)
+
+	rotated := make(chan RotationEvent, 1)
+	logger := newTestLogger(t, &LoggerConfig{
+		RotateAt: boundary.Format("15:04:05"),
+		OnRotate: func(ev RotationEvent) {
+			select {
+			case rotated <- ev:
+			default:
+			}
+		},
+	})
+	logger.setClock(clk)
+
+	if _, err := logger.Write([]byte("before boundary\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	logger.Pause()
+
+	// The boundary timer fires about 50ms in; the clock then moves past it
+	time.Sleep(100 * time.Millisecond)
+	clk.Advance(time.Second)
+	select {
+	case ev := <-rotated:
+		t.Fatalf("rotated while paused: %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	logger.Resume()
+	select {
+	case ev := <-rotated:
+		if ev.Reason != "calendar" {
+			t.Errorf("Reason = %q, want calendar", ev.Reason)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("boundary skipped while paused was never retried")
+	}
+}
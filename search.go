@@ -0,0 +1,169 @@
+// search.go: Pattern search across rotated backups
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Match is a line found by Search.
+type Match struct {
+	Backup string `json:"backup"` // Path of the backup holding the line
+	Line   int    `json:"line"`   // 1-based line number within the backup
+	Text   string `json:"text"`   // The line, without its trailing newline
+}
+
+// Search scans the rotated backups of Filename, oldest first, for lines
+// matching the regular expression pattern and returns up to limit matches.
+// Compressed and encrypted backups (with Encryptor set) are read through
+// OpenBackup, one line at a time, so memory use does not grow with backup
+// size. The active log file is not searched.
+//
+// A backup that cannot be read does not stop the search: Search returns
+// the matches from the remaining backups along with an error naming each
+// unreadable one.
+//
+// Returns an error if pattern does not compile or limit is not positive.
+//
+// Example:
+//
+//	matches, err := logger.Search(`request_id=4f2a`, 100)
+//	for _, m := range matches {
+//		fmt.Printf("%s:%d: %s\n", m.Backup, m.Line, m.Text)
+//	}
+func (l *Logger) Search(pattern string, limit int) ([]Match, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("lethe: Search: invalid pattern: %w", err)
+	}
+	if limit <= 0 {
+		return nil, errors.New("lethe: Search: limit must be > 0")
+	}
+
+	var matches []Match
+	var errs []error
+	for _, backup := range l.backupPaths() {
+		matches, err = searchBackup(backup, l.Encryptor, re, matches, limit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("search %s: %w", backup, err))
+		}
+		if len(matches) >= limit {
+			break
+		}
+	}
+	return matches, errors.Join(errs...)
+}
+
+// backupPaths lists the backups of Filename, oldest first, one path per
+// backup. While a worker compresses or encrypts a backup, two forms exist
+// for a moment; the least processed one is complete, so it is preferred.
+func (l *Logger) backupPaths() []string {
+	matches, err := filepath.Glob(l.Filename + ".*")
+	if err != nil {
+		return nil
+	}
+
+	prefix := l.Filename + "."
+	bases := make(map[string]string, len(matches)) // base name -> stamp[.N]
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, prefix)
+		if !isBackupSuffix(suffix) {
+			continue
+		}
+		suffix = trimCompressedSuffix(strings.TrimSuffix(suffix, encryptedSuffix))
+		bases[prefix+suffix] = suffix
+	}
+
+	paths := make([]string, 0, len(bases))
+	for base := range bases {
+		for _, form := range backupForms(base) {
+			if _, err := os.Stat(form); err == nil {
+				paths = append(paths, form)
+				break
+			}
+		}
+	}
+
+	// Timestamps sort lexically; collision counters (".2", ".10") do not
+	sort.Slice(paths, func(i, j int) bool {
+		si, ci := splitBackupCounter(bases[backupBase(paths[i], prefix)])
+		sj, cj := splitBackupCounter(bases[backupBase(paths[j], prefix)])
+		if si != sj {
+			return si < sj
+		}
+		return ci < cj
+	})
+	return paths
+}
+
+// backupBase strips the encryption and compression suffixes from a backup path.
+func backupBase(path, prefix string) string {
+	suffix := strings.TrimPrefix(path, prefix)
+	return prefix + trimCompressedSuffix(strings.TrimSuffix(suffix, encryptedSuffix))
+}
+
+// splitBackupCounter splits "stamp.N" into its timestamp and counter
+// (0 when the name has no collision counter).
+func splitBackupCounter(suffix string) (string, int) {
+	stamp, counter, ok := strings.Cut(suffix, ".")
+	if !ok {
+		return suffix, 0
+	}
+	n, _ := strconv.Atoi(counter) // Digits only, checked by isBackupSuffix
+	return stamp, n
+}
+
+// searchBackup appends the lines of one backup matching re to matches,
+// stopping at limit.
+func searchBackup(path string, enc Encryptor, re *regexp.Regexp, matches []Match, limit int) ([]Match, error) {
+	r, err := OpenBackup(path, enc)
+	if err != nil {
+		return matches, err
+	}
+	defer func() { _ = r.Close() }() // Read-only; close error is not actionable
+
+	br := bufio.NewReader(r)
+	var long []byte // Accumulates lines longer than the reader's buffer
+	for lineNo := 1; len(matches) < limit; {
+		chunk, err := br.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			long = append(long, chunk...)
+			continue
+		}
+
+		line := chunk
+		if long != nil {
+			long = append(long, chunk...)
+			line = long
+		}
+		if len(line) > 0 {
+			line = bytes.TrimSuffix(line, newline)
+			if re.Match(line) {
+				matches = append(matches, Match{Backup: path, Line: lineNo, Text: string(line)})
+			}
+			lineNo++
+		}
+		long = nil
+		if err != nil {
+			if err == io.EOF {
+				return matches, nil
+			}
+			return matches, err
+		}
+	}
+	return matches, nil
+}
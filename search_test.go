@@ -0,0 +1,155 @@
+// search_test.go: Tests for Logger.Search
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeBackup creates a backup of logFile with the given suffix and
+// content, gzip-compressed when the suffix ends in ".gz".
+func writeBackup(t *testing.T, logFile, suffix, content string) string {
+	t.Helper()
+	path := logFile + "." + suffix
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if strings.HasSuffix(suffix, ".gz") {
+		gz := gzip.NewWriter(f)
+		if _, err := gz.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSearch_AcrossPlainAndCompressedBackups(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	newest := writeBackup(t, logFile, "2025-01-03-00-00-00.10", "ERROR newest\n")
+	oldest := writeBackup(t, logFile, "2025-01-01-00-00-00.gz", "INFO boot\nERROR disk full\nINFO ok\nERROR retry\n")
+	middle := writeBackup(t, logFile, "2025-01-03-00-00-00.2", "INFO quiet\nERROR middle")
+	writeBackup(t, logFile, "2025-01-03-00-00-00.2.sha256", "ERROR in a sidecar\n")
+	if err := os.WriteFile(logFile, []byte("ERROR in the active file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &Logger{Filename: logFile}
+	matches, err := l.Search(`^ERROR`, 100)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	want := []Match{
+		{Backup: oldest, Line: 2, Text: "ERROR disk full"},
+		{Backup: oldest, Line: 4, Text: "ERROR retry"},
+		{Backup: middle, Line: 2, Text: "ERROR middle"},
+		{Backup: newest, Line: 1, Text: "ERROR newest"},
+	}
+	if len(matches) != len(want) {
+		t.Fatalf("got %d matches %+v, want %d", len(matches), matches, len(want))
+	}
+	for i := range want {
+		if matches[i] != want[i] {
+			t.Errorf("match %d: got %+v, want %+v", i, matches[i], want[i])
+		}
+	}
+}
+
+func TestSearch_Limit(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	writeBackup(t, logFile, "2025-01-01-00-00-00", strings.Repeat("hit\n", 50))
+	writeBackup(t, logFile, "2025-01-02-00-00-00.gz", strings.Repeat("hit\n", 50))
+
+	matches, err := (&Logger{Filename: logFile}).Search("hit", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 7 || matches[6].Line != 7 {
+		t.Errorf("got %d matches (last %+v), want the first 7", len(matches), matches[len(matches)-1])
+	}
+}
+
+func TestSearch_LongLines(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	long := strings.Repeat("x", 64<<10) + " needle"
+	writeBackup(t, logFile, "2025-01-01-00-00-00", "short\n"+long+"\nneedle again\n")
+
+	matches, err := (&Logger{Filename: logFile}).Search("needle", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Text != long || matches[0].Line != 2 || matches[1].Line != 3 {
+		t.Errorf("long line not matched as one line: %d matches", len(matches))
+	}
+}
+
+func TestSearch_EncryptedBackups(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	enc := testEncryptor(t, 0x42)
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressMinSize: -1,
+		Encryptor:       enc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = l.Close() }()
+	if _, err := l.Write([]byte("audit: secret event\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RotateSync(); err != nil {
+		t.Fatal(err)
+	}
+	plain := writeBackup(t, logFile, "2000-01-01-00-00-00", "audit: plain event\n")
+
+	matches, err := l.Search("audit", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Backup != plain || !strings.HasSuffix(matches[1].Backup, ".gz.enc") {
+		t.Fatalf("matches = %+v", matches)
+	}
+
+	// Without the key the encrypted backup is reported, the rest still searched
+	matches, err = (&Logger{Filename: logFile}).Search("audit", 10)
+	if err == nil || !strings.Contains(err.Error(), ".gz.enc") {
+		t.Errorf("err = %v, want one naming the encrypted backup", err)
+	}
+	if len(matches) != 1 || matches[0].Text != "audit: plain event" {
+		t.Errorf("matches = %+v, want the plain backup's line", matches)
+	}
+}
+
+func TestSearch_InvalidArguments(t *testing.T) {
+	l := &Logger{Filename: filepath.Join(t.TempDir(), "app.log")}
+	if _, err := l.Search("(unclosed", 10); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+	if _, err := l.Search("ok", 0); err == nil {
+		t.Error("limit 0 accepted")
+	}
+	matches, err := l.Search("ok", 10)
+	if err != nil || len(matches) != 0 {
+		t.Errorf("no backups: got %v, %v", matches, err)
+	}
+}
@@ -0,0 +1,104 @@
+// shortwrite_test.go: Tests for short-write handling
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// shortWriter accepts at most max bytes per Write, with a nil error, and
+// fails with err once limit bytes were written (limit 0 = unlimited).
+type shortWriter struct {
+	buf   bytes.Buffer
+	max   int
+	limit int
+	err   error
+	calls int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	w.calls++
+	if w.limit > 0 && w.buf.Len() >= w.limit {
+		return 0, w.err
+	}
+	n := min(len(p), w.max)
+	w.buf.Write(p[:n])
+	return n, nil
+}
+
+func TestWriteFull_RetriesShortWrites(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 10))
+	w := &shortWriter{max: 7}
+
+	n, err := writeFull(w, data)
+	if err != nil {
+		t.Fatalf("writeFull: %v", err)
+	}
+	if n != len(data) || !bytes.Equal(w.buf.Bytes(), data) {
+		t.Errorf("wrote %d bytes %q, want all %d", n, w.buf.String(), len(data))
+	}
+	if w.calls != 15 { // ceil(100/7)
+		t.Errorf("Write calls: got %d, want 15", w.calls)
+	}
+}
+
+func TestWriteFull_ErrorAfterPartialWrite(t *testing.T) {
+	diskFull := errors.New("no space left on device")
+	w := &shortWriter{max: 4, limit: 8, err: diskFull}
+
+	n, err := writeFull(w, []byte("0123456789abcdef"))
+	if !errors.Is(err, diskFull) {
+		t.Fatalf("err: got %v, want %v", err, diskFull)
+	}
+	if n != 8 {
+		t.Errorf("n: got %d, want the 8 bytes written before the error", n)
+	}
+}
+
+func TestWriteFull_NoProgress(t *testing.T) {
+	n, err := writeFull(&shortWriter{max: 0}, []byte("stuck"))
+	if !errors.Is(err, io.ErrShortWrite) || n != 0 {
+		t.Errorf("got n=%d err=%v, want 0, io.ErrShortWrite", n, err)
+	}
+}
+
+// overreportingWriter claims to have written more than it was given.
+type overreportingWriter struct{}
+
+func (overreportingWriter) Write(p []byte) (int, error) { return len(p) + 10, nil }
+
+func TestWriteFull_ClampsBogusCounts(t *testing.T) {
+	n, err := writeFull(overreportingWriter{}, []byte("abc"))
+	if err != nil || n != 3 {
+		t.Errorf("got n=%d err=%v, want 3, nil", n, err)
+	}
+}
+
+func TestWriteSync_WholeLinesInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	l := &Logger{Filename: path, DisableAutoScale: true}
+
+	line := strings.Repeat("x", 64<<10) + "\n" // Large enough to span several pipe-sized writes
+	n, err := l.Write([]byte(line))
+	if err != nil || n != len(line) {
+		t.Fatalf("Write: n=%d err=%v", n, err)
+	}
+	if got := l.Stats().CurrentFileSize; got != uint64(len(line)) {
+		t.Errorf("CurrentFileSize: got %d, want %d", got, len(line))
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readLog(t, path); got != line {
+		t.Errorf("file holds %d bytes, want %d", len(got), len(line))
+	}
+}
@@ -0,0 +1,60 @@
+// shutdown.go: Drain report for Close (CloseStats)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "time"
+
+// CloseStats closes the Logger like Close and returns its final Stats,
+// including what happened to messages still buffered when Close began:
+// ShutdownPending of them were waiting in the MPSC buffer or spill file,
+// ShutdownFlushed were written while draining, ShutdownLost were not
+// (the drain failed to write them), and ShutdownDrainNs is how long
+// draining took. ShutdownLost == 0 confirms a graceful shutdown lost no
+// buffered data.
+//
+// Sync-mode writes are never buffered, so they always report zero. Like
+// Close, only the first call drains; later calls return the same report.
+//
+// Example:
+//
+//	stats, err := logger.CloseStats()
+//	if stats.ShutdownLost > 0 {
+//		log.Printf("lost %d log messages at shutdown", stats.ShutdownLost)
+//	}
+func (l *Logger) CloseStats() (Stats, error) {
+	err := l.Close()
+	return l.Stats(), err
+}
+
+// pendingMessages counts messages buffered for the MPSC consumer: in the
+// ring and in the spill file.
+func (l *Logger) pendingMessages() uint64 {
+	var n uint64
+	buffer := l.buffer.Load()
+	if consumer := l.consumer.Load(); consumer != nil {
+		buffer = consumer.buffer.Load() // Includes rings retired by adaptive resize
+	}
+	for ; buffer != nil; buffer = buffer.next.Load() {
+		n += buffer.queued()
+	}
+	if s := l.spill.Load(); s != nil {
+		n += uint64(max(s.pending.Load(), 0)) // #nosec G115 -- clamped to non-negative
+	}
+	return n
+}
+
+// recordShutdown stores the drain report read by Stats. pending and
+// consumed are pendingMessages and consumedCount as Close began.
+func (l *Logger) recordShutdown(start time.Time, pending, consumed uint64) {
+	// Writers racing Close may have enqueued after the snapshot, so the
+	// consumer can write more than was pending
+	flushed := min(l.consumedCount.Load()-consumed, pending)
+	l.shutdownPending.Store(pending)
+	l.shutdownFlushed.Store(flushed)
+	l.shutdownLost.Store(pending - flushed)
+	l.shutdownDrainNs.Store(uint64(time.Since(start).Nanoseconds())) // #nosec G115 -- durations are positive
+}
@@ -0,0 +1,80 @@
+// signal.go: Optional signal wiring for rotation and graceful close
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// InstallSignalHandlers starts a goroutine that rotates the log file when
+// the rotate signal arrives and closes the Logger, flushing buffered
+// writes, when flushClose arrives. Either may be nil to leave it
+// unhandled. The returned stop function deregisters the signals and waits
+// for the goroutine to exit; it is safe to call more than once.
+//
+// It is a convenience built on Rotate and Close; applications with their
+// own signal handling can call those directly. Receiving flushClose only
+// closes the Logger, then deregisters both signals: the process keeps
+// running, so the application's own shutdown still decides when to exit,
+// and a repeated signal gets its default behavior unless the application
+// handles it. Errors from Close are reported as "signal_close" via
+// ErrorCallback.
+//
+// On Windows, where SIGHUP and SIGTERM are not delivered the Unix way,
+// it installs nothing and returns a no-op stop function.
+//
+// Example:
+//
+//	stop := logger.InstallSignalHandlers(syscall.SIGHUP, syscall.SIGTERM)
+//	defer stop()
+func (l *Logger) InstallSignalHandlers(rotate, flushClose os.Signal) (stop func()) {
+	var signals []os.Signal
+	for _, sig := range []os.Signal{rotate, flushClose} {
+		if sig != nil {
+			signals = append(signals, sig)
+		}
+	}
+	if !signalHandlersSupported || len(signals) == 0 {
+		return func() {}
+	}
+
+	// Buffered so a signal arriving while we rotate is not missed
+	ch := make(chan os.Signal, 2)
+	signal.Notify(ch, signals...)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-ch:
+				// flushClose wins when both name the same signal
+				if flushClose != nil && sig == flushClose {
+					if err := l.Close(); err != nil {
+						l.reportError("signal_close", err)
+					}
+					return
+				}
+				if !l.closed.Load() {
+					_ = l.Rotate() // Failures are reported via ErrorCallback
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-exited
+	}
+}
@@ -0,0 +1,76 @@
+// signal_test.go: Tests for InstallSignalHandlers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build unix
+
+package lethe
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// waitFor polls cond for up to a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestSignalHandlers_RotateAndClose uses SIGUSR1/SIGUSR2 so the test
+// process keeps its default SIGHUP/SIGTERM behavior.
+func TestSignalHandlers_RotateAndClose(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, Async: true})
+	stop := logger.InstallSignalHandlers(syscall.SIGUSR1, syscall.SIGUSR2)
+	defer stop()
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Sync(); err != nil { // Rotate does not drain the ring
+		t.Fatalf("Sync: %v", err)
+	}
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "rotation", func() bool { return logger.Stats().RotationCount == 1 })
+
+	if _, err := logger.Write([]byte("buffered\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "close", func() bool { return logger.closed.Load() })
+	stop() // Waits for Close to finish
+
+	if got := readLog(t, logFile); got != "buffered\n" {
+		t.Errorf("active file = %q, want the write flushed by Close", got)
+	}
+}
+
+func TestSignalHandlers_StopDeregisters(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "app.log")})
+
+	stop := logger.InstallSignalHandlers(syscall.SIGUSR1, nil)
+	stop()
+	stop() // Idempotent
+
+	if logger.closed.Load() {
+		t.Error("Logger closed without flushClose")
+	}
+	if stop := logger.InstallSignalHandlers(nil, nil); stop == nil {
+		t.Error("nil stop function with no signals")
+	}
+}
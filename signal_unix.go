@@ -0,0 +1,12 @@
+// signal_unix.go: Signal handler support on non-Windows platforms
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package lethe
+
+// signalHandlersSupported enables InstallSignalHandlers.
+const signalHandlersSupported = true
@@ -0,0 +1,13 @@
+// signal_windows.go: InstallSignalHandlers is a no-op on Windows
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package lethe
+
+// signalHandlersSupported disables InstallSignalHandlers: Windows has no
+// SIGHUP, and console events arrive through a different mechanism.
+const signalHandlersSupported = false
@@ -0,0 +1,199 @@
+// spill.go: On-disk overflow queue for the "overflow" BackpressurePolicy
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// spillSuffix is appended to Filename for the overflow spill file.
+const spillSuffix = ".spill"
+
+// defaultMaxSpillBytes caps the spill file when MaxSpillBytes is unset.
+const defaultMaxSpillBytes = 64 << 20
+
+// spillHeaderSize is the length prefix of each spilled message.
+const spillHeaderSize = 4
+
+// spillQueue holds messages that did not fit in the ring buffer. Records
+// are length-prefixed so the consumer replays whole messages, and a size
+// rotation never splits one across two files.
+//
+// WHY a mutex: it is only touched on overflow, where the disk write it
+// guards costs far more than the lock.
+type spillQueue struct {
+	mu       sync.Mutex
+	file     *os.File // Opened on the first spill
+	readOff  int64
+	writeOff int64
+
+	pending atomic.Int64 // Messages spilled and not yet replayed
+	size    atomic.Int64 // Bytes in the spill file (Stats.SpillBytes)
+}
+
+// spillPath returns the path of the spill file.
+func (l *Logger) spillPath() string {
+	return l.Filename + spillSuffix
+}
+
+// maxSpillBytes returns the configured spill cap, or the default.
+func (l *Logger) maxSpillBytes() int64 {
+	if l.MaxSpillBytes > 0 {
+		return l.MaxSpillBytes
+	}
+	return defaultMaxSpillBytes
+}
+
+// spilling reports whether spilled messages are waiting to be replayed.
+// Writes then queue behind them instead of overtaking them via the ring.
+func (l *Logger) spilling() bool {
+	s := l.spill.Load()
+	return s != nil && s.pending.Load() > 0
+}
+
+// writeSpill appends data to the spill file and wakes the consumer. Beyond
+// MaxSpillBytes the message is dropped and counted like the "drop" policy;
+// if the spill file cannot be written, data falls back to a sync write.
+func (l *Logger) writeSpill(buffer *ringBuffer, data []byte) (int, error) {
+	s := l.spill.Load()
+	if s == nil {
+		l.spill.CompareAndSwap(nil, &spillQueue{})
+		s = l.spill.Load()
+	}
+
+	spilled, err := s.append(l, data)
+	if err != nil {
+		l.reportError("spill", err)
+		return l.writeSync(data)
+	}
+	if !spilled {
+		l.countDrop(dropOverflowCap, len(data))
+		return len(data), nil
+	}
+	l.spilledCount.Add(1)
+	buffer.signalDataAvailable()
+	return len(data), nil
+}
+
+// append writes one record. Returns false, without error, when the record
+// would take the file past MaxSpillBytes.
+func (s *spillQueue) append(l *Logger, data []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	end := s.writeOff + spillHeaderSize + int64(len(data))
+	if end > l.maxSpillBytes() {
+		return false, nil
+	}
+	if s.file == nil {
+		// Truncate: a spill file left by a crash holds messages whose
+		// order relative to the log can no longer be established
+		file, err := os.OpenFile(l.spillPath(), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600) // #nosec G304 -- derived from Filename, not user input
+		if err != nil {
+			return false, err
+		}
+		s.file = file
+	}
+
+	record := make([]byte, spillHeaderSize+len(data))
+	binary.LittleEndian.PutUint32(record, uint32(len(data))) // #nosec G115 -- bounded by MaxSpillBytes
+	copy(record[spillHeaderSize:], data)
+	if _, err := s.file.WriteAt(record, s.writeOff); err != nil {
+		return false, err
+	}
+
+	s.writeOff = end
+	s.size.Store(end)
+	s.pending.Add(1)
+	return true, nil
+}
+
+// next reads up to max spilled messages, oldest first. Once everything
+// has been read the file is truncated, so it only grows during a burst.
+func (s *spillQueue) next(max int) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batch [][]byte
+	var header [spillHeaderSize]byte
+	for len(batch) < max && s.readOff < s.writeOff {
+		if _, err := s.file.ReadAt(header[:], s.readOff); err != nil {
+			return batch, s.discard(err)
+		}
+		msg := make([]byte, binary.LittleEndian.Uint32(header[:]))
+		if _, err := s.file.ReadAt(msg, s.readOff+spillHeaderSize); err != nil {
+			return batch, s.discard(err)
+		}
+		s.readOff += spillHeaderSize + int64(len(msg))
+		batch = append(batch, msg)
+	}
+	s.pending.Add(-int64(len(batch)))
+
+	if s.readOff >= s.writeOff && s.writeOff > 0 {
+		s.readOff, s.writeOff = 0, 0
+		s.size.Store(0)
+		if err := s.file.Truncate(0); err != nil {
+			return batch, err
+		}
+	}
+	return batch, nil
+}
+
+// discard drops every unread record after a read failure, so one bad
+// record cannot wedge the consumer. The caller holds s.mu.
+func (s *spillQueue) discard(cause error) error {
+	lost := s.pending.Swap(0)
+	s.readOff, s.writeOff = 0, 0
+	s.size.Store(0)
+	_ = s.file.Truncate(0) // Best effort: offsets are reset either way
+	return fmt.Errorf("spill file unreadable, %d messages lost: %w", lost, cause)
+}
+
+// drainSpill writes spilled messages to the log file in batches. Called by
+// flushAll once the ring is empty.
+func (c *MPSCConsumer) drainSpill(batchSize int) int {
+	s := c.logger.spill.Load()
+	if s == nil {
+		return 0
+	}
+
+	items := 0
+	for s.pending.Load() > 0 {
+		batch, err := s.next(batchSize)
+		if len(batch) > 0 {
+			c.writeMessages(batch)
+			items += len(batch)
+		}
+		if err != nil {
+			c.logger.reportError("spill_read", err)
+			break
+		}
+	}
+	return items
+}
+
+// closeSpill removes the spill file at Close, after the consumer drained it.
+func (l *Logger) closeSpill() {
+	s := l.spill.Load()
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return
+	}
+	_ = s.file.Close() // Removed below; close error is not actionable
+	if err := os.Remove(l.spillPath()); err != nil && !os.IsNotExist(err) {
+		l.reportError("spill_remove", err)
+	}
+	s.file = nil
+}
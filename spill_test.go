@@ -0,0 +1,102 @@
+// spill_test.go: Tests for the "overflow" backpressure policy
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newSpillLogger returns an overflow-policy Logger with a tiny byte budget
+// whose consumer is stuck on tee until tee.release is closed.
+func newSpillLogger(t *testing.T, maxSpill int64) (*Logger, string, *blockingWriter) {
+	t.Helper()
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	logFile := filepath.Join(t.TempDir(), "spill.log")
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:           logFile,
+		Async:              true,
+		MaxBufferBytes:     64,
+		BackpressurePolicy: "overflow",
+		MaxSpillBytes:      maxSpill,
+		Tee:                tee,
+	})
+
+	if _, err := logger.Write([]byte("line 000\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-tee.entered // The consumer is now stuck
+	return logger, logFile, tee
+}
+
+// TestOverflow_SpillsAndReplaysInOrder verifies messages that do not fit
+// in the ring are spilled to disk and written, in order, once the
+// consumer catches up.
+func TestOverflow_SpillsAndReplaysInOrder(t *testing.T) {
+	logger, logFile, tee := newSpillLogger(t, 0)
+
+	var want strings.Builder
+	want.WriteString("line 000\n")
+	for i := 1; i <= 50; i++ {
+		line := fmt.Sprintf("line %03d\n", i)
+		want.WriteString(line)
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	stats := logger.Stats()
+	if stats.SpilledCount == 0 || stats.SpillBytes == 0 {
+		t.Fatalf("nothing spilled past a 64-byte buffer: %+v", stats)
+	}
+	if _, err := os.Stat(logFile + spillSuffix); err != nil {
+		t.Errorf("spill file missing while messages wait: %v", err)
+	}
+	if stats.DroppedOnFull != 0 {
+		t.Errorf("DroppedOnFull = %d, want 0 below MaxSpillBytes", stats.DroppedOnFull)
+	}
+
+	close(tee.release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != want.String() {
+		t.Errorf("log content out of order or incomplete:\n%s", got)
+	}
+	if _, err := os.Stat(logFile + spillSuffix); !os.IsNotExist(err) {
+		t.Errorf("spill file left after Close: %v", err)
+	}
+}
+
+// TestOverflow_DropsBeyondMaxSpillBytes verifies the spill file is capped.
+func TestOverflow_DropsBeyondMaxSpillBytes(t *testing.T) {
+	logger, _, tee := newSpillLogger(t, 64)
+	defer func() { _ = logger.Close() }()
+	defer close(tee.release)
+
+	for i := 0; i < 50; i++ {
+		if _, err := logger.Write([]byte("overflowing entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	stats := logger.Stats()
+	if stats.SpillBytes > 64 {
+		t.Errorf("SpillBytes = %d, want at most MaxSpillBytes (64)", stats.SpillBytes)
+	}
+	if stats.DroppedOnFull == 0 {
+		t.Error("DroppedOnFull = 0, want drops beyond MaxSpillBytes")
+	}
+	if stats.DroppedOverflowCap != stats.DroppedOnFull || stats.DroppedBufferFull != 0 {
+		t.Errorf("DroppedOverflowCap = %d, DroppedBufferFull = %d; want every drop attributed to the spill cap",
+			stats.DroppedOverflowCap, stats.DroppedBufferFull)
+	}
+}
@@ -0,0 +1,76 @@
+// stall.go: MPSC consumer stall detection (StallTimeout)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"time"
+)
+
+// stallCheck is the watchdog's view of the buffer at its previous check.
+type stallCheck struct {
+	head    uint64    // Consumer position
+	waiting time.Time // When messages were first seen buffered at head; zero if none
+}
+
+// startStallWatchdog checks the consumer every half StallTimeout, so a
+// stall is reported between one and one and a half timeouts after it began.
+func (c *MPSCConsumer) startStallWatchdog(timeout time.Duration) {
+	w := &backgroundLoop{stopCh: make(chan struct{})}
+	c.watchdog = w
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(max(timeout/2, time.Millisecond))
+		defer ticker.Stop()
+
+		var last stallCheck
+		for {
+			select {
+			case <-w.stopCh:
+				return
+			case now := <-ticker.C:
+				last = c.checkStall(last, now, timeout)
+			}
+		}
+	}()
+}
+
+// checkStall reports a stall when messages have been buffered without the
+// consumer taking any of them for at least timeout.
+//
+// WHY not the heartbeat alone: an idle consumer sleeps without beating, so
+// right after a write its heartbeat is old even though it is about to run.
+// The stall is measured from the later of the last heartbeat and the
+// check that first saw messages waiting at the current head.
+func (c *MPSCConsumer) checkStall(last stallCheck, now time.Time, timeout time.Duration) stallCheck {
+	buffer := c.ring()
+	head := buffer.head.Load()
+	pending := buffer.queued()
+	if pending == 0 || c.logger.paused.Load() {
+		c.stalled.Store(false) // Nothing to do: a new stall is reported again
+		return stallCheck{head: head}
+	}
+	if head != last.head || last.waiting.IsZero() {
+		c.stalled.Store(false) // Progress since the previous check
+		return stallCheck{head: head, waiting: now}
+	}
+
+	since := last.waiting
+	if beat := time.Unix(0, c.heartbeat.Load()); beat.After(since) {
+		since = beat
+	}
+	stalledFor := now.Sub(since)
+	if stalledFor >= timeout && c.stalled.CompareAndSwap(false, true) {
+		c.logger.reportError("consumer_stalled", fmt.Errorf(
+			"MPSC consumer made no progress for %v with %d messages buffered (check for a hung disk)",
+			stalledFor.Round(time.Millisecond), pending))
+	}
+	return last
+}
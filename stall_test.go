@@ -0,0 +1,107 @@
+// stall_test.go: Tests for MPSC consumer stall detection
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks every Write until release is closed, standing in
+// for a hung disk on the consumer's write path.
+type blockingWriter struct {
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.release
+	return len(p), nil
+}
+
+func TestStallTimeout_ReportsStuckConsumer(t *testing.T) {
+	tee := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	stalls := make(chan error, 4)
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:     filepath.Join(t.TempDir(), "stall.log"),
+		Async:        true,
+		Tee:          tee,
+		StallTimeout: 50 * time.Millisecond,
+		ErrorCallback: func(op string, err error) {
+			if op == "consumer_stalled" {
+				stalls <- err
+			}
+		},
+	})
+
+	if _, err := logger.Write([]byte("first\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	<-tee.entered // The consumer is now stuck
+	if _, err := logger.Write([]byte("stuck behind it\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	select {
+	case err := <-stalls:
+		if !strings.Contains(err.Error(), "no progress for") {
+			t.Errorf("stall report = %v, want the stall duration", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no consumer_stalled report for a stuck consumer")
+	}
+	if h := logger.Health(); h.Healthy || !h.ConsumerStalled {
+		t.Errorf("Health = %+v, want unhealthy and stalled", h)
+	}
+
+	// Reported once per stall, not on every check
+	time.Sleep(150 * time.Millisecond)
+	if n := len(stalls); n != 0 {
+		t.Errorf("stall reported %d more times, want once", n)
+	}
+
+	close(tee.release)
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.Health().ConsumerStalled && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if h := logger.Health(); h.ConsumerStalled {
+		t.Errorf("Health = %+v after the consumer recovered, want not stalled", h)
+	}
+}
+
+func TestStallTimeout_IdleConsumerIsNotStalled(t *testing.T) {
+	stalled := make(chan struct{}, 1)
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:     filepath.Join(t.TempDir(), "idle.log"),
+		Async:        true,
+		StallTimeout: 20 * time.Millisecond,
+		ErrorCallback: func(op string, err error) {
+			if op == "consumer_stalled" {
+				stalled <- struct{}{}
+			}
+		},
+	})
+
+	// Writes separated by idle periods longer than StallTimeout
+	for i := 0; i < 5; i++ {
+		if _, err := logger.Write([]byte("tick\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+	select {
+	case <-stalled:
+		t.Error("idle consumer reported as stalled")
+	default:
+	}
+}
@@ -0,0 +1,103 @@
+// state.go: Persisted rotation state across restarts (PersistState)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// stateSuffix is appended to Filename for the persisted state sidecar.
+const stateSuffix = ".state"
+
+// persistedState is the on-disk format of the state sidecar.
+type persistedState struct {
+	RotationSeq uint64    `json:"rotation_seq"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// statePath returns the sidecar path for the persisted rotation state.
+func (l *Logger) statePath() string {
+	return l.Filename + stateSuffix
+}
+
+// loadState restores rotationSeq from the sidecar during initFile.
+// A missing or corrupt sidecar falls back to the number of existing
+// backups, so numbering still never goes backwards.
+func (l *Logger) loadState() {
+	if !l.PersistState || l.rotationSeq.Load() != 0 {
+		return
+	}
+
+	data, err := os.ReadFile(l.statePath())
+	if err == nil {
+		var st persistedState
+		if jsonErr := json.Unmarshal(data, &st); jsonErr == nil {
+			l.rotationSeq.Store(st.RotationSeq)
+			return
+		}
+		l.reportError("state_corrupt", fmt.Errorf("ignoring corrupt state file %s", l.statePath()))
+	} else if !os.IsNotExist(err) {
+		l.reportError("state_read", fmt.Errorf("failed to read state file %s: %v", l.statePath(), err))
+	}
+
+	l.rotationSeq.Store(uint64(l.countExistingBackups())) // #nosec G115 -- count is never negative
+}
+
+// saveState atomically writes the current rotationSeq to the sidecar.
+// Uses temp-file-then-rename, so a crash leaves either the old or new state.
+func (l *Logger) saveState() {
+	if !l.PersistState {
+		return
+	}
+
+	data, err := json.Marshal(persistedState{
+		RotationSeq: l.rotationSeq.Load(),
+		UpdatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		l.reportError("state_write", err)
+		return
+	}
+
+	path := l.statePath()
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		l.reportError("state_write", fmt.Errorf("failed to write state file %s: %v", tmpPath, err))
+		return
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath) // Ignore remove error during cleanup
+		l.reportError("state_write", fmt.Errorf("failed to rename state file %s: %v", path, err))
+	}
+}
+
+// countExistingBackups counts rotated backups of Filename on disk,
+// treating "name", "name.gz" and "name.gz.enc" (or another codec's
+// extension) as one backup and ignoring sidecars.
+func (l *Logger) countExistingBackups() int {
+	matches, err := filepath.Glob(l.Filename + ".*")
+	if err != nil {
+		return 0
+	}
+
+	// A backup being compressed briefly exists as both name and name.gz
+	seen := make(map[string]struct{}, len(matches))
+	prefix := l.Filename + "."
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, prefix)
+		if isBackupSuffix(suffix) {
+			suffix = strings.TrimSuffix(suffix, encryptedSuffix)
+			seen[trimCompressedSuffix(suffix)] = struct{}{}
+		}
+	}
+	return len(seen)
+}
@@ -0,0 +1,130 @@
+// state_test.go: Tests for persisted rotation state (PersistState)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// rotateN writes and rotates n times, spacing rotations so backup
+// timestamps do not collide.
+func rotateN(t *testing.T, logger *Logger, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			time.Sleep(1100 * time.Millisecond)
+		}
+		if _, err := logger.Write([]byte("entry\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Rotate(); err != nil {
+			t.Fatalf("Rotate: %v", err)
+		}
+	}
+}
+
+// TestPersistState_SequenceContinuesAcrossRestart verifies that a reopened
+// logger continues numbering from the persisted sequence.
+func TestPersistState_SequenceContinuesAcrossRestart(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "app.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, PersistState: true})
+	rotateN(t, logger, 2)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(logFile + ".state"); err != nil {
+		t.Fatalf("state sidecar missing: %v", err)
+	}
+
+	var seq uint64
+	logger = newTestLogger(t, &LoggerConfig{
+		Filename:     logFile,
+		PersistState: true,
+		OnRotate:     func(ev RotationEvent) { seq = ev.Sequence },
+	})
+
+	// State is restored when the file is first opened
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := logger.Stats().RotationCount; got != 2 {
+		t.Errorf("RotationCount after restart = %d, want 2", got)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	rotateN(t, logger, 1)
+	if seq != 3 {
+		t.Errorf("Sequence after restart = %d, want 3", seq)
+	}
+}
+
+// TestPersistState_CorruptFallsBackToBackupCount verifies a corrupt sidecar
+// is reported and the sequence resumes from the existing backups.
+func TestPersistState_CorruptFallsBackToBackupCount(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "app.log")
+
+	backups := []string{
+		logFile + ".2020-01-01-00-00-00",
+		logFile + ".2020-01-02-00-00-00.gz",
+		logFile + ".2020-01-03-00-00-00",
+	}
+	for _, name := range backups {
+		if err := os.WriteFile(name, []byte("old"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(logFile+".state", []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var reported string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		PersistState:  true,
+		ErrorCallback: func(op string, err error) { reported = op },
+	})
+
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if reported != "state_corrupt" {
+		t.Errorf("reported operation = %q, want %q", reported, "state_corrupt")
+	}
+	if got := logger.Stats().RotationCount; got != uint64(len(backups)) {
+		t.Errorf("RotationCount = %d, want %d", got, len(backups))
+	}
+}
+
+// TestPersistState_DisabledWritesNoSidecar verifies the default leaves no
+// state file behind, and that MaxBackups cleanup never deletes the sidecar.
+func TestPersistState_DisabledWritesNoSidecar(t *testing.T) {
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "app.log")
+
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile})
+	rotateN(t, logger, 1)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(logFile + ".state"); !os.IsNotExist(err) {
+		t.Errorf("state sidecar written without PersistState (err=%v)", err)
+	}
+
+	logger = newTestLogger(t, &LoggerConfig{Filename: logFile, PersistState: true, MaxBackups: 1})
+	time.Sleep(1100 * time.Millisecond)
+	rotateN(t, logger, 1)
+	logger.WaitForBackgroundTasks()
+
+	if _, err := os.Stat(logFile + ".state"); err != nil {
+		t.Errorf("state sidecar removed by cleanup: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// statshandler.go: HTTP endpoint serving Stats as JSON
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// StatsHandler returns an http.Handler that serves l.Stats() as JSON, for
+// quick inspection with curl without wiring expvar or Prometheus. Each
+// request takes a fresh snapshot of the live counters, so concurrent
+// scrapes are safe and never block writers. Only GET and HEAD are allowed.
+//
+// The handler exposes operational details (file sizes, configuration), so
+// mount it on an internal or debug listener.
+//
+// Example:
+//
+//	http.Handle("/debug/lethe", logger.StatsHandler())
+func (l *Logger) StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := json.Marshal(l.Stats())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store") // Counters change on every write
+		_, _ = w.Write(append(body, '\n'))          // Client went away; nothing to do
+	})
+}
@@ -0,0 +1,91 @@
+// statshandler_test.go: Tests for StatsHandler
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStatsHandler_ServesLiveStats(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "handler.log"),
+		DisableAutoScale: true,
+	})
+	handler := logger.StatsHandler()
+
+	scrape := func() Stats {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/lethe", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var stats Stats
+		if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		return stats
+	}
+
+	if got := scrape().WriteCount; got != 0 {
+		t.Errorf("WriteCount before writes = %d, want 0", got)
+	}
+	if _, err := logger.Write([]byte("entry\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := scrape().WriteCount; got != 1 {
+		t.Errorf("WriteCount after one write = %d, want 1", got)
+	}
+}
+
+func TestStatsHandler_RejectsOtherMethods(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "handler.log")})
+
+	rec := httptest.NewRecorder()
+	logger.StatsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/lethe", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want 405", rec.Code)
+	}
+}
+
+// TestStatsHandler_ConcurrentScrapes runs scrapes alongside writes; run
+// with -race to check the snapshot only reads atomics.
+func TestStatsHandler_ConcurrentScrapes(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{Filename: filepath.Join(t.TempDir(), "handler.log")})
+	handler := logger.StatsHandler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, _ = logger.Write([]byte("concurrent entry\n"))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/lethe", nil))
+				if rec.Code != http.StatusOK {
+					t.Errorf("status = %d, want 200", rec.Code)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
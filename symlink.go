@@ -0,0 +1,83 @@
+// symlink.go: Stable symlink to the active log file
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// symlinkPath resolves the configured Symlink against the log directory.
+// Returns an empty string when no symlink is configured.
+func (l *Logger) symlinkPath() string {
+	if l.Symlink == "" {
+		return ""
+	}
+	if filepath.IsAbs(l.Symlink) {
+		return l.Symlink
+	}
+	return filepath.Join(filepath.Dir(l.Filename), l.Symlink)
+}
+
+// updateSymlink atomically (re)points the configured Symlink to the active file.
+//
+// WHY temp-then-rename: os.Symlink fails if the link already exists, and
+// remove+create leaves a window where tail -F sees no file. Creating the link
+// under a temporary name and renaming it over the old one is atomic on POSIX.
+func (l *Logger) updateSymlink() {
+	link := l.symlinkPath()
+	if link == "" {
+		return
+	}
+
+	if runtime.GOOS == "windows" {
+		l.reportError("symlink_unsupported", fmt.Errorf("symlink %q not created: symlinks are not supported on Windows", link))
+		return
+	}
+
+	// Never clobber a regular file or directory the user placed at the link path
+	if info, err := os.Lstat(link); err == nil && info.Mode()&os.ModeSymlink == 0 {
+		l.reportError("symlink", fmt.Errorf("refusing to replace non-symlink %q", link))
+		return
+	}
+
+	// Prefer a relative target when link and log live in the same directory,
+	// so the pair can be moved or mounted elsewhere without breaking.
+	target := l.Filename
+	if filepath.Dir(link) == filepath.Dir(l.Filename) {
+		target = filepath.Base(l.Filename)
+	} else if abs, err := filepath.Abs(l.Filename); err == nil {
+		target = abs
+	}
+
+	tmpLink := link + ".tmp"
+	_ = os.Remove(tmpLink) // Remove stale temp link from an interrupted update
+	if err := os.Symlink(target, tmpLink); err != nil {
+		l.reportError("symlink", fmt.Errorf("failed to create symlink %q: %v", link, err))
+		return
+	}
+	if err := os.Rename(tmpLink, link); err != nil {
+		_ = os.Remove(tmpLink) // Ignore remove error during cleanup
+		l.reportError("symlink", fmt.Errorf("failed to install symlink %q: %v", link, err))
+		return
+	}
+	l.symlinkOwned.Store(true)
+}
+
+// removeSymlink deletes the Symlink on Close, but only if Lethe created it.
+func (l *Logger) removeSymlink() {
+	if !l.symlinkOwned.Load() {
+		return
+	}
+	link := l.symlinkPath()
+	if err := os.Remove(link); err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.reportError("symlink", fmt.Errorf("failed to remove symlink %q: %v", link, err))
+	}
+}
@@ -0,0 +1,130 @@
+// symlink_test.go: Tests for the stable active-file symlink
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestSymlink_PointsToActiveFileAcrossRotation verifies the link is created on
+// first write and still resolves to the active file after rotation.
+func TestSymlink_PointsToActiveFileAcrossRotation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks are not supported on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	logFile := filepath.Join(tmpDir, "app.log")
+	linkPath := filepath.Join(tmpDir, "current.log")
+
+	var errs []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Symlink:  "current.log",
+		ErrorCallback: func(op string, err error) {
+			errs = append(errs, op+": "+err.Error())
+		},
+	})
+
+	if _, err := logger.Write([]byte("before rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	target, err := os.Readlink(linkPath)
+	if err != nil {
+		t.Fatalf("Readlink: %v", err)
+	}
+	if target != "app.log" {
+		t.Errorf("symlink target = %q, want %q", target, "app.log")
+	}
+
+	if err := logger.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if _, err := logger.Write([]byte("after rotation\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	content, err := os.ReadFile(linkPath)
+	if err != nil {
+		t.Fatalf("ReadFile via symlink: %v", err)
+	}
+	if string(content) != "after rotation\n" {
+		t.Errorf("content via symlink = %q, want only post-rotation data", content)
+	}
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+// TestSymlink_RemovedOnClose verifies Close removes a link Lethe created.
+func TestSymlink_RemovedOnClose(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks are not supported on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	linkPath := filepath.Join(tmpDir, "current.log")
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: filepath.Join(tmpDir, "app.log"),
+		Symlink:  linkPath,
+	})
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := os.Lstat(linkPath); err != nil {
+		t.Fatalf("symlink not created: %v", err)
+	}
+
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Lstat(linkPath); !os.IsNotExist(err) {
+		t.Errorf("symlink still present after Close (err=%v)", err)
+	}
+}
+
+// TestSymlink_DoesNotClobberRegularFile verifies a pre-existing regular file at
+// the link path is left untouched and reported, and not removed on Close.
+func TestSymlink_DoesNotClobberRegularFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	linkPath := filepath.Join(tmpDir, "current.log")
+	if err := os.WriteFile(linkPath, []byte("user data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: filepath.Join(tmpDir, "app.log"),
+		Symlink:  "current.log",
+		ErrorCallback: func(op string, err error) {
+			reported = append(reported, op)
+		},
+	})
+	if _, err := logger.Write([]byte("data\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	content, err := os.ReadFile(linkPath)
+	if err != nil {
+		t.Fatalf("regular file removed: %v", err)
+	}
+	if string(content) != "user data" {
+		t.Errorf("regular file modified: %q", content)
+	}
+	if len(reported) == 0 || !strings.HasPrefix(reported[0], "symlink") {
+		t.Errorf("expected a symlink error to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,78 @@
+// tee.go: Mirroring written bytes to secondary io.Writers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"io"
+)
+
+// AddTee registers an additional writer that receives a copy of every write,
+// alongside Tee. Safe to call concurrently with writes; the writer only sees
+// bytes flushed after it was added. See Logger.Tee for ordering guarantees.
+func (l *Logger) AddTee(w io.Writer) {
+	if w == nil {
+		return
+	}
+	for {
+		old := l.extraTees.Load()
+		var tees []io.Writer
+		if old != nil {
+			tees = make([]io.Writer, 0, len(*old)+1)
+			tees = append(tees, *old...)
+		}
+		tees = append(tees, w)
+		if l.extraTees.CompareAndSwap(old, &tees) {
+			return
+		}
+	}
+}
+
+// tee forwards bytes that reached the log file to the tee writers.
+// WHY: called after the file write so tees never see data the file
+// rejected, and errors are only reported so a broken mirror (e.g., a
+// closed network sink) never fails the primary write.
+//
+// Tees run synchronously, under teeMu, on the writing goroutine (sync
+// mode) or the MPSC consumer (async mode): a slow or hung tee writer
+// slows or stalls all logging, including rotation.
+func (l *Logger) tee(p []byte) {
+	extra := l.extraTees.Load()
+	if (l.Tee == nil && extra == nil) || len(p) == 0 {
+		return
+	}
+
+	l.teeMu.Lock()
+	defer l.teeMu.Unlock()
+
+	if l.Tee != nil {
+		l.teeWrite(l.Tee, p)
+	}
+	if extra != nil {
+		for _, w := range *extra {
+			l.teeWrite(w, p)
+		}
+	}
+}
+
+// teeWrite writes p to w, reporting errors and short writes as "tee_write"
+// and panics as "tee_panic". WHY recover: in async mode w runs on the
+// consumer goroutine, where a panic would drop the rest of the batch.
+func (l *Logger) teeWrite(w io.Writer, p []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.reportError("tee_panic", fmt.Errorf("tee %T panicked: %v", w, r))
+		}
+	}()
+	n, err := w.Write(p)
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		l.reportError("tee_write", fmt.Errorf("tee %T: %w", w, err))
+	}
+}
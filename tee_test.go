@@ -0,0 +1,152 @@
+// tee_test.go: Tests for Tee and AddTee mirroring
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("sink down") }
+
+func TestTee_SyncMirrorsFileContent(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var mirror bytes.Buffer
+
+	logger, err := NewBuilder(logFile).Tee(&mirror).DisableAutoScale(true).Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := fmt.Fprintf(logger, "line %d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(mirror.Bytes(), onDisk) {
+		t.Errorf("tee = %q, file = %q", mirror.Bytes(), onDisk)
+	}
+}
+
+func TestTee_AsyncMultipleTeesFollowFileOrder(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var first, second bytes.Buffer
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Async:    true,
+		Tee:      &first,
+	})
+	logger.AddTee(&second)
+
+	for i := 0; i < 500; i++ {
+		if _, err := fmt.Fprintf(logger, "message %d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(first.Bytes(), onDisk) {
+		t.Error("Tee content differs from file content")
+	}
+	if !bytes.Equal(second.Bytes(), onDisk) {
+		t.Error("AddTee content differs from file content")
+	}
+}
+
+func TestTee_ErrorsAreReportedNotReturned(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var teeErrors atomic.Int32
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Tee:      failingWriter{},
+		ErrorCallback: func(op string, err error) {
+			if op == "tee_write" {
+				teeErrors.Add(1)
+			}
+		},
+	})
+	var healthy bytes.Buffer
+	logger.AddTee(&healthy)
+
+	if n, err := logger.Write([]byte("still written\n")); err != nil || n != 14 {
+		t.Fatalf("Write = (%d, %v), want (14, nil)", n, err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if teeErrors.Load() != 1 {
+		t.Errorf("tee_write errors = %d, want 1", teeErrors.Load())
+	}
+	if healthy.String() != "still written\n" {
+		t.Errorf("healthy tee = %q, a failing tee must not starve the others", healthy.String())
+	}
+}
+
+type panickingWriter struct{}
+
+func (panickingWriter) Write([]byte) (int, error) { panic("sink bug") }
+
+func TestTee_PanicIsRecovered(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var panics, consumerPanics atomic.Int32
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Async:    true,
+		Tee:      panickingWriter{},
+		ErrorCallback: func(op string, err error) {
+			switch op {
+			case "tee_panic":
+				panics.Add(1)
+			case "consumer_panic":
+				consumerPanics.Add(1)
+			}
+		},
+	})
+	for i := 0; i < 3; i++ {
+		if _, err := fmt.Fprintf(logger, "line %d\n", i); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := logger.Sync(); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got := readLog(t, logFile); got != "line 0\nline 1\nline 2\n" {
+		t.Errorf("log = %q, want every line", got)
+	}
+	if panics.Load() == 0 || consumerPanics.Load() != 0 {
+		t.Errorf("tee_panic = %d, consumer_panic = %d; want the tee's panic handled by teeWrite",
+			panics.Load(), consumerPanics.Load())
+	}
+}
@@ -0,0 +1,104 @@
+// tempdir_test.go: Tests for the compression TempDir option
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// compressViaTempDir rotates content once with compression staged in a
+// separate scratch directory and returns the compressed backup.
+func compressViaTempDir(t *testing.T, fs *faultFS, content []byte) string {
+	t.Helper()
+	logDir, scratch := t.TempDir(), t.TempDir()
+	logFile := filepath.Join(logDir, "app.log")
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:        logFile,
+		Compress:        true,
+		CompressMinSize: -1,
+		TempDir:         scratch,
+		BackupFileMode:  0640,
+	})
+	logger.fs = fs
+
+	if _, err := logger.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := logger.RotateSync(); err != nil {
+		t.Fatalf("RotateSync: %v", err)
+	}
+
+	gz, _ := filepath.Glob(logFile + ".*.gz")
+	if len(gz) != 1 {
+		t.Fatalf("compressed backups = %v, want one", gz)
+	}
+	for _, name := range listDir(t, logDir) {
+		if strings.HasSuffix(name, ".tmp") {
+			t.Errorf("temp file %s written next to the backup", name)
+		}
+	}
+	if left := listDir(t, scratch); len(left) != 0 {
+		t.Errorf("TempDir not empty after compression: %v", left)
+	}
+	return gz[0]
+}
+
+func assertBackupContent(t *testing.T, backup string, want []byte) {
+	t.Helper()
+	r, err := OpenBackup(backup, nil)
+	if err != nil {
+		t.Fatalf("OpenBackup: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("backup content = %q, want %q", got, want)
+	}
+}
+
+func TestTempDir_CompressesThroughScratch(t *testing.T) {
+	content := []byte("compressed via scratch\n")
+	fs := &faultFS{}
+	backup := compressViaTempDir(t, fs, content)
+	assertBackupContent(t, backup, content)
+
+	info, err := os.Stat(backup)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("backup mode = %v, want BackupFileMode 0640", info.Mode().Perm())
+	}
+
+	// The finished file was moved in from TempDir
+	staged := false
+	for _, call := range fs.calls {
+		if strings.HasPrefix(call, "rename "+filepath.Base(backup)+".") && strings.HasSuffix(call, ".tmp") {
+			staged = true
+		}
+	}
+	if !staged {
+		t.Errorf("no rename from TempDir recorded: %v", fs.calls)
+	}
+}
+
+func TestTempDir_CrossDeviceMoveCopies(t *testing.T) {
+	// Every rename fails like rename(2) across mounts
+	content := []byte("copied across devices\n")
+	backup := compressViaTempDir(t, &faultFS{failRename: syscall.EXDEV}, content)
+	assertBackupContent(t, backup, content)
+}
@@ -0,0 +1,247 @@
+// tier.go: Age-based re-compression of backups (CompressionTiers)
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CompressionTier moves backups older than AfterAge to the Compression
+// codec, e.g. {AfterAge: 30 * 24 * time.Hour, Compression: "zstd"} for
+// cold storage. See Logger.CompressionTiers.
+type CompressionTier struct {
+	AfterAge    time.Duration `json:"after_age"`
+	Compression string        `json:"compression"` // A registered Compressor name
+}
+
+// tierFor returns the tier a backup of the given age belongs to: the one
+// with the largest AfterAge not above age.
+func (l *Logger) tierFor(age time.Duration) (CompressionTier, bool) {
+	var best CompressionTier
+	found := false
+	for _, tier := range l.CompressionTiers {
+		if tier.AfterAge <= age && (!found || tier.AfterAge > best.AfterAge) {
+			best, found = tier, true
+		}
+	}
+	return best, found
+}
+
+// retierBackups re-compresses every backup whose codec differs from its
+// tier's. Runs as the "tier" background task after each rotation; every
+// failure is reported, the first one is returned.
+func (l *Logger) retierBackups() error {
+	if len(l.CompressionTiers) == 0 {
+		return nil
+	}
+	// WHY TryLock: two rotations in a row queue two passes over the same
+	// backups; the second has nothing to add and must not race the first.
+	if !l.tierMu.TryLock() {
+		return nil
+	}
+	defer l.tierMu.Unlock()
+
+	matches, err := filepath.Glob(l.Filename + ".*")
+	if err != nil {
+		return nil
+	}
+
+	var firstErr error
+	now := l.now()
+	for _, path := range matches {
+		// Encrypted backups cannot be re-compressed without their key
+		if !isBackupSuffix(strings.TrimPrefix(path, l.Filename+".")) || strings.HasSuffix(path, encryptedSuffix) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // Removed by retention meanwhile
+		}
+		tier, ok := l.tierFor(now.Sub(info.ModTime()))
+		if !ok {
+			continue
+		}
+		target, ok := lookupCompressor(tier.Compression)
+		if !ok {
+			continue // Rejected by ValidateConfig
+		}
+
+		current, compressed := compressorForPath(path)
+		if compressed && current.Name() == target.Name() {
+			continue
+		}
+		// With Compress on, a plain backup is either being compressed by
+		// its rotation's task or was kept plain by CompressMinSize
+		if !compressed && l.effectiveRetention().Compress {
+			continue
+		}
+
+		if err := l.recompressBackup(path, current, target, info); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// recompressBackup rewrites the backup at path with the target codec.
+// from is the current codec, or nil for a plain backup.
+//
+// Crash consistency: the new form is written to a temp file, synced and
+// renamed into place before the old form is removed. A crash in between
+// leaves both forms; the next pass finds the new one complete (the
+// rename is atomic) and only removes the old one.
+func (l *Logger) recompressBackup(path string, from, target Compressor, info os.FileInfo) error {
+	base := path
+	if from != nil {
+		base = strings.TrimSuffix(path, from.Extension())
+	}
+	newPath := base + target.Extension()
+	oldSidecar := path + ".sha256"
+
+	if _, err := os.Stat(newPath); err == nil {
+		// Leftover of an interrupted pass
+		return l.finishRecompress(path, newPath, base, oldSidecar)
+	}
+
+	// The old sidecar, if any, is checked while reading, so a corrupt
+	// backup is not re-encoded into one that looks valid
+	wantSum, err := readChecksumSidecar(oldSidecar)
+	if err != nil {
+		return l.recompressFailed("checksum", path, err)
+	}
+
+	defer l.acquireCompressSlot()()
+
+	src, err := os.Open(path) // #nosec G304 -- internal backup path, not user input
+	if err != nil {
+		return l.recompressFailed("open", path, err)
+	}
+	defer func() { _ = src.Close() }() // Read-only; close error is not actionable
+
+	hash := sha256.New()
+	var reader io.Reader = io.TeeReader(src, hash)
+	if from != nil {
+		cr, err := from.NewReader(reader)
+		if err != nil {
+			return l.recompressFailed("reader", path, err)
+		}
+		defer func() { _ = cr.Close() }() // Read-only; close error is not actionable
+		reader = cr
+	}
+
+	tmp, tempName, err := l.createCompressTemp(newPath)
+	if err != nil {
+		return l.recompressFailed("create", path, err)
+	}
+	if err := l.writeRecompressed(tmp, reader, src, target); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.recompressFailed("copy", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.recompressFailed("close", path, err)
+	}
+	if wantSum != nil && !bytes.Equal(hash.Sum(nil), wantSum) {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.recompressFailed("checksum", path, fmt.Errorf("checksum mismatch for %s", path))
+	}
+
+	// Keep the backup's age: retention and tiers both go by mtime
+	if err := os.Chtimes(tempName, info.ModTime(), info.ModTime()); err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.recompressFailed("chtimes", path, err)
+	}
+	if l.TempDir != "" {
+		err = l.renameFile(tempName, newPath) // May cross devices
+	} else {
+		err = os.Rename(tempName, newPath)
+	}
+	if err != nil {
+		_ = os.Remove(tempName) // Ignore remove error during cleanup
+		return l.recompressFailed("rename", path, err)
+	}
+	return l.finishRecompress(path, newPath, base, oldSidecar)
+}
+
+// writeRecompressed encodes reader into tmp with target and syncs tmp.
+// src is drained after the stream so the old sidecar hash covers it all.
+func (l *Logger) writeRecompressed(tmp *os.File, reader io.Reader, src *os.File, target Compressor) error {
+	cw, err := target.NewWriter(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(cw, reader); err != nil {
+		_ = cw.Close()
+		return err
+	}
+	if err := cw.Close(); err != nil {
+		return err
+	}
+	// Trailing bytes past the end of the old stream are still hashed
+	if _, err := io.Copy(io.Discard, src); err != nil {
+		return err
+	}
+	return tmp.Sync()
+}
+
+// finishRecompress replaces the old form's checksum sidecar and manifest
+// entry with ones for newPath, then removes the old form.
+func (l *Logger) finishRecompress(path, newPath, base, oldSidecar string) error {
+	var firstErr error
+	_, statErr := os.Stat(oldSidecar)
+	hadSidecar := statErr == nil
+	if _, err := os.Stat(newPath + ".sha256"); os.IsNotExist(err) && (hadSidecar || l.effectiveRetention().Checksum) {
+		firstErr = l.generateChecksum(newPath)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return l.recompressFailed("cleanup", path, err)
+	}
+	_ = os.Remove(oldSidecar) // Best effort: most forms have no sidecar
+
+	if l.Manifest {
+		if err := l.pruneManifest([]string{path}); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if err := l.recordBackup(base); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// recompressFailed reports a re-compression failure as a CompressionError
+// under the operation "recompress_" + op.
+func (l *Logger) recompressFailed(op, path string, err error) error {
+	return l.taskFailed("recompress_"+op, &CompressionError{Op: op, Path: path, Err: err})
+}
+
+// validateCompressionTiers checks CompressionTiers for ValidateConfig.
+func validateCompressionTiers(tiers []CompressionTier) error {
+	seen := make(map[time.Duration]bool, len(tiers))
+	for i, tier := range tiers {
+		if tier.AfterAge <= 0 {
+			return fmt.Errorf("invalid CompressionTiers[%d]: AfterAge %v must be positive", i, tier.AfterAge)
+		}
+		if seen[tier.AfterAge] {
+			return fmt.Errorf("invalid CompressionTiers[%d]: duplicate AfterAge %v", i, tier.AfterAge)
+		}
+		seen[tier.AfterAge] = true
+		if _, ok := lookupCompressor(tier.Compression); !ok || tier.Compression == "" {
+			return fmt.Errorf("invalid CompressionTiers[%d]: no such compressor %q registered", i, tier.Compression)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// tier_test.go: Tests for CompressionTiers
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeAgedBackup writes content to path, gzipped when path ends in
+// ".gz", and backdates it by age.
+func writeAgedBackup(t *testing.T, path, content string, age time.Duration) time.Time {
+	t.Helper()
+	data := []byte(content)
+	if strings.HasSuffix(path, ".gz") {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(data)
+		_ = zw.Close()
+		data = buf.Bytes()
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	mtime := time.Now().Add(-age).Truncate(time.Second)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	return mtime
+}
+
+// rotateWithTiers rotates a Logger using the warm/cold tiers below and
+// waits for its background tasks.
+func rotateWithTiers(t *testing.T, logFile string, errs *[]string) {
+	t.Helper()
+	registerZlib(t)
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename: logFile,
+		Checksum: true,
+		CompressionTiers: []CompressionTier{
+			{AfterAge: time.Hour, Compression: "gzip"},
+			{AfterAge: 48 * time.Hour, Compression: "zlib"},
+		},
+		ErrorCallback: func(op string, err error) {
+			if errs != nil {
+				*errs = append(*errs, op)
+			}
+		},
+	})
+
+	if _, err := logger.Write([]byte("fresh\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	_ = logger.RotateSync() // Task errors are checked by the caller
+}
+
+// TestCompressionTiers_MovesBackupsBetweenCodecs verifies plain backups
+// past the warm threshold are gzipped and gzipped ones past the cold
+// threshold become zlib, with content, mtime and checksums carried over.
+func TestCompressionTiers_MovesBackupsBetweenCodecs(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	warm := logFile + ".2025-01-02-00-00-00"
+	cold := logFile + ".2025-01-01-00-00-00.gz"
+	warmTime := writeAgedBackup(t, warm, "warm entry\n", 2*time.Hour)
+	coldTime := writeAgedBackup(t, cold, "cold entry\n", 72*time.Hour)
+
+	rotateWithTiers(t, logFile, nil)
+
+	coldBase := strings.TrimSuffix(cold, ".gz")
+	for path, want := range map[string]struct {
+		content string
+		mtime   time.Time
+	}{
+		warm + ".gz":     {"warm entry\n", warmTime},
+		coldBase + ".zz": {"cold entry\n", coldTime},
+	} {
+		if got := string(readBackup(t, path)); got != want.content {
+			t.Errorf("%s content = %q, want %q", filepath.Base(path), got, want.content)
+		}
+		if info, err := os.Stat(path); err == nil && !info.ModTime().Equal(want.mtime) {
+			t.Errorf("%s mtime = %v, want %v preserved", filepath.Base(path), info.ModTime(), want.mtime)
+		}
+		if _, err := os.Stat(path + ".sha256"); err != nil {
+			t.Errorf("%s has no checksum sidecar: %v", filepath.Base(path), err)
+		}
+	}
+	for _, gone := range []string{warm, cold} {
+		if _, err := os.Stat(gone); !os.IsNotExist(err) {
+			t.Errorf("old form %s left behind (err=%v)", filepath.Base(gone), err)
+		}
+	}
+
+	// The backup just rotated is younger than every tier
+	hot, _ := filepath.Glob(logFile + ".2*")
+	plain := 0
+	for _, path := range hot {
+		if !strings.HasSuffix(path, ".gz") && !strings.HasSuffix(path, ".zz") && !strings.HasSuffix(path, ".sha256") {
+			plain++
+		}
+	}
+	if plain != 1 {
+		t.Errorf("plain backups = %d, want only the fresh one: %v", plain, hot)
+	}
+}
+
+// TestCompressionTiers_FinishesInterruptedPass verifies a crash after the
+// rename (both forms present) is completed by removing the old form.
+func TestCompressionTiers_FinishesInterruptedPass(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	base := logFile + ".2025-01-01-00-00-00"
+	writeAgedBackup(t, base+".gz", "cold entry\n", 72*time.Hour)
+	var buf bytes.Buffer
+	zw, _ := zlibCodec{}.NewWriter(&buf)
+	_, _ = zw.Write([]byte("cold entry\n"))
+	_ = zw.Close()
+	if err := os.WriteFile(base+".zz", buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rotateWithTiers(t, logFile, nil)
+
+	if _, err := os.Stat(base + ".gz"); !os.IsNotExist(err) {
+		t.Errorf("old form left behind (err=%v)", err)
+	}
+	if got := string(readBackup(t, base+".zz")); got != "cold entry\n" {
+		t.Errorf("content = %q, want %q", got, "cold entry\n")
+	}
+}
+
+// TestCompressionTiers_KeepsCorruptBackup verifies a backup failing its
+// checksum sidecar is reported and left untouched.
+func TestCompressionTiers_KeepsCorruptBackup(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	cold := logFile + ".2025-01-01-00-00-00.gz"
+	writeAgedBackup(t, cold, "cold entry\n", 72*time.Hour)
+	sidecar := strings.Repeat("0", 64) + "  " + filepath.Base(cold) + "\n"
+	if err := os.WriteFile(cold+".sha256", []byte(sidecar), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []string
+	rotateWithTiers(t, logFile, &errs)
+
+	if _, err := os.Stat(cold); err != nil {
+		t.Errorf("corrupt backup removed: %v", err)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(cold, ".gz") + ".zz"); !os.IsNotExist(err) {
+		t.Errorf("corrupt backup re-compressed (err=%v)", err)
+	}
+	if !strings.Contains(strings.Join(errs, ","), "recompress_checksum") {
+		t.Errorf("reported ops = %v, want recompress_checksum", errs)
+	}
+}
+
+func TestCompressionTiers_Validation(t *testing.T) {
+	for name, tiers := range map[string][]CompressionTier{
+		"zero age":      {{AfterAge: 0, Compression: "gzip"}},
+		"unknown codec": {{AfterAge: time.Hour, Compression: "brotli"}},
+		"empty codec":   {{AfterAge: time.Hour}},
+		"duplicate age": {{AfterAge: time.Hour, Compression: "gzip"}, {AfterAge: time.Hour, Compression: "gzip"}},
+	} {
+		if err := ValidateConfig(&LoggerConfig{Filename: "app.log", CompressionTiers: tiers}); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// timezone_test.go: Tests for the explicit TimeZone option
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeZone_InvalidLocationRejected(t *testing.T) {
+	_, err := NewWithConfig(&LoggerConfig{
+		Filename: filepath.Join(t.TempDir(), "tz.log"),
+		TimeZone: "Mars/Olympus_Mons",
+	})
+	if err == nil {
+		t.Fatal("expected construction error for invalid TimeZone")
+	}
+}
+
+func TestTimeZone_OverridesLocalTime(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:  filepath.Join(t.TempDir(), "tz.log"),
+		TimeZone:  "America/New_York",
+		LocalTime: true,
+	})
+
+	if got := logger.location(); got.String() != ny.String() {
+		t.Errorf("location() = %v, want %v", got, ny)
+	}
+
+	// The backup timestamp must be wall-clock time in New York
+	name := logger.generateBackupName()
+	stamp := strings.TrimPrefix(name, logger.Filename+".")
+	parsed, err := time.ParseInLocation("2006-01-02-15-04-05", stamp, ny)
+	if err != nil {
+		t.Fatalf("parse backup stamp %q: %v", stamp, err)
+	}
+	if d := time.Since(parsed); d < -2*time.Second || d > 2*time.Second {
+		t.Errorf("backup stamp %q is %v away from now in %v", stamp, d, ny)
+	}
+}
+
+func TestTimeZone_StructLiteralResolvesLazily(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	logger := &Logger{Filename: filepath.Join(t.TempDir(), "tz.log"), TimeZone: "Asia/Tokyo"}
+	defer func() { _ = logger.Close() }()
+
+	if got := logger.location(); got.String() != tokyo.String() {
+		t.Errorf("location() = %v, want %v", got, tokyo)
+	}
+}
+
+func TestTimeZone_InvalidStructLiteralFallsBack(t *testing.T) {
+	var reported string
+	logger := &Logger{
+		Filename: filepath.Join(t.TempDir(), "tz.log"),
+		TimeZone: "Not/AZone",
+		ErrorCallback: func(op string, err error) {
+			reported = op
+		},
+	}
+	defer func() { _ = logger.Close() }()
+
+	if got := logger.location(); got != time.UTC {
+		t.Errorf("location() = %v, want UTC fallback", got)
+	}
+	if reported != "timezone" {
+		t.Errorf("reported operation = %q, want %q", reported, "timezone")
+	}
+}
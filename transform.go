@@ -0,0 +1,43 @@
+// transform.go: Transform hook for redacting or rewriting writes
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import "fmt"
+
+// applyTransform runs Transform on data. A panic fails the write rather
+// than letting through data Transform was meant to scrub.
+func (l *Logger) applyTransform(data []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("transform panicked: %v", r)
+			l.reportError("transform_panic", err)
+		}
+	}()
+	return l.Transform(data), nil
+}
+
+// writeTransformed runs Transform on data and submits the result. A nil
+// result drops the write, counted in Stats.TransformDropped.
+//
+// WHY the caller's length: Transform may shorten or grow data, and
+// io.Writer callers (bufio.Writer, log.Logger) treat n < len(data) with a
+// nil error as a short write.
+func (l *Logger) writeTransformed(data []byte, dispatch func([]byte) (int, error)) (int, error) {
+	out, err := l.applyTransform(data)
+	if err != nil {
+		return 0, err
+	}
+	if out == nil {
+		l.transformDropped.Add(1)
+		return len(data), nil
+	}
+	n, err := l.submit(out, dispatch)
+	if err != nil {
+		return min(n, len(data)), err
+	}
+	return len(data), nil
+}
@@ -0,0 +1,97 @@
+// transform_test.go: Tests for the Transform hook
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var bearerToken = regexp.MustCompile(`Bearer [A-Za-z0-9._-]+`)
+
+// redactTokens masks bearer tokens and drops lines marked as debug-only.
+func redactTokens(data []byte) []byte {
+	if bytes.HasPrefix(data, []byte("DEBUG ")) {
+		return nil
+	}
+	if !bytes.Contains(data, []byte("Bearer ")) {
+		return data
+	}
+	return bearerToken.ReplaceAll(data, []byte("Bearer [REDACTED]"))
+}
+
+func TestTransform_SecretNeverReachesDisk(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		t.Run(fmt.Sprintf("async=%v", async), func(t *testing.T) {
+			logFile := filepath.Join(t.TempDir(), "app.log")
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:  logFile,
+				Async:     async,
+				Transform: redactTokens,
+			})
+
+			const secret = "eyJhbGciOiJIUzI1NiJ9.c2VjcmV0.sig"
+			line := "auth header: Bearer " + secret + "\n"
+			n, err := logger.Write([]byte(line))
+			if err != nil || n != len(line) {
+				t.Fatalf("Write = (%d, %v), want (%d, nil) despite the shorter output", n, err, len(line))
+			}
+			if _, err := logger.WriteString("DEBUG Bearer " + secret + "\n"); err != nil {
+				t.Fatalf("WriteString: %v", err)
+			}
+			if _, err := logger.WriteBatch([][]byte{[]byte("retry with Bearer " + secret + "\n")}); err != nil {
+				t.Fatalf("WriteBatch: %v", err)
+			}
+			// log.Logger reports a short write if n differs from the line length
+			std := log.New(logger, "", 0)
+			if err := std.Output(1, "token Bearer "+secret); err != nil {
+				t.Fatalf("log.Output: %v", err)
+			}
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			got := readLog(t, logFile)
+			if strings.Contains(got, secret) {
+				t.Fatalf("secret written to disk:\n%s", got)
+			}
+			want := "auth header: Bearer [REDACTED]\nretry with Bearer [REDACTED]\ntoken Bearer [REDACTED]\n"
+			if got != want {
+				t.Errorf("log content = %q, want %q", got, want)
+			}
+			if dropped := logger.Stats().TransformDropped; dropped != 1 {
+				t.Errorf("TransformDropped = %d, want 1", dropped)
+			}
+		})
+	}
+}
+
+func TestTransform_PanicFailsClosed(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var ops []string
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:      logFile,
+		Transform:     func([]byte) []byte { panic("bad pattern") },
+		ErrorCallback: func(op string, err error) { ops = append(ops, op) },
+	})
+
+	if _, err := logger.Write([]byte("password=hunter2\n")); err == nil {
+		t.Error("Write succeeded although Transform panicked")
+	}
+	if len(ops) != 1 || ops[0] != "transform_panic" {
+		t.Errorf("reported operations = %v, want [transform_panic]", ops)
+	}
+	if info, err := os.Stat(logFile); err == nil && info.Size() > 0 {
+		t.Errorf("unscrubbed data written: %d bytes", info.Size())
+	}
+}
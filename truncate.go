@@ -0,0 +1,69 @@
+// truncate.go: Truncate the active log file in place
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"fmt"
+	"os"
+)
+
+// Truncate empties the active log file without rotating: no backup is
+// created and retention is not run. It suits test harnesses and
+// single-file logs that are periodically reset; use Rotate to keep the
+// old contents instead.
+//
+// The size, line count and age used for rotation restart from zero, as
+// for a freshly opened file. Truncate takes the rotation flag, so it never
+// races a rotation, but writes from other goroutines are not stopped:
+// those concurrent with Truncate may land before it (and be discarded) or
+// after it (and be kept). Async writes still in the buffer count as
+// concurrent; call Sync first to discard them too. It does nothing when no
+// file has been opened yet.
+//
+// Returns ErrClosed after Close and ErrPaused while paused.
+func (l *Logger) Truncate() error {
+	if l.closed.Load() {
+		return ErrClosed
+	}
+	if !l.enterFS() {
+		return ErrPaused
+	}
+	defer l.exitFS()
+
+	l.claimRotation()
+	defer l.rotationFlag.Store(false)
+
+	file := l.currentFile.Load()
+	if file == nil {
+		return nil
+	}
+
+	// Flush and hold BufferedSync (or end the CompressActive member) so no
+	// buffered write lands at a stale offset after the truncation
+	release := l.holdSyncBuffer()
+	// WHY by name: on Windows the active handle is append-only (see
+	// openAppend) and cannot be truncated through; under the rotation
+	// flag file.Name() still names it. Appends continue at the new end
+	// since the file is opened with O_APPEND.
+	err := os.Truncate(file.Name(), 0)
+	if err == nil {
+		l.bytesWritten.Store(0)
+		l.lineCount.Store(0)
+		l.fileCreated.Store(l.now().Unix())
+		l.deferredRotation.Store(uint32(rotateNone))
+		l.preallocate(file) // Truncation released the reservation
+		l.activeSum.restart.Store(true)
+	}
+	release()
+
+	if err != nil {
+		err = fmt.Errorf("truncate %s: %w", file.Name(), err)
+		l.reportError("truncate", err)
+		return err
+	}
+	return nil
+}
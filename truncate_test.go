@@ -0,0 +1,92 @@
+// truncate_test.go: Tests for Truncate
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTruncate_EmptiesActiveFileWithoutBackup(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "truncate.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, MaxSizeStr: "1KB"})
+
+	if err := logger.Truncate(); err != nil {
+		t.Fatalf("Truncate before the first write: %v", err)
+	}
+	line := strings.Repeat("x", 99) + "\n"
+	for i := 0; i < 9; i++ { // 900 bytes, just under MaxSize
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if err := logger.Truncate(); err != nil {
+		t.Fatalf("Truncate: %v", err)
+	}
+	if got := readLog(t, logFile); got != "" {
+		t.Errorf("log after Truncate = %q, want empty", got)
+	}
+	if got := logger.Stats().CurrentFileSize; got != 0 {
+		t.Errorf("CurrentFileSize = %d, want 0", got)
+	}
+
+	// Without the reset these would push the file past MaxSize
+	for i := 0; i < 2; i++ {
+		if _, err := logger.Write([]byte(line)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if got := readLog(t, logFile); got != line+line {
+		t.Errorf("log = %q, want only the writes after Truncate", got)
+	}
+	if got := logger.Stats().RotationCount; got != 0 {
+		t.Errorf("RotationCount = %d, want 0", got)
+	}
+	if entries := listDir(t, dir); len(entries) != 1 {
+		t.Errorf("directory = %v, want only the active file", entries)
+	}
+}
+
+// TestTruncate_ConcurrentWrites truncates while writers run; run with
+// -race. Every line left in the file must be whole.
+func TestTruncate_ConcurrentWrites(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "truncate.log")
+	logger := newTestLogger(t, &LoggerConfig{Filename: logFile, MaxSizeStr: "100MB"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				_, _ = logger.Write([]byte("concurrent entry\n"))
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		if err := logger.Truncate(); err != nil {
+			t.Errorf("Truncate: %v", err)
+		}
+	}
+	wg.Wait()
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(readLog(t, logFile), "\n"), "\n") {
+		if line != "" && line != "concurrent entry" {
+			t.Fatalf("torn line after concurrent Truncate: %q", line)
+		}
+	}
+	if err := logger.Truncate(); err != ErrClosed {
+		t.Errorf("Truncate after Close = %v, want ErrClosed", err)
+	}
+}
@@ -0,0 +1,90 @@
+// validate_test.go: Tests for ValidateConfig pre-flight checks
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "sub", "app.log")
+
+	tests := []struct {
+		name    string
+		config  *LoggerConfig
+		wantErr bool
+	}{
+		{"nil config", nil, true},
+		{"empty filename", &LoggerConfig{}, true},
+		{"minimal", &LoggerConfig{Filename: file}, false},
+		{"full", &LoggerConfig{Filename: file, MaxSizeStr: "10MB", MaxAgeStr: "7d", BackpressurePolicy: "adaptive", BufferSize: 4096}, false},
+		{"bad size", &LoggerConfig{Filename: file, MaxSizeStr: "ten megs"}, true},
+		{"bad age", &LoggerConfig{Filename: file, MaxAgeStr: "a while"}, true},
+		{"age conflict", &LoggerConfig{Filename: file, MaxAge: time.Hour, MaxAgeStr: "1h"}, true},
+		{"unknown policy", &LoggerConfig{Filename: file, BackpressurePolicy: "droop"}, true},
+		{"unknown compression", &LoggerConfig{Filename: file, Compression: "lz77"}, true},
+		{"unknown pause policy", &LoggerConfig{Filename: file, PausePolicy: "queue"}, true},
+		{"huge buffer", &LoggerConfig{Filename: file, BufferSize: maxConfigBufferSize + 1}, true},
+		{"negative buffer", &LoggerConfig{Filename: file, BufferSize: -1}, true},
+		{"negative workers", &LoggerConfig{Filename: file, BackgroundWorkers: -1}, true},
+		{"sample rate above 1", &LoggerConfig{Filename: file, SampleRate: 1.5}, true},
+		{"negative write cap", &LoggerConfig{Filename: file, MaxWritesPerSecond: -1}, true},
+		{"negative dedup window", &LoggerConfig{Filename: file, DedupWindow: -time.Second}, true},
+		{"dir mode 0755", &LoggerConfig{Filename: file, DirMode: 0755}, false},
+		{"dir mode not writable", &LoggerConfig{Filename: file, DirMode: 0555}, true},
+		{"dir mode not searchable", &LoggerConfig{Filename: file, DirMode: 0644}, true},
+		{"dir mode type bits", &LoggerConfig{Filename: file, DirMode: os.ModeDir | 0755}, true},
+		{"bad rotate at", &LoggerConfig{Filename: file, RotateAt: "25:00"}, true},
+		{"preallocate with multi-process", &LoggerConfig{Filename: file, Preallocate: true, MultiProcess: true}, true},
+		{"bad time zone", &LoggerConfig{Filename: file, TimeZone: "Atlantis/Capital"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Pre-flight checks must not touch the filesystem
+	if _, err := os.Stat(filepath.Dir(file)); !os.IsNotExist(err) {
+		t.Errorf("ValidateConfig created %s (err=%v)", filepath.Dir(file), err)
+	}
+}
+
+func TestNewWithConfig_RejectsBadMaxSizeStr(t *testing.T) {
+	// Previously only surfaced via ErrorCallback at first write
+	_, err := NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(t.TempDir(), "app.log"),
+		MaxSizeStr: "lots",
+	})
+	if err == nil {
+		t.Fatal("expected construction error for invalid MaxSizeStr")
+	}
+
+	_, err = NewWithConfig(&LoggerConfig{
+		Filename:   filepath.Join(t.TempDir(), "app.log"),
+		MaxSizeStr: "banana",
+	})
+	if err == nil || !strings.Contains(err.Error(), "MaxSizeStr") {
+		t.Fatalf("NewWithConfig(MaxSizeStr: \"banana\") = %v, want a MaxSizeStr error", err)
+	}
+}
+
+func TestNewSimple_RejectsBadMaxSize(t *testing.T) {
+	logger, err := NewSimple(filepath.Join(t.TempDir(), "app.log"), "banana", 3)
+	if err == nil {
+		_ = logger.Close()
+		t.Fatal("expected construction error for invalid maxSize")
+	}
+}
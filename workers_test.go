@@ -0,0 +1,101 @@
+// workers_test.go: Tests for the background worker pool
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackgroundWorkers_Configurable(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured int
+		want       int
+	}{
+		{"default", 0, defaultBackgroundWorkers},
+		{"custom", 6, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := newTestLogger(t, &LoggerConfig{
+				Filename:          filepath.Join(t.TempDir(), "workers.log"),
+				MaxBackups:        2,
+				BackgroundWorkers: tt.configured,
+			})
+
+			if _, err := logger.Write([]byte("data\n")); err != nil {
+				t.Fatalf("Write: %v", err)
+			}
+			if err := logger.RotateSync(); err != nil {
+				t.Fatalf("RotateSync: %v", err)
+			}
+
+			workers := logger.bgWorkers.Load()
+			if workers == nil {
+				t.Fatal("background workers not started by rotation")
+			}
+			if workers.workers != tt.want {
+				t.Errorf("workers = %d, want %d", workers.workers, tt.want)
+			}
+			if depth := logger.Stats().TaskQueueDepth; depth != 0 {
+				t.Errorf("TaskQueueDepth = %d after RotateSync, want 0", depth)
+			}
+		})
+	}
+}
+
+func TestStats_TaskQueueDepth(t *testing.T) {
+	logger := &Logger{Filename: filepath.Join(t.TempDir(), "depth.log")}
+	defer func() { _ = logger.Close() }()
+
+	// A pool with no running workers leaves submitted tasks queued
+	workers := newBackgroundWorkers(0)
+	logger.bgWorkers.Store(workers)
+	for i := 0; i < 3; i++ {
+		logger.safeSubmitTask(BackgroundTask{TaskType: "cleanup", Logger: logger})
+	}
+
+	if depth := logger.Stats().TaskQueueDepth; depth != 3 {
+		t.Errorf("TaskQueueDepth = %d, want 3", depth)
+	}
+}
+
+func TestSafeSubmitTask_ReportsDroppedTasks(t *testing.T) {
+	var dropped []error
+	logger := &Logger{
+		Filename: filepath.Join(t.TempDir(), "dropped.log"),
+		ErrorCallback: func(operation string, err error) {
+			if operation == "task_dropped" {
+				dropped = append(dropped, err)
+			}
+		},
+	}
+	defer func() { _ = logger.Close() }()
+
+	// No running workers: the queue fills up and the next submit is dropped
+	workers := newBackgroundWorkers(0)
+	logger.bgWorkers.Store(workers)
+	for i := 0; i < backgroundTaskQueueSize; i++ {
+		logger.safeSubmitTask(BackgroundTask{TaskType: "cleanup", Logger: logger})
+	}
+	logger.safeSubmitTask(BackgroundTask{TaskType: "compress", FilePath: "dropped.log.1", Logger: logger})
+
+	if len(dropped) != 1 {
+		t.Fatalf("task_dropped reports = %d, want 1", len(dropped))
+	}
+	if msg := dropped[0].Error(); !strings.Contains(msg, "compress") || !strings.Contains(msg, "dropped.log.1") {
+		t.Errorf("report %q should name task type and file", msg)
+	}
+	if got := logger.Stats().DroppedTasks; got != 1 {
+		t.Errorf("Stats.DroppedTasks = %d, want 1", got)
+	}
+	if got := workers.activeTasks.Load(); got != backgroundTaskQueueSize {
+		t.Errorf("activeTasks = %d, want %d (dropped task must not be counted)", got, backgroundTaskQueueSize)
+	}
+}
@@ -0,0 +1,29 @@
+// writeerrors.go: Counting failed writes and fsyncs of the log file
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// reportWriteError counts a failed write, flush or fsync of the log file
+// in Stats.WriteErrors and reports it via ErrorCallback. err is passed on
+// unwrapped, so the errno stays reachable with errors.As (syscall.Errno)
+// or errors.Is (syscall.ENOSPC, syscall.EIO).
+//
+// Writes racing a rotation that closed their handle are reported but not
+// counted: they say nothing about the disk. Unlike isFileAlreadyClosedError
+// this does not match EBADF, which comes from the kernel, not from Go.
+func (l *Logger) reportWriteError(operation string, err error) {
+	if !errors.Is(err, os.ErrClosed) {
+		l.writeErrors.Add(1)
+		l.lastWriteErrorTime.Store(time.Now().UnixNano())
+	}
+	l.reportError(operation, err)
+}
@@ -0,0 +1,80 @@
+// writeerrors_test.go: Tests for Stats.WriteErrors
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestWriteErrors_CountedAndReportedWithErrno(t *testing.T) {
+	var ops []string
+	var reported []error
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "app.log"),
+		DisableAutoScale: true,
+		ErrorCallback: func(op string, err error) {
+			ops = append(ops, op)
+			reported = append(reported, err)
+		},
+	})
+	if _, err := logger.Write([]byte("healthy\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if stats := logger.Stats(); stats.WriteErrors != 0 || !stats.LastWriteErrorTime.IsZero() {
+		t.Fatalf("WriteErrors = %d, LastWriteErrorTime = %v before any failure", stats.WriteErrors, stats.LastWriteErrorTime)
+	}
+
+	// A read-only handle fails every write in the kernel, even as root
+	readOnly, err := os.Open(logger.Filename)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	_ = logger.currentFile.Swap(readOnly).Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := logger.Write([]byte("lost\n")); err == nil {
+			t.Fatal("Write through a read-only handle succeeded")
+		}
+	}
+
+	stats := logger.Stats()
+	if stats.WriteErrors != 3 {
+		t.Errorf("WriteErrors = %d, want 3", stats.WriteErrors)
+	}
+	if stats.LastWriteErrorTime.IsZero() {
+		t.Error("LastWriteErrorTime not set")
+	}
+	if len(ops) != 3 || ops[0] != "write" {
+		t.Fatalf("reported operations = %v, want 3 x write", ops)
+	}
+	var errno syscall.Errno
+	if !errors.As(reported[0], &errno) || errno == 0 {
+		t.Errorf("reported error %v carries no errno", reported[0])
+	}
+}
+
+// TestWriteErrors_IgnoresClosedHandle verifies a write that raced the
+// close of its handle is not counted as a disk error.
+func TestWriteErrors_IgnoresClosedHandle(t *testing.T) {
+	logger := newTestLogger(t, &LoggerConfig{
+		Filename:         filepath.Join(t.TempDir(), "app.log"),
+		DisableAutoScale: true,
+	})
+	if _, err := logger.Write([]byte("healthy\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	_ = logger.currentFile.Load().Close()
+	_, _ = logger.Write([]byte("raced\n"))
+	if got := logger.Stats().WriteErrors; got != 0 {
+		t.Errorf("WriteErrors = %d after a closed-handle write, want 0", got)
+	}
+}
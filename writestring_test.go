@@ -0,0 +1,122 @@
+// writestring_test.go: Tests for Logger.WriteString
+//
+// Copyright (c) 2025 AGILira
+// Series: an AGILira fragment
+// SPDX-License-Identifier: MPL-2.0
+
+package lethe
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var _ io.StringWriter = (*Logger)(nil)
+
+func TestWriteString_SyncAndAsync(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		name := "sync"
+		if async {
+			name = "async"
+		}
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.log")
+			l := &Logger{Filename: path, Async: async}
+
+			n, err := l.WriteString("first line\n")
+			if err != nil || n != len("first line\n") {
+				t.Fatalf("WriteString: n=%d err=%v", n, err)
+			}
+			if _, err := io.WriteString(l, "via io.WriteString\n"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := l.WriteString(""); err != nil {
+				t.Fatalf("empty WriteString: %v", err)
+			}
+			if err := l.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := readLog(t, path), "first line\nvia io.WriteString\n"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteString_HookDoesNotMutateString(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	l, err := NewWithConfig(&LoggerConfig{
+		Filename: path,
+		// Redacts in place, as hooks are allowed to
+		PreWriteHook: func(data []byte) ([]byte, error) {
+			if i := bytes.Index(data, []byte("secret")); i >= 0 {
+				copy(data[i:], "******")
+			}
+			return data, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := strings.Repeat("x", 8) + " secret\n" // Not a constant: lives on the heap
+	if _, err := l.WriteString(msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(msg, "secret") {
+		t.Fatalf("hook modified the caller's string: %q", msg)
+	}
+	if got := readLog(t, path); got != "xxxxxxxx ******\n" {
+		t.Errorf("file: got %q", got)
+	}
+}
+
+func TestWriteString_NoConversionAlloc(t *testing.T) {
+	l := &Logger{Filename: filepath.Join(t.TempDir(), "test.log"), DisableAutoScale: true}
+	defer func() { _ = l.Close() }()
+
+	msg := strings.Repeat("allocation check ", 4) + "\n"
+	_, _ = l.WriteString(msg) // Open the file outside the measurement
+
+	byteAllocs := testing.AllocsPerRun(100, func() { _, _ = l.Write([]byte(msg)) })
+	stringAllocs := testing.AllocsPerRun(100, func() { _, _ = l.WriteString(msg) })
+	if stringAllocs >= byteAllocs {
+		t.Errorf("WriteString allocs/op = %v, want fewer than Write([]byte(s)) = %v", stringAllocs, byteAllocs)
+	}
+}
+
+// BenchmarkWriteString compares WriteString with the Write([]byte(s))
+// idiom it replaces.
+func BenchmarkWriteString(b *testing.B) {
+	msg := strings.Repeat("benchmark message ", 4) + "\n"
+	for _, async := range []bool{false, true} {
+		mode := "Sync"
+		if async {
+			mode = "Async"
+		}
+		b.Run(mode+"/Bytes", func(b *testing.B) {
+			l := &Logger{Filename: filepath.Join(b.TempDir(), "bench.log"), Async: async}
+			defer func() { _ = l.Close() }()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = l.Write([]byte(msg))
+			}
+		})
+		b.Run(mode+"/String", func(b *testing.B) {
+			l := &Logger{Filename: filepath.Join(b.TempDir(), "bench.log"), Async: async}
+			defer func() { _ = l.Close() }()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = l.WriteString(msg)
+			}
+		})
+	}
+}